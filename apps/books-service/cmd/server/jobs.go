@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/config"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/db"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/scheduler"
+	"gorm.io/gorm"
+)
+
+const jobsUsage = `usage:
+  server jobs list        list background jobs and their schedules
+  server jobs run <name>  run a job once, now`
+
+// newScheduler registers every background job. Runs are guarded by
+// Postgres advisory locks so each job runs on one replica at a time.
+func newScheduler(cfg *config.Config, database *gorm.DB) (*scheduler.Scheduler, error) {
+	sqlDB, err := database.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	sched := scheduler.New(scheduler.NewPostgresLocker(sqlDB))
+
+	holds := repository.NewHoldRepository(database, cfg.HoldOfferTTL)
+	if err := sched.Register(scheduler.ExpireHoldOffers(holds)); err != nil {
+		return nil, err
+	}
+
+	loans := repository.NewLoanRepository(database)
+	if err := sched.Register(scheduler.RemindDueLoans(loans, cfg.LoanReminderLead)); err != nil {
+		return nil, err
+	}
+	if err := sched.Register(scheduler.MarkOverdueLoans(loans)); err != nil {
+		return nil, err
+	}
+
+	retention := repository.NewRetentionRepository(database)
+	if err := sched.Register(scheduler.PurgeClosedRecords(retention, cfg.RetentionPeriod)); err != nil {
+		return nil, err
+	}
+
+	similarities := repository.NewSimilarityRepository(database)
+	if err := sched.Register(scheduler.RefreshBookSimilarities(similarities)); err != nil {
+		return nil, err
+	}
+
+	return sched, nil
+}
+
+// runJobsCommand implements the jobs subcommand, used to inspect and
+// trigger background jobs by hand. It returns the process exit code.
+func runJobsCommand(cfg *config.Config, args []string) int {
+	if len(args) == 0 || (args[0] == "run" && len(args) != 2) || (args[0] != "list" && args[0] != "run") {
+		fmt.Fprintln(os.Stderr, jobsUsage)
+		return 2
+	}
+
+	database := db.ConnectWithRetry(cfg)
+	if err := migrate(database); err != nil {
+		fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+		return 1
+	}
+
+	sched, err := newScheduler(cfg, database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "scheduler: %v\n", err)
+		return 1
+	}
+
+	if args[0] == "list" {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tSCHEDULE")
+		for _, st := range sched.Stats() {
+			fmt.Fprintf(w, "%s\t%s\n", st.Name, st.Schedule)
+		}
+		w.Flush()
+		return 0
+	}
+
+	name := args[1]
+	if err := sched.RunNow(context.Background(), name); err != nil {
+		switch {
+		case errors.Is(err, scheduler.ErrJobNotFound):
+			fmt.Fprintf(os.Stderr, "unknown job %q; see 'server jobs list'\n", name)
+		case errors.Is(err, scheduler.ErrJobLocked):
+			fmt.Fprintf(os.Stderr, "job %q is running on another replica; try again later\n", name)
+		default:
+			fmt.Fprintf(os.Stderr, "job %q failed: %v\n", name, err)
+		}
+		return 1
+	}
+
+	fmt.Printf("job %q finished\n", name)
+	return 0
+}
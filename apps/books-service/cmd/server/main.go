@@ -13,18 +13,33 @@ package main
 // @host      localhost:8080
 // @BasePath  /api
 
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 Access token from auth-service, sent as "Bearer <token>".
+
 import (
+	"context"
+	"log"
+	"net"
+	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/config"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/cover"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/db"
 	docs "github.com/snnyvrz/shelfshare/apps/books-service/internal/docs"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/graph"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/handler"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/rpc"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/storage"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"gorm.io/gorm"
 )
 
 const appVersion = "0.1.0"
@@ -34,6 +49,13 @@ func main() {
 
 	cfg := config.Load()
 
+	if len(os.Args) > 1 && os.Args[1] == "jobs" {
+		os.Exit(runJobsCommand(cfg, os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "search" {
+		os.Exit(runSearchCommand(cfg, os.Args[2:]))
+	}
+
 	gin.SetMode(cfg.GinMode)
 
 	e := gin.Default()
@@ -47,26 +69,180 @@ func main() {
 
 	database := db.ConnectWithRetry(cfg)
 
-	if err := database.AutoMigrate(&model.Author{}, &model.Book{}); err != nil {
+	if err := migrate(database); err != nil {
+		panic(err)
+	}
+
+	blobStore, err := storage.NewFromConfig(context.Background(), cfg)
+	if err != nil {
+		panic(err)
+	}
+	if cfg.BlobBackend == "local" && cfg.BlobPublicURL == "" {
+		e.Static(storage.LocalMediaPath, cfg.BlobLocalDir)
+	}
+	covers := cover.NewService(blobStore, cfg.CoverMaxBytes)
+
+	searchIndex, err := newSearchIndex(context.Background(), cfg, database)
+	if err != nil {
 		panic(err)
 	}
+	withSearch := repository.WithSearchIndex(searchIndex)
 
 	healthHandler := handler.NewHealthHandler(database, startTime, appVersion)
 	healthHandler.RegisterRoutes(e)
 
-	api := e.Group("/api")
+	verifier := auth.NewVerifier(cfg.JWTSecret)
+	// Replicas with the scheduler off build no jobs and serve no /jobs.
+	if cfg.SchedulerEnabled {
+		sched, err := newScheduler(cfg, database)
+		if err != nil {
+			panic(err)
+		}
+		sched.Start(context.Background())
+		handler.NewJobsHandler(sched).RegisterRoutes(e.Group("", verifier.Middleware()))
+	}
+
+	api := e.Group("/api", verifier.Middleware())
 	{
-		bookRepo := repository.NewGormBookRepository(database)
-		authorRepo := repository.NewAuthorRepository(database)
+		bookRepo := repository.NewGormBookRepository(database, withSearch)
+		authorRepo := repository.NewAuthorRepository(database, withSearch)
 
-		bookHandler := handler.NewBookHandler(bookRepo)
+		bookHandler := handler.NewBookHandler(bookRepo,
+			handler.WithCovers(covers),
+			handler.WithSimilarBooks(repository.NewSimilarityRepository(database)),
+		)
 		authorHandler := handler.NewAuthorHandler(authorRepo)
+		coverHandler := handler.NewCoverHandler(bookRepo, covers)
+		tagHandler := handler.NewTagHandler(repository.NewTagRepository(database))
+		seriesHandler := handler.NewSeriesHandler(repository.NewSeriesRepository(database))
+		workHandler := handler.NewWorkHandler(repository.NewWorkRepository(database))
+		publisherHandler := handler.NewPublisherHandler(repository.NewPublisherRepository(database))
+		shelfRepo := repository.NewShelfRepository(database)
+		shelfHandler := handler.NewShelfHandler(shelfRepo)
+		reviewHandler := handler.NewReviewHandler(repository.NewReviewRepository(database), bookRepo)
+		progressHandler := handler.NewProgressHandler(repository.NewProgressRepository(database), bookRepo)
+		holdRepo := repository.NewHoldRepository(database, cfg.HoldOfferTTL)
+		copyHandler := handler.NewCopyHandler(repository.NewCopyRepository(database, repository.WithHoldOffers(cfg.HoldOfferTTL)))
+		holdHandler := handler.NewHoldHandler(holdRepo)
+		notificationHandler := handler.NewNotificationHandler(repository.NewNotificationRepository(database))
+		groupHandler := handler.NewGroupHandler(repository.NewGroupRepository(database))
+		followHandler := handler.NewFollowHandler(repository.NewFollowRepository(database))
+		feedHandler := handler.NewFeedHandler(repository.NewActivityRepository(database))
+		statsHandler := handler.NewStatsHandler(repository.NewStatsRepository(database, cfg.StatsCacheTTL, withSearch))
+		suggestHandler := handler.NewSuggestHandler(repository.NewSuggestRepository(database))
+
+		graphServer, err := graph.NewServer(bookRepo, authorRepo, repository.NewReviewRepository(database), shelfRepo,
+			graph.WithCovers(covers),
+		)
+		if err != nil {
+			panic(err)
+		}
+		graphQLHandler := handler.NewGraphQLHandler(graphServer)
 
 		bookHandler.RegisterRoutes(api)
 		authorHandler.RegisterRoutes(api)
+		coverHandler.RegisterRoutes(api)
+		tagHandler.RegisterRoutes(api)
+		seriesHandler.RegisterRoutes(api)
+		workHandler.RegisterRoutes(api)
+		publisherHandler.RegisterRoutes(api)
+		shelfHandler.RegisterRoutes(api)
+		reviewHandler.RegisterRoutes(api)
+		progressHandler.RegisterRoutes(api)
+		copyHandler.RegisterRoutes(api)
+		holdHandler.RegisterRoutes(api)
+		notificationHandler.RegisterRoutes(api)
+		groupHandler.RegisterRoutes(api)
+		followHandler.RegisterRoutes(api)
+		feedHandler.RegisterRoutes(api)
+		statsHandler.RegisterRoutes(api)
+		suggestHandler.RegisterRoutes(api)
+		graphQLHandler.RegisterRoutes(api)
+		// GraphQL is also served from the root, where GraphQL clients
+		// look for it by default.
+		graphQLHandler.RegisterRoutes(e.Group("", verifier.Middleware()))
 	}
 
 	e.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	grpcListener, err := net.Listen("tcp", "0.0.0.0:"+cfg.GRPCPort)
+	if err != nil {
+		panic(err)
+	}
+	grpcServer := rpc.NewServer(verifier,
+		repository.NewGormBookRepository(database, withSearch),
+		repository.NewAuthorRepository(database, withSearch),
+		rpc.WithCovers(covers),
+	)
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatalf("grpc server stopped: %v", err)
+		}
+	}()
+
 	e.Run("0.0.0.0:8080")
 }
+
+func migrate(database *gorm.DB) error {
+	// Fuzzy book search scores matches with pg_trgm.
+	if err := database.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return err
+	}
+
+	if err := database.AutoMigrate(&model.Author{}, &model.Series{}, &model.Work{}, &model.WorkContributor{}, &model.Publisher{}, &model.Book{}, &model.Tag{}, &model.Shelf{}, &model.ShelfEntry{}, &model.Review{}, &model.ProgressEntry{}, &model.Copy{}, &model.Hold{}, &model.Notification{}, &model.NotificationPreference{}, &model.Group{}, &model.GroupMember{}, &model.GroupInvite{}, &model.CopyGroup{}, &model.Follow{}, &model.AccountSettings{}, &model.Block{}, &model.BookSimilarity{}, &model.Activity{}); err != nil {
+		return err
+	}
+
+	if err := migratePublisherNames(database); err != nil {
+		return err
+	}
+
+	// Autocomplete matches the start of titles and author names, ignoring
+	// case, and fuzzy search any part of them.
+	for _, index := range []string{
+		"CREATE INDEX IF NOT EXISTS idx_books_title_prefix ON books (LOWER(title) text_pattern_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_authors_name_prefix ON authors (LOWER(name) text_pattern_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_books_title_trgm ON books USING gin (title gin_trgm_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_authors_name_trgm ON authors USING gin (name gin_trgm_ops)",
+	} {
+		if err := database.Exec(index).Error; err != nil {
+			return err
+		}
+	}
+
+	// Copies shared with groups before visibility existed default to
+	// public; keep them groups-only.
+	return database.Exec(
+		"UPDATE copies SET visibility = ? WHERE visibility = ? AND EXISTS (SELECT 1 FROM copy_groups cg WHERE cg.copy_id = copies.id)",
+		model.VisibilityGroups, model.VisibilityPublic,
+	).Error
+}
+
+// migratePublisherNames moves books from the old free-text publisher
+// column to publisher rows, creating one per distinct name, and then drops
+// the column. It does nothing once the column is gone.
+func migratePublisherNames(database *gorm.DB) error {
+	if !database.Migrator().HasColumn(&model.Book{}, "publisher") {
+		return nil
+	}
+
+	return database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(
+			"INSERT INTO publishers (id, name, created_at, updated_at)" +
+				" SELECT gen_random_uuid(), name, NOW(), NOW() FROM (" +
+				"SELECT DISTINCT TRIM(publisher) AS name FROM books WHERE TRIM(COALESCE(publisher, '')) <> ''" +
+				") AS legacy ON CONFLICT (name) DO NOTHING",
+		).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(
+			"UPDATE books SET publisher_id = publishers.id FROM publishers" +
+				" WHERE books.publisher_id IS NULL AND publishers.name = TRIM(books.publisher)",
+		).Error; err != nil {
+			return err
+		}
+
+		return tx.Migrator().DropColumn(&model.Book{}, "publisher")
+	})
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/config"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/db"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/search"
+	"gorm.io/gorm"
+)
+
+const searchUsage = `usage:
+  server search reindex   rebuild the search index from every book
+
+With SEARCH_BACKEND=embedded, stop the server before reindexing: it holds
+the index file open and would not see the rebuilt one.`
+
+// newSearchIndex opens the backend SEARCH_BACKEND selects. An embedded
+// index that is empty, as on first start, is built from the books table.
+func newSearchIndex(ctx context.Context, cfg *config.Config, database *gorm.DB) (repository.SearchIndex, error) {
+	switch cfg.SearchBackend {
+	case "database", "":
+		return repository.NewSQLSearchIndex(), nil
+	case "embedded":
+		ix, err := search.Open(cfg.SearchIndexPath)
+		if err != nil {
+			return nil, err
+		}
+		index := repository.NewEmbeddedSearchIndex(ix)
+		if ix.Len() == 0 {
+			n, err := index.Reindex(ctx, database)
+			if err != nil {
+				return nil, fmt.Errorf("build search index: %w", err)
+			}
+			log.Printf("built search index with %d books", n)
+		}
+		return index, nil
+	default:
+		return nil, fmt.Errorf("unknown SEARCH_BACKEND %q", cfg.SearchBackend)
+	}
+}
+
+// runSearchCommand implements the search subcommand. It returns the
+// process exit code.
+func runSearchCommand(cfg *config.Config, args []string) int {
+	if len(args) != 1 || args[0] != "reindex" {
+		fmt.Fprintln(os.Stderr, searchUsage)
+		return 2
+	}
+
+	database := db.ConnectWithRetry(cfg)
+	if err := migrate(database); err != nil {
+		fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	index, err := newSearchIndex(ctx, cfg, database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "search index: %v\n", err)
+		return 1
+	}
+
+	n, err := index.Reindex(ctx, database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reindex failed: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("indexed %d books (%s backend)\n", n, cfg.SearchBackend)
+	return 0
+}
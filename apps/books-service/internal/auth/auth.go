@@ -0,0 +1,139 @@
+// Package auth verifies the access tokens issued by auth-service and exposes
+// the calling user to handlers.
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+)
+
+const userContextKey = "auth.user"
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// User is the caller identified by a token. ID is the auth-service user id
+// (a Mongo ObjectId string), which is what per-user rows are keyed by.
+type User struct {
+	ID    string
+	Email string
+}
+
+// Claims mirrors the payload auth-service signs: the user id in sub plus email.
+type Claims struct {
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+type Verifier struct {
+	secret []byte
+}
+
+func NewVerifier(secret string) *Verifier {
+	return &Verifier{secret: []byte(secret)}
+}
+
+func (v *Verifier) Parse(token string) (User, error) {
+	var claims Claims
+
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		return v.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || claims.Subject == "" {
+		return User{}, ErrInvalidToken
+	}
+
+	return User{ID: claims.Subject, Email: claims.Email}, nil
+}
+
+// Middleware attaches the caller to the context when a bearer token is sent.
+// Requests without an Authorization header pass through anonymously so public
+// routes keep working; a malformed or invalid token is rejected with 401.
+func (v *Verifier) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		scheme, token, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			abortUnauthorized(c, "INVALID_AUTHORIZATION", "authorization header must be: Bearer <token>")
+			return
+		}
+
+		user, err := v.Parse(token)
+		if err != nil {
+			abortUnauthorized(c, "INVALID_TOKEN", err.Error())
+			return
+		}
+
+		c.Set(userContextKey, user)
+		c.Request = c.Request.WithContext(ContextWithUser(c.Request.Context(), user))
+		c.Next()
+	}
+}
+
+// Required rejects anonymous requests. It must run after Verifier.Middleware.
+func Required() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := UserFrom(c); !ok {
+			abortUnauthorized(c, "UNAUTHORIZED", "authentication required")
+			return
+		}
+		c.Next()
+	}
+}
+
+type ctxKey struct{}
+
+// ContextWithUser returns a copy of ctx carrying user, so code below the
+// handlers, such as repositories applying visibility rules, knows who is
+// asking.
+func ContextWithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, ctxKey{}, user)
+}
+
+// UserFromContext returns the user stored by ContextWithUser.
+func UserFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(ctxKey{}).(User)
+	return user, ok
+}
+
+func UserFrom(c *gin.Context) (User, bool) {
+	v, ok := c.Get(userContextKey)
+	if !ok {
+		return User{}, false
+	}
+	user, ok := v.(User)
+	return user, ok
+}
+
+// SignToken issues a token in the same shape as auth-service. The service
+// itself never signs tokens; this exists for tests and local tooling.
+func SignToken(secret string, user User, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		Email: user.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+func abortUnauthorized(c *gin.Context, code, message string) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, validation.ErrorResponse{
+		Code:    code,
+		Message: message,
+	})
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testSecret = "test_secret"
+
+func setupAuthRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(NewVerifier(testSecret).Middleware())
+
+	r.GET("/public", func(c *gin.Context) {
+		user, _ := UserFrom(c)
+		c.String(http.StatusOK, user.ID)
+	})
+	r.GET("/private", Required(), func(c *gin.Context) {
+		user, _ := UserFrom(c)
+		c.String(http.StatusOK, user.ID)
+	})
+	return r
+}
+
+func doAuthRequest(r *gin.Engine, path, header string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if header != "" {
+		req.Header.Set("Authorization", header)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware(t *testing.T) {
+	r := setupAuthRouter()
+
+	valid, err := SignToken(testSecret, User{ID: "6563a1f0c2a4b5d6e7f80911", Email: "a@example.com"}, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	expired, err := SignToken(testSecret, User{ID: "6563a1f0c2a4b5d6e7f80911"}, -time.Minute)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	forged, err := SignToken("other_secret", User{ID: "6563a1f0c2a4b5d6e7f80911"}, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		header   string
+		wantCode int
+		wantBody string
+	}{
+		{"anonymous public", "/public", "", http.StatusOK, ""},
+		{"anonymous private", "/private", "", http.StatusUnauthorized, ""},
+		{"valid token", "/private", "Bearer " + valid, http.StatusOK, "6563a1f0c2a4b5d6e7f80911"},
+		{"expired token", "/public", "Bearer " + expired, http.StatusUnauthorized, ""},
+		{"wrong secret", "/private", "Bearer " + forged, http.StatusUnauthorized, ""},
+		{"wrong scheme", "/public", "Basic abc", http.StatusUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doAuthRequest(r, tt.path, tt.header)
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d, body=%s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantCode == http.StatusOK && w.Body.String() != tt.wantBody {
+				t.Fatalf("expected body %q, got %q", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
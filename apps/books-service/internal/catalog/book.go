@@ -0,0 +1,310 @@
+// Package catalog creates, updates and deletes books. The REST, GraphQL
+// and gRPC APIs all go through it, so a book is checked by the same rules
+// whichever way it arrives.
+package catalog
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/cover"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"gorm.io/gorm"
+)
+
+type CreateBookInput struct {
+	Title        string      `json:"title" binding:"required"`
+	AuthorID     uuid.UUID   `json:"author_id" binding:"required,uuid4"`
+	Description  string      `json:"description"`
+	PublishedAt  *model.Date `json:"published_at" swaggertype:"string" example:"2025-11-24"`
+	Tags         []string    `json:"tags" binding:"omitempty,max=20,dive,min=1,max=50"`
+	SeriesID     *uuid.UUID  `json:"series_id"`
+	SeriesVolume *float64    `json:"series_volume" binding:"omitempty,gte=0" example:"2.5"`
+	WorkID       *uuid.UUID  `json:"work_id"`
+	Format       string      `json:"format" binding:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	Language     string      `json:"language" binding:"omitempty,min=2,max=8" example:"en"`
+	PublisherID  *uuid.UUID  `json:"publisher_id"`
+	PageCount    *int        `json:"page_count" binding:"omitempty,min=1"`
+	ISBN         string      `json:"isbn" example:"978-0-441-17271-9"`
+	Visibility   string      `json:"visibility" binding:"omitempty,oneof=public groups private" example:"public"`
+}
+
+// UpdateBookInput sets the fields that aren't nil. An empty series, work
+// or publisher ID clears it, as does a zero published date.
+type UpdateBookInput struct {
+	Title        *string     `json:"title" binding:"omitempty,min=1"`
+	AuthorID     *uuid.UUID  `json:"author_id" binding:"omitempty,uuid4"`
+	Description  *string     `json:"description" binding:"omitempty,max=2000"`
+	PublishedAt  *model.Date `json:"published_at" swaggertype:"string" example:"2025-11-24"`
+	Tags         []string    `json:"tags" binding:"omitempty,max=20,dive,min=1,max=50"`
+	SeriesID     *string     `json:"series_id"`
+	SeriesVolume *float64    `json:"series_volume" binding:"omitempty,gte=0" example:"2.5"`
+	WorkID       *string     `json:"work_id"`
+	Format       *string     `json:"format" binding:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	Language     *string     `json:"language" binding:"omitempty,min=2,max=8" example:"en"`
+	PublisherID  *string     `json:"publisher_id"`
+	PageCount    *int        `json:"page_count" binding:"omitempty,min=1"`
+	ISBN         *string     `json:"isbn" example:"978-0-441-17271-9"`
+	Visibility   *string     `json:"visibility" binding:"omitempty,oneof=public groups private" example:"private"`
+}
+
+func (in UpdateBookInput) Empty() bool {
+	return in.Title == nil && in.AuthorID == nil && in.Description == nil &&
+		in.PublishedAt == nil && in.Tags == nil && in.SeriesID == nil &&
+		in.SeriesVolume == nil && in.WorkID == nil && in.Format == nil &&
+		in.Language == nil && in.PublisherID == nil && in.PageCount == nil &&
+		in.ISBN == nil && in.Visibility == nil
+}
+
+type Service struct {
+	books  repository.BookRepository
+	covers *cover.Service
+}
+
+// NewService returns a Service saving to books. Without covers, deleting
+// a book leaves any cover blobs it had in storage.
+func NewService(books repository.BookRepository, covers *cover.Service) *Service {
+	return &Service{books: books, covers: covers}
+}
+
+// Create saves a new book owned by the caller, if there is one, and
+// returns it with the given relations loaded. Anonymous callers can only
+// create public books.
+func (s *Service) Create(ctx context.Context, in CreateBookInput, include ...string) (*model.Book, error) {
+	if resp := validation.Validate(&in); resp != nil {
+		return nil, validationError(resp)
+	}
+
+	book := model.Book{
+		Title:       in.Title,
+		AuthorID:    in.AuthorID,
+		Description: in.Description,
+		PublishedAt: dateOrNil(in.PublishedAt),
+		Tags:        tagsFromNames(in.Tags),
+		WorkID:      in.WorkID,
+		Format:      in.Format,
+		Language:    in.Language,
+		PublisherID: in.PublisherID,
+		PageCount:   in.PageCount,
+		Visibility:  in.Visibility,
+	}
+
+	if user, ok := auth.UserFromContext(ctx); ok {
+		book.OwnerID = &user.ID
+	} else if in.Visibility != "" && in.Visibility != model.VisibilityPublic {
+		return nil, newError(KindUnauthenticated, "UNAUTHORIZED", "authentication required to limit a book's visibility")
+	}
+
+	if in.ISBN != "" {
+		isbn, err := model.NormalizeISBN(in.ISBN)
+		if err != nil {
+			return nil, invalid("INVALID_ISBN", "isbn must be a valid ISBN-10 or ISBN-13")
+		}
+		book.ISBN = isbn
+	}
+
+	if in.SeriesID != nil {
+		book.SeriesID = in.SeriesID
+		book.SeriesVolume = in.SeriesVolume
+	} else if in.SeriesVolume != nil {
+		return nil, invalid("SERIES_VOLUME_WITHOUT_SERIES", "series_volume requires series_id")
+	}
+
+	if err := s.books.Create(ctx, &book); err != nil {
+		if refErr := referenceError(err); refErr != nil {
+			return nil, refErr
+		}
+		return nil, newError(KindInternal, "BOOK_CREATE_FAILED", "failed to create book")
+	}
+
+	created, err := s.books.FindByID(ctx, book.ID, include...)
+	if err != nil {
+		return nil, newError(KindInternal, "BOOK_FETCH_FAILED", "failed to fetch created book")
+	}
+	return created, nil
+}
+
+// Update applies in to the book and returns it with the given relations
+// loaded. Only its owner can update an owned book, and a book without an
+// owner can't be made anything but public.
+func (s *Service) Update(ctx context.Context, id uuid.UUID, in UpdateBookInput, include ...string) (*model.Book, error) {
+	book, err := s.books.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, newError(KindNotFound, "BOOK_NOT_FOUND", "book not found")
+		}
+		return nil, newError(KindInternal, "BOOK_FETCH_FAILED", "failed to fetch book")
+	}
+
+	if err := checkOwner(ctx, book); err != nil {
+		return nil, err
+	}
+
+	if resp := validation.Validate(&in); resp != nil {
+		return nil, validationError(resp)
+	}
+	if in.Empty() {
+		return nil, invalid("NO_FIELDS_TO_UPDATE", "at least one field must be provided to update")
+	}
+
+	if in.Title != nil {
+		book.Title = *in.Title
+	}
+	if in.AuthorID != nil {
+		book.AuthorID = *in.AuthorID
+	}
+	if in.Description != nil {
+		book.Description = *in.Description
+	}
+	if in.PublishedAt != nil {
+		book.PublishedAt = dateOrNil(in.PublishedAt)
+	}
+	if in.Tags != nil {
+		book.Tags = tagsFromNames(in.Tags)
+	}
+	if in.SeriesID != nil {
+		if book.SeriesID, err = optionalID(*in.SeriesID, "INVALID_SERIES_ID", "series_id must be a valid UUID or empty"); err != nil {
+			return nil, err
+		}
+		if book.SeriesID == nil {
+			book.SeriesVolume = nil
+		}
+	}
+	if in.SeriesVolume != nil {
+		if book.SeriesID == nil {
+			return nil, invalid("SERIES_VOLUME_WITHOUT_SERIES", "series_volume requires series_id")
+		}
+		book.SeriesVolume = in.SeriesVolume
+	}
+	if in.WorkID != nil {
+		if book.WorkID, err = optionalID(*in.WorkID, "INVALID_WORK_ID", "work_id must be a valid UUID or empty"); err != nil {
+			return nil, err
+		}
+	}
+	if in.Format != nil {
+		book.Format = *in.Format
+	}
+	if in.Language != nil {
+		book.Language = *in.Language
+	}
+	if in.PublisherID != nil {
+		if book.PublisherID, err = optionalID(*in.PublisherID, "INVALID_PUBLISHER_ID", "publisher_id must be a valid UUID or empty"); err != nil {
+			return nil, err
+		}
+		book.Publisher = nil
+	}
+	if in.PageCount != nil {
+		book.PageCount = in.PageCount
+	}
+	if in.Visibility != nil && *in.Visibility != book.Visibility {
+		if book.OwnerID == nil {
+			return nil, newError(KindForbidden, "FORBIDDEN", "only the book's owner can change its visibility")
+		}
+		book.Visibility = *in.Visibility
+	}
+	if in.ISBN != nil {
+		if *in.ISBN == "" {
+			book.ISBN = ""
+		} else {
+			isbn, err := model.NormalizeISBN(*in.ISBN)
+			if err != nil {
+				return nil, invalid("INVALID_ISBN", "isbn must be a valid ISBN-10 or ISBN-13")
+			}
+			book.ISBN = isbn
+		}
+	}
+
+	if err := s.books.Update(ctx, book); err != nil {
+		if refErr := referenceError(err); refErr != nil {
+			return nil, refErr
+		}
+		return nil, newError(KindInternal, "BOOK_UPDATE_FAILED", "failed to update book")
+	}
+
+	updated, err := s.books.FindByID(ctx, book.ID, include...)
+	if err != nil {
+		return nil, newError(KindInternal, "BOOK_FETCH_FAILED", "failed to fetch updated book")
+	}
+	return updated, nil
+}
+
+// Delete removes the book, which for an owned book only its owner can do,
+// and then its cover blobs. Failing to remove the blobs is logged rather
+// than reported, as the book is already gone.
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	book, err := s.books.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return newError(KindNotFound, "BOOK_NOT_FOUND", "book not found")
+		}
+		return newError(KindInternal, "BOOK_FETCH_FAILED", "failed to fetch book")
+	}
+	if err := checkOwner(ctx, book); err != nil {
+		return err
+	}
+
+	var coverKey string
+	if s.covers != nil {
+		coverKey = book.CoverKey
+	}
+
+	if err := s.books.Delete(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return newError(KindNotFound, "BOOK_NOT_FOUND", "book not found")
+		}
+		return newError(KindInternal, "BOOK_DELETE_FAILED", "failed to delete book")
+	}
+
+	if coverKey != "" {
+		if err := s.covers.Delete(ctx, coverKey); err != nil {
+			log.Printf("failed to delete cover blobs for book %s: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// checkOwner lets only the owner of an owned book, and never an anonymous
+// caller, change or delete it. Books without an owner stay open to all.
+func checkOwner(ctx context.Context, book *model.Book) error {
+	if book.OwnerID == nil {
+		return nil
+	}
+	if user, ok := auth.UserFromContext(ctx); !ok || *book.OwnerID != user.ID {
+		return newError(KindForbidden, "FORBIDDEN", "only the book's owner can change it")
+	}
+	return nil
+}
+
+func dateOrNil(d *model.Date) *time.Time {
+	if d == nil || d.Time.IsZero() {
+		return nil
+	}
+	t := d.Time
+	return &t
+}
+
+// optionalID parses a UUID, where an empty string means none.
+func optionalID(s, code, message string) (*uuid.UUID, error) {
+	if s == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil, invalid(code, message)
+	}
+	return &id, nil
+}
+
+func tagsFromNames(names []string) []model.Tag {
+	tags := make([]model.Tag, 0, len(names))
+	for _, n := range names {
+		tags = append(tags, model.Tag{Name: n})
+	}
+	return tags
+}
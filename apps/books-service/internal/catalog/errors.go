@@ -0,0 +1,64 @@
+package catalog
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+)
+
+// Kind is the class of an Error, for each API to map to its own status.
+type Kind int
+
+const (
+	KindInvalid Kind = iota + 1
+	KindUnauthenticated
+	KindForbidden
+	KindNotFound
+	KindInternal
+)
+
+// Error is a failure the caller is told about. Code and Message are the
+// ones the REST API reports, and Fields lists the inputs that failed
+// validation.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []validation.FieldError
+}
+
+func (e *Error) Error() string { return e.Message }
+
+func newError(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func invalid(code, message string) *Error {
+	return newError(KindInvalid, code, message)
+}
+
+func validationError(resp *validation.ErrorResponse) *Error {
+	return &Error{Kind: KindInvalid, Code: resp.Code, Message: resp.Message, Fields: resp.Errors}
+}
+
+// referenceError maps foreign key violations on a book's references to
+// the error naming the missing row, or returns nil for other errors.
+func referenceError(err error) *Error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23503" {
+		return nil
+	}
+
+	switch pgErr.ConstraintName {
+	case "fk_authors_books":
+		return invalid("AUTHOR_NOT_FOUND", "author does not exist")
+	case "fk_series_books":
+		return invalid("SERIES_NOT_FOUND", "series does not exist")
+	case "fk_works_editions":
+		return invalid("WORK_NOT_FOUND", "work does not exist")
+	case "fk_publishers_books":
+		return invalid("PUBLISHER_NOT_FOUND", "publisher does not exist")
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -18,6 +20,32 @@ type Config struct {
 	DBPass    string
 	DBName    string
 	DBSSLMode string
+
+	BlobBackend   string
+	BlobLocalDir  string
+	BlobPublicURL string
+	S3Endpoint    string
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+	S3UseSSL      bool
+	CoverMaxBytes int64
+
+	JWTSecret string
+
+	HoldOfferTTL     time.Duration
+	LoanReminderLead time.Duration
+	RetentionPeriod  time.Duration
+
+	StatsCacheTTL time.Duration
+
+	SearchBackend   string
+	SearchIndexPath string
+
+	SchedulerEnabled bool
+
+	GRPCPort string
 }
 
 func findRepoRoot() string {
@@ -61,6 +89,32 @@ func Load() *Config {
 		DBPass:    getenv("DB_PASS", ""),
 		DBName:    getenv("DB_NAME", ""),
 		DBSSLMode: getenv("DB_SSLMODE", "disable"),
+
+		BlobBackend:   getenv("BLOB_BACKEND", "local"),
+		BlobLocalDir:  getenv("BLOB_LOCAL_DIR", "./data/blobs"),
+		BlobPublicURL: getenv("BLOB_PUBLIC_URL", ""),
+		S3Endpoint:    getenv("S3_ENDPOINT", "localhost:9000"),
+		S3Region:      getenv("S3_REGION", "us-east-1"),
+		S3Bucket:      getenv("S3_BUCKET", "shelfshare"),
+		S3AccessKey:   getenv("S3_ACCESS_KEY", ""),
+		S3SecretKey:   getenv("S3_SECRET_KEY", ""),
+		S3UseSSL:      getenvBool("S3_USE_SSL", false),
+		CoverMaxBytes: getenvInt64("COVER_MAX_BYTES", 5<<20),
+
+		JWTSecret: getenv("JWT_SECRET", "dev_secret"),
+
+		HoldOfferTTL:     getenvDuration("HOLD_OFFER_TTL", 48*time.Hour),
+		LoanReminderLead: getenvDuration("LOAN_REMINDER_LEAD", 48*time.Hour),
+		RetentionPeriod:  getenvDuration("RETENTION_PERIOD", 90*24*time.Hour),
+
+		StatsCacheTTL: getenvDuration("STATS_CACHE_TTL", time.Minute),
+
+		SearchBackend:   getenv("SEARCH_BACKEND", "database"),
+		SearchIndexPath: getenv("SEARCH_INDEX_PATH", "./data/search/books.idx"),
+
+		SchedulerEnabled: getenvBool("SCHEDULER_ENABLED", true),
+
+		GRPCPort: getenv("GRPC_PORT", "9090"),
 	}
 
 	return cfg
@@ -85,3 +139,39 @@ func getenv(key, def string) string {
 	}
 	return def
 }
+
+func getenvBool(key string, def bool) bool {
+	if v := os.Getenv(key); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			log.Printf("warning: invalid %s=%q, using default %t", key, v, def)
+			return def
+		}
+		return b
+	}
+	return def
+}
+
+func getenvInt64(key string, def int64) int64 {
+	if v := os.Getenv(key); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			log.Printf("warning: invalid %s=%q, using default %d", key, v, def)
+			return def
+		}
+		return n
+	}
+	return def
+}
+
+func getenvDuration(key string, def time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Printf("warning: invalid %s=%q, using default %s", key, v, def)
+			return def
+		}
+		return d
+	}
+	return def
+}
@@ -0,0 +1,182 @@
+package cover
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/storage"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const DefaultMaxBytes int64 = 5 << 20
+
+var (
+	ErrTooLarge        = errors.New("cover image too large")
+	ErrUnsupportedType = errors.New("unsupported cover content type")
+	ErrInvalidImage    = errors.New("cover image could not be decoded")
+)
+
+// allowedTypes maps the sniffed content type to the extension of the stored original.
+var allowedTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+type ThumbnailSize struct {
+	Name  string
+	Width int
+}
+
+var ThumbnailSizes = []ThumbnailSize{
+	{Name: "small", Width: 128},
+	{Name: "medium", Width: 320},
+	{Name: "large", Width: 640},
+}
+
+type URLs struct {
+	Original   string
+	Thumbnails map[string]string
+}
+
+type Service struct {
+	store    storage.BlobStore
+	maxBytes int64
+}
+
+func NewService(store storage.BlobStore, maxBytes int64) *Service {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	return &Service{store: store, maxBytes: maxBytes}
+}
+
+func (s *Service) MaxBytes() int64 {
+	return s.maxBytes
+}
+
+// Upload validates the image, stores the original plus one JPEG per
+// ThumbnailSizes entry and returns the key of the original.
+func (s *Service) Upload(ctx context.Context, bookID uuid.UUID, r io.Reader) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.maxBytes+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)) > s.maxBytes {
+		return "", ErrTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedTypes[contentType]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	dir := path.Join("covers", bookID.String(), uuid.NewString())
+	key := path.Join(dir, "original"+ext)
+
+	written := make([]string, 0, len(ThumbnailSizes)+1)
+	cleanup := func() {
+		for _, k := range written {
+			_ = s.store.Delete(context.WithoutCancel(ctx), k)
+		}
+	}
+
+	if err := s.store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return "", err
+	}
+	written = append(written, key)
+
+	for _, size := range ThumbnailSizes {
+		thumb, err := encodeThumbnail(img, size.Width)
+		if err != nil {
+			cleanup()
+			return "", err
+		}
+
+		thumbKey := thumbnailKey(key, size.Name)
+		if err := s.store.Put(ctx, thumbKey, bytes.NewReader(thumb), int64(len(thumb)), "image/jpeg"); err != nil {
+			cleanup()
+			return "", err
+		}
+		written = append(written, thumbKey)
+	}
+
+	return key, nil
+}
+
+// Delete removes the original and every thumbnail derived from key.
+func (s *Service) Delete(ctx context.Context, key string) error {
+	if key == "" {
+		return nil
+	}
+
+	var errs []error
+	for _, size := range ThumbnailSizes {
+		if err := s.store.Delete(ctx, thumbnailKey(key, size.Name)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := s.store.Delete(ctx, key); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Service) URLs(key string) URLs {
+	if s == nil || key == "" {
+		return URLs{}
+	}
+
+	thumbs := make(map[string]string, len(ThumbnailSizes))
+	for _, size := range ThumbnailSizes {
+		thumbs[size.Name] = s.store.URL(thumbnailKey(key, size.Name))
+	}
+
+	return URLs{
+		Original:   s.store.URL(key),
+		Thumbnails: thumbs,
+	}
+}
+
+func thumbnailKey(key, name string) string {
+	return path.Join(path.Dir(key), name+".jpg")
+}
+
+func encodeThumbnail(src image.Image, width int) ([]byte, error) {
+	b := src.Bounds()
+	if b.Dx() < width {
+		width = b.Dx()
+	}
+	height := b.Dy() * width / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	// JPEG has no alpha channel, so flatten transparent covers onto white.
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
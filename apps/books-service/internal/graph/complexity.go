@@ -0,0 +1,520 @@
+package graph
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/graph-gophers/graphql-go/ast"
+)
+
+// errSyntax means the query couldn't be read, so its cost is unknown.
+var errSyntax = errors.New("graphql: syntax error")
+
+// queryCost estimates how much work an operation asks for before it runs:
+// each field costs 1 plus the cost of its selections, which counts once
+// per item for fields paged by a first argument.
+func queryCost(schema *ast.Schema, query, operationName string, variables map[string]any) (int, error) {
+	doc, err := parseDocument(query)
+	if err != nil {
+		return 0, err
+	}
+
+	var op *operation
+	for i := range doc.operations {
+		if doc.operations[i].name == operationName || (operationName == "" && len(doc.operations) == 1) {
+			op = &doc.operations[i]
+		}
+	}
+	if op == nil {
+		return 0, errSyntax
+	}
+	root, ok := schema.RootOperationTypes[op.kind]
+	if !ok {
+		return 0, errSyntax
+	}
+
+	c := coster{schema: schema, fragments: doc.fragments, variables: variables, defaults: op.defaults, visiting: map[string]bool{}}
+	return c.cost(root.TypeName(), op.selections), nil
+}
+
+type coster struct {
+	schema    *ast.Schema
+	fragments map[string]fragment
+	variables map[string]any
+	defaults  map[string]int
+	visiting  map[string]bool
+}
+
+func (c *coster) cost(typeName string, selections []selection) int {
+	total := 0
+	for _, sel := range selections {
+		switch {
+		case sel.spread != "":
+			// Cycles are rejected when the query is validated.
+			frag, ok := c.fragments[sel.spread]
+			if !ok || c.visiting[sel.spread] {
+				continue
+			}
+			c.visiting[sel.spread] = true
+			total += c.cost(frag.typeCondition, frag.selections)
+			c.visiting[sel.spread] = false
+		case sel.field == "":
+			inline := typeName
+			if sel.typeCondition != "" {
+				inline = sel.typeCondition
+			}
+			total += c.cost(inline, sel.selections)
+		default:
+			total += 1 + c.fieldCost(typeName, sel)
+		}
+	}
+	return total
+}
+
+// fieldCost is the cost of sel's selections, times its page size.
+func (c *coster) fieldCost(typeName string, sel selection) int {
+	object, ok := c.schema.Types[typeName].(*ast.ObjectTypeDefinition)
+	if !ok {
+		return 0
+	}
+	def := object.Fields.Get(sel.field)
+	if def == nil {
+		return 0
+	}
+
+	items := 1
+	if arg := def.Arguments.Get("first"); arg != nil {
+		items = c.first(sel, arg)
+	}
+	return items * c.cost(namedType(def.Type), sel.selections)
+}
+
+// first is the page size the executor will use for sel: its literal
+// first, else the variable's value or the operation's default for it,
+// else the schema's default.
+func (c *coster) first(sel selection, arg *ast.InputValueDefinition) int {
+	n := 0
+	if arg.Default != nil {
+		n, _ = toInt(arg.Default.Deserialize(nil))
+	}
+	switch v := sel.first.(type) {
+	case int:
+		n = v
+	case variable:
+		if value, ok := c.variables[string(v)]; ok {
+			if i, ok := toInt(value); ok {
+				n = i
+			}
+		} else if d, ok := c.defaults[string(v)]; ok {
+			n = d
+		}
+	}
+	return max(n, 1)
+}
+
+func toInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+func namedType(t ast.Type) string {
+	for {
+		switch w := t.(type) {
+		case *ast.NonNull:
+			t = w.OfType
+		case *ast.List:
+			t = w.OfType
+		case ast.NamedType:
+			return w.TypeName()
+		default:
+			return ""
+		}
+	}
+}
+
+// The parser below reads just enough of a query to cost it: operations,
+// fragments, selections and the first argument. Everything else is
+// skipped, and anything malformed is left for the executor to report.
+
+type document struct {
+	operations []operation
+	fragments  map[string]fragment
+}
+
+type operation struct {
+	kind string
+	name string
+	// defaults are the variables' integer default values.
+	defaults   map[string]int
+	selections []selection
+}
+
+type fragment struct {
+	typeCondition string
+	selections    []selection
+}
+
+type selection struct {
+	// field is set for fields, spread for fragment spreads and neither for
+	// inline fragments.
+	field         string
+	spread        string
+	typeCondition string
+	// first is the field's first argument: an int, a variable or nil.
+	first      any
+	selections []selection
+}
+
+type variable string
+
+type token struct {
+	kind  rune // 'n' name, 'i' int, 'f' float, 's' string, '$', '.', or punctuation
+	value string
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parseDocument(src string) (*document, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	doc := &document{fragments: map[string]fragment{}}
+
+	for !p.done() {
+		t := p.peek()
+		switch {
+		case t.kind == '{':
+			sels, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, operation{kind: "query", selections: sels})
+		case t.kind == 'n' && (t.value == "query" || t.value == "mutation" || t.value == "subscription"):
+			p.pos++
+			op := operation{kind: t.value}
+			if p.peek().kind == 'n' {
+				op.name = p.next().value
+			}
+			if p.peek().kind == '(' {
+				if op.defaults, err = p.variableDefinitions(); err != nil {
+					return nil, err
+				}
+			}
+			if err := p.directives(); err != nil {
+				return nil, err
+			}
+			if op.selections, err = p.selectionSet(); err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case t.kind == 'n' && t.value == "fragment":
+			p.pos++
+			name := p.next()
+			if name.kind != 'n' || p.next().value != "on" {
+				return nil, errSyntax
+			}
+			var frag fragment
+			frag.typeCondition = p.next().value
+			if err := p.directives(); err != nil {
+				return nil, err
+			}
+			if frag.selections, err = p.selectionSet(); err != nil {
+				return nil, err
+			}
+			doc.fragments[name.value] = frag
+		default:
+			return nil, errSyntax
+		}
+	}
+	return doc, nil
+}
+
+func (p *parser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if p.next().kind != '{' {
+		return nil, errSyntax
+	}
+
+	var sels []selection
+	for p.peek().kind != '}' {
+		if p.done() {
+			return nil, errSyntax
+		}
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	p.pos++
+	return sels, nil
+}
+
+func (p *parser) selection() (selection, error) {
+	var sel selection
+	var err error
+
+	if p.peek().kind == '.' {
+		p.pos++
+		if t := p.peek(); t.kind == 'n' && t.value != "on" {
+			sel.spread = p.next().value
+			return sel, p.directives()
+		}
+		if p.peek().value == "on" {
+			p.pos++
+			sel.typeCondition = p.next().value
+		}
+		if err := p.directives(); err != nil {
+			return sel, err
+		}
+		sel.selections, err = p.selectionSet()
+		return sel, err
+	}
+
+	name := p.next()
+	if name.kind != 'n' {
+		return sel, errSyntax
+	}
+	sel.field = name.value
+	if p.peek().kind == ':' {
+		p.pos++
+		if sel.field = p.next().value; sel.field == "" {
+			return sel, errSyntax
+		}
+	}
+
+	if p.peek().kind == '(' {
+		if sel.first, err = p.arguments(); err != nil {
+			return sel, err
+		}
+	}
+	if err := p.directives(); err != nil {
+		return sel, err
+	}
+	if p.peek().kind == '{' {
+		sel.selections, err = p.selectionSet()
+	}
+	return sel, err
+}
+
+// arguments reads an argument list, returning the value of first.
+func (p *parser) arguments() (any, error) {
+	p.pos++
+	var first any
+	for p.peek().kind != ')' {
+		name := p.next()
+		if name.kind != 'n' || p.next().kind != ':' {
+			return nil, errSyntax
+		}
+		t := p.peek()
+		if name.value == "first" {
+			switch t.kind {
+			case 'i':
+				n, err := strconv.Atoi(t.value)
+				if err != nil {
+					return nil, errSyntax
+				}
+				first = n
+			case '$':
+				first = variable(t.value)
+			}
+		}
+		if err := p.skipValue(); err != nil {
+			return nil, err
+		}
+	}
+	p.pos++
+	return first, nil
+}
+
+// variableDefinitions reads an operation's variables, returning the
+// integer defaults among them.
+func (p *parser) variableDefinitions() (map[string]int, error) {
+	p.pos++
+	defaults := map[string]int{}
+	for p.peek().kind != ')' {
+		name := p.next()
+		if name.kind != '$' || name.value == "" || p.next().kind != ':' {
+			return nil, errSyntax
+		}
+		if err := p.skipType(); err != nil {
+			return nil, err
+		}
+		if p.peek().kind == '=' {
+			p.pos++
+			if t := p.peek(); t.kind == 'i' {
+				n, err := strconv.Atoi(t.value)
+				if err != nil {
+					return nil, errSyntax
+				}
+				defaults[name.value] = n
+			}
+			if err := p.skipValue(); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.directives(); err != nil {
+			return nil, err
+		}
+	}
+	p.pos++
+	return defaults, nil
+}
+
+func (p *parser) skipType() error {
+	switch p.peek().kind {
+	case '[':
+		if err := p.skipBalanced('[', ']'); err != nil {
+			return err
+		}
+	case 'n':
+		p.pos++
+	default:
+		return errSyntax
+	}
+	if p.peek().kind == '!' {
+		p.pos++
+	}
+	return nil
+}
+
+func (p *parser) skipValue() error {
+	switch p.peek().kind {
+	case '[':
+		return p.skipBalanced('[', ']')
+	case '{':
+		return p.skipBalanced('{', '}')
+	case 'n', 'i', 'f', 's', '$':
+		p.pos++
+		return nil
+	}
+	return errSyntax
+}
+
+func (p *parser) skipBalanced(open, close rune) error {
+	depth := 0
+	for !p.done() {
+		switch p.next().kind {
+		case open:
+			depth++
+		case close:
+			if depth--; depth == 0 {
+				return nil
+			}
+		}
+	}
+	return errSyntax
+}
+
+func (p *parser) directives() error {
+	for p.peek().kind == '@' {
+		p.pos++
+		if p.next().kind != 'n' {
+			return errSyntax
+		}
+		if p.peek().kind == '(' {
+			if err := p.skipBalanced('(', ')'); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		ch := rune(src[i])
+		switch {
+		case ch == ',' || unicode.IsSpace(ch):
+			i++
+		case ch == '#':
+			for i < len(src) && src[i] != '\n' && src[i] != '\r' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "..."):
+			tokens = append(tokens, token{kind: '.'})
+			i += 3
+		case strings.ContainsRune("!():=@[]{}|&", ch):
+			tokens = append(tokens, token{kind: ch})
+			i++
+		case ch == '$':
+			start := i + 1
+			i = scanName(src, start)
+			tokens = append(tokens, token{kind: '$', value: src[start:i]})
+		case ch == '_' || unicode.IsLetter(ch):
+			start := i
+			i = scanName(src, i)
+			tokens = append(tokens, token{kind: 'n', value: src[start:i]})
+		case ch == '-' || unicode.IsDigit(ch):
+			start := i
+			kind := 'i'
+			for i++; i < len(src) && (unicode.IsDigit(rune(src[i])) || strings.ContainsRune(".eE+-", rune(src[i]))); i++ {
+				if !unicode.IsDigit(rune(src[i])) {
+					kind = 'f'
+				}
+			}
+			tokens = append(tokens, token{kind: kind, value: src[start:i]})
+		case strings.HasPrefix(src[i:], `"""`):
+			end := strings.Index(strings.ReplaceAll(src[i+3:], `\"""`, "xxxx"), `"""`)
+			if end < 0 {
+				return nil, errSyntax
+			}
+			tokens = append(tokens, token{kind: 's'})
+			i += 3 + end + 3
+		case ch == '"':
+			for i++; i < len(src) && src[i] != '"'; i++ {
+				if src[i] == '\\' {
+					i++
+				} else if src[i] == '\n' {
+					return nil, errSyntax
+				}
+			}
+			if i >= len(src) {
+				return nil, errSyntax
+			}
+			tokens = append(tokens, token{kind: 's'})
+			i++
+		default:
+			return nil, errSyntax
+		}
+	}
+	return tokens, nil
+}
+
+func scanName(src string, i int) int {
+	for i < len(src) && (src[i] == '_' || unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i]))) {
+		i++
+	}
+	return i
+}
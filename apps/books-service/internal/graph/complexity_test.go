@@ -0,0 +1,182 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/ast"
+)
+
+func testSchema(t *testing.T) *ast.Schema {
+	t.Helper()
+
+	schema, err := graphql.ParseSchema(schemaSDL, nil, graphql.UseStringDescriptions())
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	return schema.AST()
+}
+
+func TestParseDocument(t *testing.T) {
+	doc, err := parseDocument(`
+	  # a comment, with a "string" in it
+	  query Q($n: Int = 7, $tags: [String!]! = ["a", "b"], $after: String) @cached {
+	    list: books(first: $n, tags: $tags, after: $after, sort: TITLE_ASC) {
+	      edges { node { ...bookFields title(format: {long: true}) } }
+	    }
+	  }
+	  mutation M { deleteBook(id: "1") }
+	  fragment bookFields on Book @skip(if: false) {
+	    ... on Book { author { books(first: 3) { totalCount } } }
+	    description(text: """a "block" string""")
+	  }`)
+	if err != nil {
+		t.Fatalf("parseDocument failed: %v", err)
+	}
+
+	if len(doc.operations) != 2 {
+		t.Fatalf("expected 2 operations, got %d", len(doc.operations))
+	}
+	q, m := doc.operations[0], doc.operations[1]
+	if q.kind != "query" || q.name != "Q" || m.kind != "mutation" || m.name != "M" {
+		t.Fatalf("unexpected operations: %s %s, %s %s", q.kind, q.name, m.kind, m.name)
+	}
+	if len(q.defaults) != 1 || q.defaults["n"] != 7 {
+		t.Fatalf("expected only the int default of $n, got %v", q.defaults)
+	}
+
+	books := q.selections[0]
+	if books.field != "books" || books.first != variable("n") {
+		t.Fatalf("expected the aliased books field paged by $n, got %+v", books)
+	}
+	node := books.selections[0].selections[0]
+	if len(node.selections) != 2 || node.selections[0].spread != "bookFields" || node.selections[1].field != "title" {
+		t.Fatalf("expected a spread and a field under node, got %+v", node.selections)
+	}
+
+	frag, ok := doc.fragments["bookFields"]
+	if !ok || frag.typeCondition != "Book" || len(frag.selections) != 2 {
+		t.Fatalf("expected the bookFields fragment, got %+v", frag)
+	}
+	inline := frag.selections[0]
+	if inline.field != "" || inline.spread != "" || inline.typeCondition != "Book" {
+		t.Fatalf("expected an inline fragment on Book, got %+v", inline)
+	}
+	if first := inline.selections[0].selections[0].first; first != 3 {
+		t.Fatalf("expected a literal first of 3, got %v", first)
+	}
+}
+
+func TestParseDocument_Shorthand(t *testing.T) {
+	doc, err := parseDocument(`{ book(id: "x") { title } }`)
+	if err != nil {
+		t.Fatalf("parseDocument failed: %v", err)
+	}
+	if len(doc.operations) != 1 || doc.operations[0].kind != "query" || doc.operations[0].name != "" {
+		t.Fatalf("expected an anonymous query, got %+v", doc.operations)
+	}
+}
+
+func TestParseDocument_Malformed(t *testing.T) {
+	for _, query := range []string{
+		`{ books { title }`,
+		`{ books(first: ) { title } }`,
+		`{ books(first 2) { title } }`,
+		`query ($n Int) { books { title } }`,
+		`query ($n: Int = ) { books { title } }`,
+		`query (n: Int) { books { title } }`,
+		`{ book(id: "unterminated) { title } }`,
+		`{ books { title } } ~`,
+		`fragment f Book { title }`,
+		`subscriptions { books }`,
+	} {
+		if _, err := parseDocument(query); !errors.Is(err, errSyntax) {
+			t.Errorf("expected a syntax error for %q, got %v", query, err)
+		}
+	}
+}
+
+func TestQueryCost(t *testing.T) {
+	schema := testSchema(t)
+
+	tests := []struct {
+		name      string
+		query     string
+		operation string
+		variables map[string]any
+		want      int
+	}{
+		{"scalar", `{ book(id: "1") { title } }`, "", nil, 2},
+		{"literal first", `{ books(first: 5) { totalCount } }`, "", nil, 1 + 5},
+		{"schema default", `{ books { totalCount } }`, "", nil, 1 + 20},
+		{"supplied variable", `query ($n: Int) { books(first: $n) { totalCount } }`, "", map[string]any{"n": float64(3)}, 1 + 3},
+		{"variable default", `query ($n: Int = 100) { books(first: $n) { totalCount } }`, "", nil, 1 + 100},
+		{"supplied over default", `query ($n: Int = 100) { books(first: $n) { totalCount } }`, "", map[string]any{"n": float64(2)}, 1 + 2},
+		{"unset variable", `query ($n: Int) { books(first: $n) { totalCount } }`, "", nil, 1 + 20},
+		{
+			"nested pages multiply",
+			`query ($n: Int = 100) { books(first: $n) { edges { node { author { books(first: $n) { totalCount } } } } } }`,
+			"", nil,
+			// books + 100 × (edges + node + author + books + 100 × totalCount)
+			1 + 100*(4+100),
+		},
+		{
+			"fragments and inline fragments",
+			`{ books(first: 2) { edges { node { ...f ... on Book { isbn } } } } } fragment f on Book { title }`,
+			"", nil,
+			1 + 2*(1+1+1+1),
+		},
+		{
+			"named operation",
+			`query A { books(first: 2) { totalCount } } query B { books(first: 9) { totalCount } }`,
+			"B", nil,
+			1 + 9,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := queryCost(schema, tt.query, tt.operation, tt.variables)
+			if err != nil {
+				t.Fatalf("queryCost failed: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected cost %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQueryCost_UnknownOperation(t *testing.T) {
+	schema := testSchema(t)
+
+	if _, err := queryCost(schema, `query A { books { totalCount } }`, "B", nil); err == nil {
+		t.Fatal("expected an error for an unknown operation")
+	}
+	if _, err := queryCost(schema, `subscription { books { totalCount } }`, "", nil); err == nil {
+		t.Fatal("expected an error for an operation type the schema lacks")
+	}
+}
+
+func TestServerExec_RejectsVariableDefaultBypass(t *testing.T) {
+	server, err := NewServer(nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	resp := server.Exec(context.Background(), Request{
+		Query: `query Q($n: Int = 100) {
+		  books(first: $n) { edges { node { author { books(first: $n) { edges { node { title } } } } } } }
+		}`,
+	})
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "QUERY_TOO_COMPLEX" {
+		t.Fatalf("expected QUERY_TOO_COMPLEX, got %+v", resp.Errors)
+	}
+
+	// A query the estimator can't read isn't run either.
+	resp = server.Exec(context.Background(), Request{Query: `{ books { title }`})
+	if len(resp.Errors) == 0 || resp.Data != nil {
+		t.Fatalf("expected an unparseable query to be rejected, got %+v", resp)
+	}
+}
@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/catalog"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// errUnauthenticated rejects fields that only make sense for a signed-in
+// caller, with the code of the REST API's 401 responses.
+var errUnauthenticated = newError("UNAUTHORIZED", "authentication required")
+
+// queryError is an error a client can act on. Its code and any field
+// errors are reported in the error's extensions, matching the code and
+// errors of the REST API's error responses.
+type queryError struct {
+	code    string
+	message string
+	fields  []validation.FieldError
+}
+
+func newError(code, message string) *queryError {
+	return &queryError{code: code, message: message}
+}
+
+// validationError reports the input fields that failed validation.
+func validationError(resp *validation.ErrorResponse) *queryError {
+	return &queryError{code: resp.Code, message: resp.Message, fields: resp.Errors}
+}
+
+// catalogError reports an error from the catalog service with its code.
+func catalogError(err error) *queryError {
+	var catErr *catalog.Error
+	if !errors.As(err, &catErr) {
+		return newError("INTERNAL_ERROR", "internal server error")
+	}
+	return &queryError{code: catErr.Code, message: catErr.Message, fields: catErr.Fields}
+}
+
+func (e *queryError) Error() string { return e.message }
+
+func (e *queryError) Extensions() map[string]any {
+	ext := map[string]any{"code": e.code}
+	if len(e.fields) > 0 {
+		ext["errors"] = e.fields
+	}
+	return ext
+}
@@ -0,0 +1,108 @@
+// Package graph serves the GraphQL API. It resolves books, authors,
+// reviews and the caller's shelves through the same repositories as the REST handlers and batches
+// the loads of nested fields per request.
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/catalog"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/cover"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+const (
+	// MaxDepth is how deeply selections may nest.
+	MaxDepth = 10
+	// MaxComplexity caps the estimated cost of an operation, counting one
+	// per field and multiplying nested fields by their page size.
+	MaxComplexity = 5000
+	// maxParallelism caps the resolvers a request runs at once.
+	maxParallelism = 50
+)
+
+type Server struct {
+	resolver *Resolver
+	schema   *graphql.Schema
+}
+
+// Option configures a Server.
+type Option func(*options)
+
+type options struct {
+	covers *cover.Service
+}
+
+// WithCovers removes a book's cover blobs when the book is deleted.
+func WithCovers(covers *cover.Service) Option {
+	return func(o *options) {
+		o.covers = covers
+	}
+}
+
+func NewServer(books repository.BookRepository, authors repository.AuthorRepository, reviews repository.ReviewRepository, shelves repository.ShelfRepository, opts ...Option) (*Server, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	resolver := &Resolver{
+		books:   books,
+		authors: authors,
+		reviews: reviews,
+		shelves: shelves,
+		catalog: catalog.NewService(books, o.covers),
+	}
+	schema, err := graphql.ParseSchema(schemaSDL, resolver,
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(MaxDepth),
+		graphql.MaxParallelism(maxParallelism),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{resolver: resolver, schema: schema}, nil
+}
+
+// Request is a GraphQL request as sent over HTTP.
+type Request struct {
+	Query         string         `json:"query" binding:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Exec runs req, rejecting it unexecuted if it's too complex or its cost
+// can't be estimated.
+func (s *Server) Exec(ctx context.Context, req Request) *graphql.Response {
+	cost, err := queryCost(s.schema.AST(), req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		// Report why the query is invalid where the executor can say.
+		if errs := s.schema.ValidateWithVariables(req.Query, req.Variables); len(errs) > 0 {
+			return &graphql.Response{Errors: errs}
+		}
+		return errorResponse("query complexity could not be determined", "QUERY_TOO_COMPLEX")
+	}
+	if cost > MaxComplexity {
+		return errorResponse(
+			"query complexity "+strconv.Itoa(cost)+" exceeds the limit of "+strconv.Itoa(MaxComplexity),
+			"QUERY_TOO_COMPLEX",
+		)
+	}
+
+	ctx = withLoaders(ctx, newLoaders(s.resolver))
+	return s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+}
+
+func errorResponse(message, code string) *graphql.Response {
+	return &graphql.Response{Errors: []*gqlerrors.QueryError{{
+		Message:    message,
+		Extensions: map[string]any{"code": code},
+	}}}
+}
@@ -0,0 +1,177 @@
+package graph
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+)
+
+const (
+	// loaderWait is how long a loader collects keys before fetching them.
+	// Resolvers for the items of a list run concurrently, so their loads
+	// land in the same batch.
+	loaderWait = 2 * time.Millisecond
+	// maxBatch caps the keys fetched at once.
+	maxBatch = 100
+)
+
+// loader batches the loads made within loaderWait of each other into one
+// fetch and caches the results for the rest of the request.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu    sync.Mutex
+	batch *batch[K, V]
+	done  map[K]*batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	started bool
+	values  map[K]V
+	err     error
+	ready   chan struct{}
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{fetch: fetch, done: make(map[K]*batch[K, V])}
+}
+
+// Load returns the value for key, or the zero value if the fetch found
+// none.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	b, ok := l.done[key]
+	if !ok {
+		if l.batch == nil {
+			l.batch = &batch[K, V]{ready: make(chan struct{})}
+			pending := l.batch
+			time.AfterFunc(loaderWait, func() { l.run(ctx, pending) })
+		}
+		b = l.batch
+		b.keys = append(b.keys, key)
+		l.done[key] = b
+		if len(b.keys) >= maxBatch {
+			l.batch = nil
+			go l.run(ctx, b)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-b.ready:
+		return b.values[key], b.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// run fetches b's keys, unless it filled up and went early.
+func (l *loader[K, V]) run(ctx context.Context, b *batch[K, V]) {
+	l.mu.Lock()
+	if b.started {
+		l.mu.Unlock()
+		return
+	}
+	b.started = true
+	if l.batch == b {
+		l.batch = nil
+	}
+	l.mu.Unlock()
+
+	b.values, b.err = l.fetch(ctx, b.keys)
+	close(b.ready)
+}
+
+// pageKey identifies one page of a connection under a parent.
+type pageKey struct {
+	parent uuid.UUID
+	first  int
+	offset int
+}
+
+// loaders holds the loaders of one request.
+type loaders struct {
+	authors      *loader[uuid.UUID, *model.Author]
+	authorBooks  *loader[pageKey, repository.BookListResult]
+	bookReviews  *loader[pageKey, repository.ReviewListResult]
+	shelfEntries *loader[pageKey, repository.ShelfEntryListResult]
+}
+
+func newLoaders(r *Resolver) *loaders {
+	return &loaders{
+		authors: newLoader(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*model.Author, error) {
+			authors, err := r.authors.FindByIDs(ctx, ids, []string{}...)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uuid.UUID]*model.Author, len(authors))
+			for i := range authors {
+				byID[authors[i].ID] = &authors[i]
+			}
+			return byID, nil
+		}),
+		authorBooks: newLoader(func(ctx context.Context, keys []pageKey) (map[pageKey]repository.BookListResult, error) {
+			return loadPages(ctx, keys, func(ctx context.Context, parents []uuid.UUID, first, offset int) (map[uuid.UUID]repository.BookListResult, error) {
+				return r.books.ListByAuthors(ctx, parents, repository.BookListParams{
+					PageSize: first,
+					Offset:   offset,
+					Include:  []string{repository.IncludeTags},
+				})
+			})
+		}),
+		bookReviews: newLoader(func(ctx context.Context, keys []pageKey) (map[pageKey]repository.ReviewListResult, error) {
+			return loadPages(ctx, keys, func(ctx context.Context, parents []uuid.UUID, first, offset int) (map[uuid.UUID]repository.ReviewListResult, error) {
+				return r.reviews.ListByBooks(ctx, parents, repository.ReviewListParams{PageSize: first, Offset: offset})
+			})
+		}),
+		shelfEntries: newLoader(func(ctx context.Context, keys []pageKey) (map[pageKey]repository.ShelfEntryListResult, error) {
+			return loadPages(ctx, keys, func(ctx context.Context, parents []uuid.UUID, first, offset int) (map[uuid.UUID]repository.ShelfEntryListResult, error) {
+				return r.shelves.EntriesByShelves(ctx, parents, repository.ShelfEntryListParams{PageSize: first, Offset: offset})
+			})
+		}),
+	}
+}
+
+// loadPages fetches pages of a connection with one query per distinct
+// page size and offset, which in practice is one query.
+func loadPages[V any](
+	ctx context.Context,
+	keys []pageKey,
+	fetch func(ctx context.Context, parents []uuid.UUID, first, offset int) (map[uuid.UUID]V, error),
+) (map[pageKey]V, error) {
+	type window struct{ first, offset int }
+	parents := make(map[window][]uuid.UUID)
+	for _, k := range keys {
+		w := window{k.first, k.offset}
+		parents[w] = append(parents[w], k.parent)
+	}
+
+	pages := make(map[pageKey]V, len(keys))
+	for w, ids := range parents {
+		byParent, err := fetch(ctx, ids, w.first, w.offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			pages[pageKey{id, w.first, w.offset}] = byParent[id]
+		}
+	}
+	return pages, nil
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	l, _ := ctx.Value(loadersKey{}).(*loaders)
+	return l
+}
@@ -0,0 +1,352 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/catalog"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"gorm.io/gorm"
+)
+
+const dateLayout = "2006-01-02"
+
+// Resolver resolves the Query and Mutation fields. Like the REST handlers
+// it reads the caller from the request context, so visibility rules apply
+// to everything it loads.
+type Resolver struct {
+	books   repository.BookRepository
+	authors repository.AuthorRepository
+	reviews repository.ReviewRepository
+	shelves repository.ShelfRepository
+	catalog *catalog.Service
+}
+
+func (r *Resolver) Book(ctx context.Context, args struct{ ID graphql.ID }) (*bookResolver, error) {
+	id, err := parseID(args.ID, "INVALID_BOOK_ID", "invalid book id")
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := r.books.FindByID(ctx, id, repository.IncludeTags)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, newError("BOOK_FETCH_FAILED", "failed to fetch book")
+	}
+	return &bookResolver{b: *book}, nil
+}
+
+type booksArgs struct {
+	First    int32
+	After    *string
+	Q        *string
+	AuthorID *graphql.ID
+	Tags     *[]string
+	Sort     string
+}
+
+func (r *Resolver) Books(ctx context.Context, args booksArgs) (*bookConnection, error) {
+	first, offset, err := pageArgs{First: args.First, After: args.After}.window()
+	if err != nil {
+		return nil, err
+	}
+
+	params := repository.BookListParams{
+		PageSize: first,
+		Offset:   offset,
+		Include:  []string{repository.IncludeTags},
+	}
+	if args.Q != nil {
+		params.Query = *args.Q
+	}
+	if args.AuthorID != nil {
+		id, err := parseID(*args.AuthorID, "INVALID_AUTHOR_ID", "authorId must be a valid UUID")
+		if err != nil {
+			return nil, err
+		}
+		params.AuthorID = &id
+	}
+	if args.Tags != nil {
+		params.Tags = *args.Tags
+	}
+	params.Sort = strings.ToLower(args.Sort)
+
+	result, err := r.books.List(ctx, params)
+	if err != nil {
+		return nil, newError("BOOK_LIST_FAILED", "failed to list books")
+	}
+	return bookPage(result, offset), nil
+}
+
+func (r *Resolver) Author(ctx context.Context, args struct{ ID graphql.ID }) (*authorResolver, error) {
+	id, err := parseID(args.ID, "AUTHOR_INVALID_ID", "invalid author id")
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := r.authors.FindByID(ctx, id, []string{}...)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, newError("AUTHOR_FETCH_FAILED", "failed to fetch author")
+	}
+	return &authorResolver{a: *author}, nil
+}
+
+func (r *Resolver) Shelves(ctx context.Context) ([]*shelfResolver, error) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return nil, errUnauthenticated
+	}
+
+	shelves, err := r.shelves.List(ctx, user.ID)
+	if err != nil {
+		return nil, newError("SHELF_LIST_FAILED", "failed to list shelves")
+	}
+
+	res := make([]*shelfResolver, 0, len(shelves))
+	for _, s := range shelves {
+		res = append(res, &shelfResolver{s: model.Shelf{
+			ID:        s.ID,
+			Slug:      s.Slug,
+			Name:      s.Name,
+			Builtin:   s.Builtin,
+			CreatedAt: s.CreatedAt,
+			UpdatedAt: s.UpdatedAt,
+		}})
+	}
+	return res, nil
+}
+
+func (r *Resolver) Shelf(ctx context.Context, args struct{ Ref string }) (*shelfResolver, error) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return nil, errUnauthenticated
+	}
+
+	shelf, err := r.shelves.Find(ctx, user.ID, args.Ref)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, newError("SHELF_FETCH_FAILED", "failed to fetch shelf")
+	}
+	return &shelfResolver{s: *shelf}, nil
+}
+
+// The author inputs carry the binding rules of the REST author requests,
+// so both APIs accept the same values. Books are checked by the catalog.
+
+type createAuthorInput struct {
+	Name string  `binding:"required,min=1"`
+	Bio  *string `binding:"omitempty,max=2000"`
+}
+
+type updateAuthorInput struct {
+	Name *string `binding:"omitempty,min=1"`
+	Bio  *string `binding:"omitempty,max=2000"`
+}
+
+func (r *Resolver) CreateAuthor(ctx context.Context, args struct{ Input createAuthorInput }) (*authorResolver, error) {
+	if resp := validation.Validate(&args.Input); resp != nil {
+		return nil, validationError(resp)
+	}
+
+	author := model.Author{Name: args.Input.Name}
+	if args.Input.Bio != nil {
+		author.Bio = *args.Input.Bio
+	}
+
+	if err := r.authors.Create(ctx, &author); err != nil {
+		return nil, newError("AUTHOR_CREATE_FAILED", "failed to create author")
+	}
+	return &authorResolver{a: author}, nil
+}
+
+func (r *Resolver) UpdateAuthor(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input updateAuthorInput
+}) (*authorResolver, error) {
+	id, err := parseID(args.ID, "AUTHOR_INVALID_ID", "invalid author id")
+	if err != nil {
+		return nil, err
+	}
+	if resp := validation.Validate(&args.Input); resp != nil {
+		return nil, validationError(resp)
+	}
+
+	author, err := r.authors.FindByID(ctx, id, []string{}...)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, newError("AUTHOR_NOT_FOUND", "author not found")
+		}
+		return nil, newError("AUTHOR_FETCH_FAILED", "failed to fetch author")
+	}
+
+	if args.Input.Name != nil {
+		author.Name = *args.Input.Name
+	}
+	if args.Input.Bio != nil {
+		author.Bio = *args.Input.Bio
+	}
+
+	if err := r.authors.Update(ctx, author); err != nil {
+		return nil, newError("AUTHOR_UPDATE_FAILED", "failed to update author")
+	}
+	return &authorResolver{a: *author}, nil
+}
+
+type createBookInput struct {
+	Title       string
+	AuthorID    graphql.ID
+	Description *string
+	PublishedAt *string
+	Tags        *[]string
+	Format      *string
+	Language    *string
+	PageCount   *int32
+	ISBN        *string
+	Visibility  *string
+}
+
+type updateBookInput struct {
+	Title       *string
+	AuthorID    *graphql.ID
+	Description *string
+	PublishedAt *string
+	Tags        *[]string
+	Format      *string
+	Language    *string
+	PageCount   *int32
+	ISBN        *string
+	Visibility  *string
+}
+
+func (r *Resolver) CreateBook(ctx context.Context, args struct{ Input createBookInput }) (*bookResolver, error) {
+	in := args.Input
+	authorID, err := parseID(in.AuthorID, "INVALID_AUTHOR_ID", "authorId must be a valid UUID")
+	if err != nil {
+		return nil, err
+	}
+
+	create := catalog.CreateBookInput{
+		Title:       in.Title,
+		AuthorID:    authorID,
+		Description: deref(in.Description),
+		Format:      deref(in.Format),
+		Language:    deref(in.Language),
+		PageCount:   toIntPtr(in.PageCount),
+		ISBN:        deref(in.ISBN),
+		Visibility:  deref(in.Visibility),
+	}
+	if in.PublishedAt != nil {
+		if create.PublishedAt, err = parseDate(*in.PublishedAt); err != nil {
+			return nil, err
+		}
+	}
+	if in.Tags != nil {
+		create.Tags = *in.Tags
+	}
+
+	book, err := r.catalog.Create(ctx, create, repository.IncludeTags)
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return &bookResolver{b: *book}, nil
+}
+
+func (r *Resolver) UpdateBook(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input updateBookInput
+}) (*bookResolver, error) {
+	id, err := parseID(args.ID, "INVALID_BOOK_ID", "invalid book id")
+	if err != nil {
+		return nil, err
+	}
+
+	in := args.Input
+	update := catalog.UpdateBookInput{
+		Title:       in.Title,
+		Description: in.Description,
+		Format:      in.Format,
+		Language:    in.Language,
+		PageCount:   toIntPtr(in.PageCount),
+		ISBN:        in.ISBN,
+		Visibility:  in.Visibility,
+	}
+	if in.AuthorID != nil {
+		authorID, err := parseID(*in.AuthorID, "INVALID_AUTHOR_ID", "authorId must be a valid UUID")
+		if err != nil {
+			return nil, err
+		}
+		update.AuthorID = &authorID
+	}
+	if in.PublishedAt != nil {
+		date, err := parseDate(*in.PublishedAt)
+		if err != nil {
+			return nil, err
+		}
+		// A zero date clears it.
+		if date == nil {
+			date = &model.Date{}
+		}
+		update.PublishedAt = date
+	}
+	if in.Tags != nil {
+		update.Tags = append([]string{}, *in.Tags...)
+	}
+
+	book, err := r.catalog.Update(ctx, id, update, repository.IncludeTags)
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return &bookResolver{b: *book}, nil
+}
+
+func (r *Resolver) DeleteBook(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	id, err := parseID(args.ID, "INVALID_BOOK_ID", "invalid book id")
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.catalog.Delete(ctx, id); err != nil {
+		return false, catalogError(err)
+	}
+	return true, nil
+}
+
+// parseDate reads a YYYY-MM-DD date; an empty string is no date.
+func parseDate(s string) (*model.Date, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return nil, newError("INVALID_PUBLISHED_AT", "publishedAt must be a date as YYYY-MM-DD")
+	}
+	return &model.Date{Time: t}, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func toIntPtr(n *int32) *int {
+	if n == nil {
+		return nil
+	}
+	i := int(*n)
+	return &i
+}
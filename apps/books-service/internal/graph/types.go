@@ -0,0 +1,328 @@
+package graph
+
+import (
+	"context"
+	"encoding/base64"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+)
+
+type bookResolver struct {
+	b model.Book
+}
+
+func (r *bookResolver) ID() graphql.ID      { return graphql.ID(r.b.ID.String()) }
+func (r *bookResolver) Title() string       { return r.b.Title }
+func (r *bookResolver) Description() string { return r.b.Description }
+func (r *bookResolver) Format() *string     { return optional(r.b.Format) }
+func (r *bookResolver) Language() *string   { return optional(r.b.Language) }
+func (r *bookResolver) ISBN() *string       { return optional(r.b.ISBN) }
+func (r *bookResolver) Visibility() string  { return r.b.Visibility }
+func (r *bookResolver) RatingCount() int32  { return int32(r.b.RatingCount) }
+
+func (r *bookResolver) RatingAverage() float64 {
+	return math.Round(r.b.RatingAverage*100) / 100
+}
+
+func (r *bookResolver) PublishedAt() *string {
+	if r.b.PublishedAt == nil || r.b.PublishedAt.IsZero() {
+		return nil
+	}
+	s := r.b.PublishedAt.Format(dateLayout)
+	return &s
+}
+
+func (r *bookResolver) PageCount() *int32 {
+	if r.b.PageCount == nil {
+		return nil
+	}
+	n := int32(*r.b.PageCount)
+	return &n
+}
+
+func (r *bookResolver) Tags() []string {
+	tags := make([]string, 0, len(r.b.Tags))
+	for _, t := range r.b.Tags {
+		tags = append(tags, t.Name)
+	}
+	return tags
+}
+
+func (r *bookResolver) Author(ctx context.Context) (*authorResolver, error) {
+	author, err := loadersFrom(ctx).authors.Load(ctx, r.b.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if author == nil {
+		return nil, newError("AUTHOR_NOT_FOUND", "author not found")
+	}
+	return &authorResolver{a: *author}, nil
+}
+
+func (r *bookResolver) Reviews(ctx context.Context, args pageArgs) (*reviewConnection, error) {
+	first, offset, err := args.window()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := loadersFrom(ctx).bookReviews.Load(ctx, pageKey{r.b.ID, first, offset})
+	if err != nil {
+		return nil, err
+	}
+	return &reviewConnection{reviews: page.Reviews, offset: offset, total: page.Total}, nil
+}
+
+func (r *bookResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.b.CreatedAt} }
+func (r *bookResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.b.UpdatedAt} }
+
+type authorResolver struct {
+	a model.Author
+}
+
+func (r *authorResolver) ID() graphql.ID { return graphql.ID(r.a.ID.String()) }
+func (r *authorResolver) Name() string   { return r.a.Name }
+func (r *authorResolver) Bio() string    { return r.a.Bio }
+
+func (r *authorResolver) Books(ctx context.Context, args pageArgs) (*bookConnection, error) {
+	first, offset, err := args.window()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := loadersFrom(ctx).authorBooks.Load(ctx, pageKey{r.a.ID, first, offset})
+	if err != nil {
+		return nil, err
+	}
+	return &bookConnection{books: page.Books, offset: offset, total: page.Total}, nil
+}
+
+func (r *authorResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.a.CreatedAt} }
+func (r *authorResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.a.UpdatedAt} }
+
+type reviewResolver struct {
+	rv model.Review
+}
+
+func (r *reviewResolver) ID() graphql.ID          { return graphql.ID(r.rv.ID.String()) }
+func (r *reviewResolver) UserID() string          { return r.rv.UserID }
+func (r *reviewResolver) Rating() int32           { return int32(r.rv.Rating) }
+func (r *reviewResolver) Body() string            { return r.rv.Body }
+func (r *reviewResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.rv.CreatedAt} }
+func (r *reviewResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.rv.UpdatedAt} }
+
+type shelfResolver struct {
+	s model.Shelf
+}
+
+func (r *shelfResolver) ID() graphql.ID { return graphql.ID(r.s.ID.String()) }
+func (r *shelfResolver) Slug() string   { return r.s.Slug }
+func (r *shelfResolver) Name() string   { return r.s.Name }
+func (r *shelfResolver) Builtin() bool  { return r.s.Builtin }
+
+func (r *shelfResolver) Books(ctx context.Context, args pageArgs) (*shelfEntryConnection, error) {
+	first, offset, err := args.window()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := loadersFrom(ctx).shelfEntries.Load(ctx, pageKey{r.s.ID, first, offset})
+	if err != nil {
+		return nil, err
+	}
+	return &shelfEntryConnection{entries: page.Entries, offset: offset, total: page.Total}, nil
+}
+
+func (r *shelfResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.s.CreatedAt} }
+func (r *shelfResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.s.UpdatedAt} }
+
+type shelfEntryResolver struct {
+	e model.ShelfEntry
+}
+
+func (r *shelfEntryResolver) Book() *bookResolver       { return &bookResolver{b: r.e.Book} }
+func (r *shelfEntryResolver) AddedAt() graphql.Time     { return graphql.Time{Time: r.e.AddedAt} }
+func (r *shelfEntryResolver) StartedAt() *graphql.Time  { return optionalTime(r.e.StartedAt) }
+func (r *shelfEntryResolver) FinishedAt() *graphql.Time { return optionalTime(r.e.FinishedAt) }
+
+// Connections page by offset: a cursor is the position of its item in the
+// list, so any sort and filter can be paged.
+
+const maxFirst = 100
+
+// pageArgs are a connection's arguments; the schema defaults first.
+type pageArgs struct {
+	First int32
+	After *string
+}
+
+// window turns the page arguments into a page size and an offset.
+func (a pageArgs) window() (first, offset int, err error) {
+	first = int(a.First)
+	if first < 1 || first > maxFirst {
+		return 0, 0, newError("INVALID_FIRST", "first must be between 1 and "+strconv.Itoa(maxFirst))
+	}
+
+	if a.After != nil && *a.After != "" {
+		pos, err := decodeCursor(*a.After)
+		if err != nil {
+			return 0, 0, newError("INVALID_CURSOR", "invalid after cursor")
+		}
+		offset = pos + 1
+	}
+	return first, offset, nil
+}
+
+func encodeCursor(pos int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(pos)))
+}
+
+func decodeCursor(s string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := strings.CutPrefix(string(raw), "offset:")
+	if !ok {
+		return 0, errInvalidCursor
+	}
+	pos, err := strconv.Atoi(n)
+	if err != nil || pos < 0 {
+		return 0, errInvalidCursor
+	}
+	return pos, nil
+}
+
+type pageInfo struct {
+	hasNextPage bool
+	endCursor   *string
+}
+
+func (p pageInfo) HasNextPage() bool  { return p.hasNextPage }
+func (p pageInfo) EndCursor() *string { return p.endCursor }
+
+func newPageInfo(offset, count int, total int64) pageInfo {
+	info := pageInfo{hasNextPage: int64(offset+count) < total}
+	if count > 0 {
+		end := encodeCursor(offset + count - 1)
+		info.endCursor = &end
+	}
+	return info
+}
+
+type bookConnection struct {
+	books  []model.Book
+	offset int
+	total  int64
+}
+
+type bookEdge struct {
+	cursor string
+	node   *bookResolver
+}
+
+func (e bookEdge) Cursor() string      { return e.cursor }
+func (e bookEdge) Node() *bookResolver { return e.node }
+
+func (c *bookConnection) Edges() []bookEdge {
+	edges := make([]bookEdge, 0, len(c.books))
+	for i, b := range c.books {
+		edges = append(edges, bookEdge{cursor: encodeCursor(c.offset + i), node: &bookResolver{b: b}})
+	}
+	return edges
+}
+
+func (c *bookConnection) PageInfo() pageInfo {
+	return newPageInfo(c.offset, len(c.books), c.total)
+}
+
+func (c *bookConnection) TotalCount() int32 { return int32(c.total) }
+
+type reviewConnection struct {
+	reviews []model.Review
+	offset  int
+	total   int64
+}
+
+type reviewEdge struct {
+	cursor string
+	node   *reviewResolver
+}
+
+func (e reviewEdge) Cursor() string        { return e.cursor }
+func (e reviewEdge) Node() *reviewResolver { return e.node }
+
+func (c *reviewConnection) Edges() []reviewEdge {
+	edges := make([]reviewEdge, 0, len(c.reviews))
+	for i, rv := range c.reviews {
+		edges = append(edges, reviewEdge{cursor: encodeCursor(c.offset + i), node: &reviewResolver{rv: rv}})
+	}
+	return edges
+}
+
+func (c *reviewConnection) PageInfo() pageInfo {
+	return newPageInfo(c.offset, len(c.reviews), c.total)
+}
+
+func (c *reviewConnection) TotalCount() int32 { return int32(c.total) }
+
+type shelfEntryConnection struct {
+	entries []model.ShelfEntry
+	offset  int
+	total   int64
+}
+
+type shelfEntryEdge struct {
+	cursor string
+	node   *shelfEntryResolver
+}
+
+func (e shelfEntryEdge) Cursor() string            { return e.cursor }
+func (e shelfEntryEdge) Node() *shelfEntryResolver { return e.node }
+
+func (c *shelfEntryConnection) Edges() []shelfEntryEdge {
+	edges := make([]shelfEntryEdge, 0, len(c.entries))
+	for i, e := range c.entries {
+		edges = append(edges, shelfEntryEdge{cursor: encodeCursor(c.offset + i), node: &shelfEntryResolver{e: e}})
+	}
+	return edges
+}
+
+func (c *shelfEntryConnection) PageInfo() pageInfo {
+	return newPageInfo(c.offset, len(c.entries), c.total)
+}
+
+func (c *shelfEntryConnection) TotalCount() int32 { return int32(c.total) }
+
+// bookPage adapts a List result to a connection.
+func bookPage(result repository.BookListResult, offset int) *bookConnection {
+	return &bookConnection{books: result.Books, offset: offset, total: result.Total}
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
+
+func parseID(id graphql.ID, code, message string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.Nil, newError(code, message)
+	}
+	return parsed, nil
+}
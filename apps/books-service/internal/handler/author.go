@@ -88,13 +88,21 @@ func (h *AuthorHandler) CreateAuthor(c *gin.Context) {
 // @Tags         authors
 // @Accept       json
 // @Produce      json
+// @Param        fields   query     string  false  "Comma-separated fields to return; id is always returned" example(id,name)
+// @Param        include  query     string  false  "Comma-separated relations to load; empty loads none. Defaults to all, or to those named in fields" Enums(books)
 // @Success      200  {array}   AuthorResponse
+// @Failure      400  {object}  validation.ErrorResponse   "Unknown field or include"
 // @Failure      500  {object}  validation.ErrorResponse   "Internal server error"
 // @Router       /authors [get]
 func (h *AuthorHandler) ListAuthors(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	authors, err := h.repo.List(ctx)
+	fields, ok := parseFieldSet(c, Author{}, authorRelationFields)
+	if !ok {
+		return
+	}
+
+	authors, err := h.repo.List(ctx, fields.include...)
 	if err != nil {
 		writeError(c, http.StatusInternalServerError,
 			"AUTHOR_LIST_FAILED",
@@ -103,9 +111,17 @@ func (h *AuthorHandler) ListAuthors(c *gin.Context) {
 		return
 	}
 
-	res := make([]AuthorResponse, 0, len(authors))
+	res := make([]gin.H, 0, len(authors))
 	for _, a := range authors {
-		res = append(res, toAuthorResponse(a))
+		data, err := fields.apply(toAuthorResponse(a).Data)
+		if err != nil {
+			writeError(c, http.StatusInternalServerError,
+				"AUTHOR_LIST_FAILED",
+				"failed to list authors",
+			)
+			return
+		}
+		res = append(res, gin.H{"data": data})
 	}
 
 	c.JSON(http.StatusOK, res)
@@ -117,9 +133,11 @@ func (h *AuthorHandler) ListAuthors(c *gin.Context) {
 // @Tags         authors
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string                    true  "Author ID (UUID)"
+// @Param        id       path      string                    true  "Author ID (UUID)"
+// @Param        fields   query     string                    false  "Comma-separated fields to return; id is always returned" example(id,name)
+// @Param        include  query     string                    false  "Comma-separated relations to load; empty loads none. Defaults to all, or to those named in fields" Enums(books)
 // @Success      200  {object}  AuthorResponse
-// @Failure      400  {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid ID, unknown field or include"
 // @Failure      404  {object}  validation.ErrorResponse  "Author not found"
 // @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
 // @Router       /authors/{id} [get]
@@ -134,9 +152,14 @@ func (h *AuthorHandler) GetAuthorByID(c *gin.Context) {
 		return
 	}
 
+	fields, ok := parseFieldSet(c, Author{}, authorRelationFields)
+	if !ok {
+		return
+	}
+
 	ctx := c.Request.Context()
 
-	author, err := h.repo.FindByID(ctx, id)
+	author, err := h.repo.FindByID(ctx, id, fields.include...)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			writeError(c, http.StatusNotFound,
@@ -153,7 +176,16 @@ func (h *AuthorHandler) GetAuthorByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, toAuthorResponse(*author))
+	data, err := fields.apply(toAuthorResponse(*author).Data)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"AUTHOR_FETCH_FAILED",
+			"failed to fetch author",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": data})
 }
 
 // UpdateAuthor godoc
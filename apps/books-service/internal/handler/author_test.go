@@ -34,20 +34,24 @@ func (f *fakeAuthorRepo) Create(ctx context.Context, a *model.Author) error {
 	return nil
 }
 
-func (f *fakeAuthorRepo) List(ctx context.Context) ([]model.Author, error) {
+func (f *fakeAuthorRepo) List(ctx context.Context, include ...string) ([]model.Author, error) {
 	if f.ListFn != nil {
 		return f.ListFn(ctx)
 	}
 	return nil, nil
 }
 
-func (f *fakeAuthorRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.Author, error) {
+func (f *fakeAuthorRepo) FindByID(ctx context.Context, id uuid.UUID, include ...string) (*model.Author, error) {
 	if f.FindByIDFn != nil {
 		return f.FindByIDFn(ctx, id)
 	}
 	return nil, gorm.ErrRecordNotFound
 }
 
+func (f *fakeAuthorRepo) FindByIDs(ctx context.Context, ids []uuid.UUID, include ...string) ([]model.Author, error) {
+	return nil, nil
+}
+
 func (f *fakeAuthorRepo) Update(ctx context.Context, a *model.Author) error {
 	if f.UpdateFn != nil {
 		return f.UpdateFn(ctx, a)
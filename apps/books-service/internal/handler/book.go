@@ -3,23 +3,48 @@ package handler
 import (
 	"errors"
 	"net/http"
-	"time"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/catalog"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/cover"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
 	"gorm.io/gorm"
 )
 
 type BookHandler struct {
-	repo repository.BookRepository
+	repo         repository.BookRepository
+	catalog      *catalog.Service
+	covers       *cover.Service
+	similarities repository.SimilarityRepository
 }
 
-func NewBookHandler(repo repository.BookRepository) *BookHandler {
-	return &BookHandler{repo: repo}
+type BookHandlerOption func(*BookHandler)
+
+// WithCovers enables cover URLs in responses and blob cleanup on delete.
+func WithCovers(covers *cover.Service) BookHandlerOption {
+	return func(h *BookHandler) {
+		h.covers = covers
+	}
+}
+
+// WithSimilarBooks enables the similar books endpoint.
+func WithSimilarBooks(similarities repository.SimilarityRepository) BookHandlerOption {
+	return func(h *BookHandler) {
+		h.similarities = similarities
+	}
+}
+
+func NewBookHandler(repo repository.BookRepository, opts ...BookHandlerOption) *BookHandler {
+	h := &BookHandler{repo: repo}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.catalog = catalog.NewService(repo, h.covers)
+	return h
 }
 
 func (h *BookHandler) RegisterRoutes(r *gin.RouterGroup) {
@@ -30,144 +55,97 @@ func (h *BookHandler) RegisterRoutes(r *gin.RouterGroup) {
 		books.PATCH("/:id", h.UpdateBook)
 		books.DELETE("/:id", h.DeleteBook)
 		books.POST("", h.CreateBook)
+		if h.similarities != nil {
+			books.GET("/:id/similar", h.ListSimilarBooks)
+		}
 	}
 }
 
 // CreateBook godoc
 // @Summary      Create a book
-// @Description  Create a new book with title, author, description and optional published date
+// @Description  Create a new book with title, author, description and optional published date. With a bearer token the caller becomes the book's owner, who can limit its visibility to members of their groups or to themselves.
 // @Tags         books
 // @Accept       json
 // @Produce      json
-// @Param        payload  body      CreateBookRequest          true  "Book to create"
+// @Param        payload  body      catalog.CreateBookInput    true  "Book to create"
 // @Success      201      {object}  BookResponse
 // @Failure      400      {object}  validation.ErrorResponse   "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse   "Visibility other than public without authentication"
 // @Failure      500      {object}  validation.ErrorResponse   "Internal server error"
 // @Router       /books [post]
 func (h *BookHandler) CreateBook(c *gin.Context) {
-	var req CreateBookRequest
+	var req catalog.CreateBookInput
 	if !validation.BindAndValidateJSON(c, &req) {
 		return
 	}
 
-	var pubAt *time.Time
-	if req.PublishedAt != nil && !req.PublishedAt.Time.IsZero() {
-		t := req.PublishedAt.Time
-		pubAt = &t
-	}
-
-	book := model.Book{
-		Title:       req.Title,
-		AuthorID:    req.AuthorID,
-		Description: req.Description,
-		PublishedAt: pubAt,
-	}
-
-	ctx := c.Request.Context()
-
-	if err := h.repo.Create(ctx, &book); err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			if pgErr.Code == "23503" && pgErr.ConstraintName == "fk_authors_books" {
-				writeError(c, http.StatusBadRequest,
-					"AUTHOR_NOT_FOUND",
-					"author does not exist",
-				)
-				return
-			}
-		}
-
-		writeError(c, http.StatusInternalServerError,
-			"BOOK_CREATE_FAILED",
-			"failed to create book",
-		)
-		return
-	}
-
-	created, err := h.repo.FindByID(ctx, book.ID)
+	created, err := h.catalog.Create(c.Request.Context(), req)
 	if err != nil {
-		writeError(c, http.StatusInternalServerError,
-			"BOOK_FETCH_FAILED",
-			"failed to fetch created book",
-		)
+		writeCatalogError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, toBookResponse(*created))
+	c.JSON(http.StatusCreated, toBookResponse(*created, h.covers))
 }
 
 // ListBooks godoc
 // @Summary      List books
-// @Description  Get all books
+// @Description  Get all books the caller can see; books whose owner limited their visibility are only listed for those allowed to see them
 // @Tags         books
 // @Produce      json
 // @Param        page            query     int     false  "Page number"      default(1) minimum(1)
 // @Param        page_size       query     int     false  "Items per page"   default(20) minimum(1) maximum(100)
-// @Param        sort            query     string  false  "Sort field and direction" Enums(created_at_desc,created_at_asc,title_asc,title_desc,published_at_desc,published_at_asc)
+// @Param        sort            query     string  false  "Sort field and direction; relevance ranks fuzzy matches best first and is the default for fuzzy searches" Enums(created_at_desc,created_at_asc,title_asc,title_desc,published_at_desc,published_at_asc,series_volume_asc,publisher_asc,publisher_desc,rating_desc,relevance)
 // @Param        q               query     string  false  "Full-text search on title and description"
+// @Param        fuzzy           query     bool    false  "Match q against titles and author names by trigram similarity, tolerating typos"
+// @Param        similarity      query     number  false  "Similarity a fuzzy match needs, from 0 to 1" default(0.5)
 // @Param        author_id       query     string  false  "Filter by author ID (UUID)"
+// @Param        series_id       query     string  false  "Filter by series ID (UUID); sorts by volume unless sort is given"
+// @Param        work_id         query     string  false  "Filter to the editions of a work (UUID)"
+// @Param        publisher_id    query     string  false  "Filter by publisher ID (UUID)"
 // @Param        published_after query     string  false  "Filter: published_at >= YYYY-MM-DD" example(2015-01-01)
 // @Param        published_before query    string  false  "Filter: published_at <= YYYY-MM-DD" example(2020-12-31)
+// @Param        decade          query     int     false  "Filter to books published in the decade starting this year" example(1990)
+// @Param        language        query     string  false  "Filter by language code" example(en)
+// @Param        tags            query     string  false  "Comma-separated tag names to filter by" example(scifi,classic)
+// @Param        tags_mode       query     string  false  "Whether books need any or all of the tags" Enums(any,all) default(any)
+// @Param        exclude_tags    query     string  false  "Comma-separated tag names to exclude" example(horror)
+// @Param        min_rating      query     number  false  "Only books whose average rating is at least this" minimum(1) maximum(5)
+// @Param        available       query     bool    false  "Only books with at least one copy available to borrow"
+// @Param        shelf           query     string  false  "Only books on one of the caller's shelves (UUID or slug); requires a bearer token" example(currently-reading)
+// @Param        group_id        query     string  false  "Only books in a group's combined library (UUID); requires a bearer token and membership"
+// @Param        facets          query     string  false  "Comma-separated facets to count over all matching books" example(author,decade,tag,language)
+// @Param        fields          query     string  false  "Comma-separated fields to return for each book; id is always returned" example(id,title,published_at)
+// @Param        include         query     string  false  "Comma-separated relations to load; empty loads none. Defaults to all, or to those named in fields" example(author,tags)
 // @Success      200  {object}   ListBooksResponse
 // @Failure      400  {object}  validation.ErrorResponse   "Invalid query parameters"
+// @Failure      401  {object}  validation.ErrorResponse   "Shelf or group filter without authentication"
+// @Failure      403  {object}  validation.ErrorResponse   "Not a member of the group"
 // @Failure      500  {object}  validation.ErrorResponse   "Internal server error"
 // @Router       /books [get]
 func (h *BookHandler) ListBooks(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	page := parseIntQuery(c, "page", 1)
-	pageSize := parseIntQuery(c, "page_size", 20)
-	if pageSize > 100 {
-		pageSize = 100
-	}
-
-	sort := c.DefaultQuery("sort", "created_at_desc")
-
-	query := c.Query("q")
-
-	var authorIDPtr *uuid.UUID
-	if authorStr := c.Query("author_id"); authorStr != "" {
-		id, err := uuid.Parse(authorStr)
-		if err != nil {
-			writeError(c, http.StatusBadRequest,
-				"INVALID_AUTHOR_ID",
-				"author_id must be a valid UUID",
-			)
-			return
-		}
-		authorIDPtr = &id
-	}
-
-	pubAfter, err := parseDateQuery(c, "published_after")
-	if err != nil {
-		writeError(c, http.StatusBadRequest,
-			"INVALID_PUBLISHED_AFTER",
-			"published_after must be in format YYYY-MM-DD",
-		)
+	params, ok := parseBookListParams(c)
+	if !ok {
 		return
 	}
-
-	pubBefore, err := parseDateQuery(c, "published_before")
-	if err != nil {
-		writeError(c, http.StatusBadRequest,
-			"INVALID_PUBLISHED_BEFORE",
-			"published_before must be in format YYYY-MM-DD",
-		)
+	fields, ok := parseFieldSet(c, Book{}, bookRelationFields)
+	if !ok {
 		return
 	}
-
-	params := repository.BookListParams{
-		Page:      page,
-		PageSize:  pageSize,
-		Sort:      sort,
-		Query:     query,
-		AuthorID:  authorIDPtr,
-		PubAfter:  pubAfter,
-		PubBefore: pubBefore,
-	}
+	params.Include = fields.include
 
 	result, err := h.repo.List(ctx, params)
 	if err != nil {
+		if errors.Is(err, repository.ErrNotGroupMember) {
+			writeError(c, http.StatusForbidden,
+				"NOT_GROUP_MEMBER",
+				"you are not a member of this group",
+			)
+			return
+		}
+
 		writeError(c, http.StatusInternalServerError,
 			"BOOK_LIST_FAILED",
 			"failed to fetch books",
@@ -177,7 +155,7 @@ func (h *BookHandler) ListBooks(c *gin.Context) {
 
 	responses := make([]Book, 0, len(result.Books))
 	for _, b := range result.Books {
-		responses = append(responses, toBookResponse(b).Data)
+		responses = append(responses, toBookResponse(b, h.covers).Data)
 	}
 
 	totalPages := 0
@@ -185,7 +163,31 @@ func (h *BookHandler) ListBooks(c *gin.Context) {
 		totalPages = int((result.Total + int64(params.PageSize) - 1) / int64(params.PageSize))
 	}
 
-	c.JSON(http.StatusOK, toListBooksResponse(responses, params.Page, params.PageSize, result.Total, totalPages))
+	resp := toListBooksResponse(responses, params.Page, params.PageSize, result.Total, totalPages)
+	resp.DidYouMean = result.Suggestions
+	if result.Facets != nil {
+		resp.Facets = make(map[string][]FacetValue, len(result.Facets))
+		for facet, counts := range result.Facets {
+			values := make([]FacetValue, 0, len(counts))
+			for _, fc := range counts {
+				values = append(values, FacetValue{Value: fc.Value, Label: fc.Label, Count: fc.Count})
+			}
+			resp.Facets[facet] = values
+		}
+	}
+
+	data, err := applyAll(fields, resp.Data)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"BOOK_LIST_FAILED",
+			"failed to fetch books",
+		)
+		return
+	}
+	c.JSON(http.StatusOK, struct {
+		ListBooksResponse
+		Data any `json:"data"`
+	}{resp, data})
 }
 
 // GetBookByID godoc
@@ -193,9 +195,11 @@ func (h *BookHandler) ListBooks(c *gin.Context) {
 // @Description  Get a single book by its UUID
 // @Tags         books
 // @Produce      json
-// @Param        id   path      string  true  "Book ID (UUID)"
+// @Param        id       path      string  true   "Book ID (UUID)"
+// @Param        fields   query     string  false  "Comma-separated fields to return; id is always returned" example(id,title,published_at)
+// @Param        include  query     string  false  "Comma-separated relations to load; empty loads none. Defaults to all, or to those named in fields" example(author,tags)
 // @Success      200  {object}  BookResponse
-// @Failure      400  {object}  validation.ErrorResponse   "Invalid ID"
+// @Failure      400  {object}  validation.ErrorResponse   "Invalid ID, unknown field or include"
 // @Failure      404  {object}  validation.ErrorResponse   "Book not found"
 // @Failure      500  {object}  validation.ErrorResponse   "Internal server error"
 // @Router       /books/{id} [get]
@@ -211,9 +215,14 @@ func (h *BookHandler) GetBookByID(c *gin.Context) {
 		return
 	}
 
+	fields, ok := parseFieldSet(c, Book{}, bookRelationFields)
+	if !ok {
+		return
+	}
+
 	ctx := c.Request.Context()
 
-	book, err := h.repo.FindByID(ctx, bookID)
+	book, err := h.repo.FindByID(ctx, bookID, fields.include...)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			writeError(c, http.StatusNotFound,
@@ -230,39 +239,106 @@ func (h *BookHandler) GetBookByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, toBookResponse(*book))
+	data, err := fields.apply(toBookResponse(*book, h.covers).Data)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"BOOK_FETCH_FAILED",
+			"failed to fetch book",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": data})
 }
 
 // UpdateBook godoc
 // @Summary      Update a book
-// @Description  Partially update a book by its UUID
+// @Description  Partially update a book by its UUID. Send series_id, work_id or publisher_id as an empty string to clear them. Only the book's owner can change its visibility.
 // @Tags         books
 // @Accept       json
 // @Produce      json
 // @Param        id       path      string              true  "Book ID (UUID)"
-// @Param        payload  body      UpdateBookRequest   true  "Fields to update"
+// @Param        payload  body      catalog.UpdateBookInput  true  "Fields to update"
 // @Success      200      {object}  BookResponse
 // @Failure      400      {object}  validation.ErrorResponse   "Invalid ID or payload"
+// @Failure      403      {object}  validation.ErrorResponse   "Changing the visibility of a book the caller doesn't own"
 // @Failure      404      {object}  validation.ErrorResponse   "Book not found"
 // @Failure      500      {object}  validation.ErrorResponse   "Internal server error"
 // @Router       /books/{id} [patch]
 func (h *BookHandler) UpdateBook(c *gin.Context) {
-	idParam := c.Param("id")
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
 
-	bookID, err := uuid.Parse(idParam)
+	var req catalog.UpdateBookInput
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	updated, err := h.catalog.Update(c.Request.Context(), bookID, req)
 	if err != nil {
-		writeError(c, http.StatusBadRequest,
-			"INVALID_BOOK_ID",
-			"invalid book id",
-		)
+		writeCatalogError(c, err)
 		return
 	}
 
-	ctx := c.Request.Context()
+	c.JSON(http.StatusOK, toBookResponse(*updated, h.covers))
+}
+
+// DeleteBook godoc
+// @Summary      Delete a book
+// @Description  Delete a book by its UUID
+// @Tags         books
+// @Produce      json
+// @Param        id   path      string  true  "Book ID (UUID)"
+// @Success      204  {string}  string  "No content"
+// @Failure      400  {object}  validation.ErrorResponse   "Invalid ID"
+// @Failure      404  {object}  validation.ErrorResponse   "Book not found"
+// @Failure      500  {object}  validation.ErrorResponse   "Internal server error"
+// @Router       /books/{id} [delete]
+func (h *BookHandler) DeleteBook(c *gin.Context) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.catalog.Delete(c.Request.Context(), bookID); err != nil {
+		writeCatalogError(c, err)
+		return
+	}
 
-	book, err := h.repo.FindByID(ctx, bookID)
+	c.Status(http.StatusNoContent)
+}
+
+// ListSimilarBooks godoc
+// @Summary      List similar books
+// @Description  Get the books most related to a book, scored by shared author, series and tags and by readers who shelved both. Books the caller has read are left out. Scores are refreshed periodically, so new books show up after the next refresh.
+// @Tags         books
+// @Produce      json
+// @Param        id     path      string  true   "Book ID (UUID)"
+// @Param        limit  query     int     false  "Number of books"  default(10) minimum(1) maximum(50)
+// @Success      200    {object}  SimilarBooksResponse
+// @Failure      400    {object}  validation.ErrorResponse   "Invalid ID"
+// @Failure      404    {object}  validation.ErrorResponse   "Book not found"
+// @Failure      500    {object}  validation.ErrorResponse   "Internal server error"
+// @Router       /books/{id}/similar [get]
+func (h *BookHandler) ListSimilarBooks(c *gin.Context) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	limit := parseIntQuery(c, "limit", 10)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	books, err := h.similarities.Similar(c.Request.Context(), bookID, limit)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, repository.ErrBookNotFound) {
 			writeError(c, http.StatusNotFound,
 				"BOOK_NOT_FOUND",
 				"book not found",
@@ -271,102 +347,267 @@ func (h *BookHandler) UpdateBook(c *gin.Context) {
 		}
 
 		writeError(c, http.StatusInternalServerError,
-			"BOOK_FETCH_FAILED",
-			"failed to fetch book",
+			"SIMILAR_BOOKS_FAILED",
+			"failed to fetch similar books",
 		)
 		return
 	}
 
-	var req UpdateBookRequest
-	if !validation.BindAndValidateJSON(c, &req) {
-		return
+	data := make([]Book, 0, len(books))
+	for _, b := range books {
+		data = append(data, toBookResponse(b, h.covers).Data)
 	}
 
-	if req.Title == nil && req.AuthorID == nil &&
-		req.Description == nil && req.PublishedAt == nil {
-		writeError(c, http.StatusBadRequest,
-			"NO_FIELDS_TO_UPDATE",
-			"at least one field must be provided to update",
-		)
+	c.JSON(http.StatusOK, SimilarBooksResponse{Data: data})
+}
+
+// catalogStatuses are the HTTP statuses of catalog error kinds.
+var catalogStatuses = map[catalog.Kind]int{
+	catalog.KindInvalid:         http.StatusBadRequest,
+	catalog.KindUnauthenticated: http.StatusUnauthorized,
+	catalog.KindForbidden:       http.StatusForbidden,
+	catalog.KindNotFound:        http.StatusNotFound,
+}
+
+// writeCatalogError writes the response for an error from the catalog
+// service.
+func writeCatalogError(c *gin.Context, err error) {
+	var catErr *catalog.Error
+	if !errors.As(err, &catErr) {
+		writeError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
 		return
 	}
 
-	if req.Title != nil {
-		book.Title = *req.Title
+	status, ok := catalogStatuses[catErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
 	}
-	if req.AuthorID != nil {
-		book.AuthorID = *req.AuthorID
+	c.AbortWithStatusJSON(status, validation.ErrorResponse{
+		Code:    catErr.Code,
+		Message: catErr.Message,
+		Errors:  catErr.Fields,
+	})
+}
+
+// parseBookListParams reads the filters, sort and page ListBooks accepts
+// from the query string. It writes an error response and returns false
+// when they are invalid.
+func parseBookListParams(c *gin.Context) (repository.BookListParams, bool) {
+	page := parseIntQuery(c, "page", 1)
+	pageSize := parseIntQuery(c, "page_size", 20)
+	if pageSize > 100 {
+		pageSize = 100
 	}
-	if req.Description != nil {
-		book.Description = *req.Description
+
+	query := c.Query("q")
+
+	var authorIDPtr *uuid.UUID
+	if authorStr := c.Query("author_id"); authorStr != "" {
+		id, err := uuid.Parse(authorStr)
+		if err != nil {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_AUTHOR_ID",
+				"author_id must be a valid UUID",
+			)
+			return repository.BookListParams{}, false
+		}
+		authorIDPtr = &id
 	}
-	if req.PublishedAt != nil {
-		if req.PublishedAt.Time.IsZero() {
-			book.PublishedAt = nil
-		} else {
-			t := req.PublishedAt.Time
-			book.PublishedAt = &t
+
+	var seriesIDPtr *uuid.UUID
+	if seriesStr := c.Query("series_id"); seriesStr != "" {
+		id, err := uuid.Parse(seriesStr)
+		if err != nil {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_SERIES_ID",
+				"series_id must be a valid UUID",
+			)
+			return repository.BookListParams{}, false
 		}
+		seriesIDPtr = &id
 	}
 
-	if err := h.repo.Update(ctx, book); err != nil {
-		writeError(c, http.StatusInternalServerError,
-			"BOOK_UPDATE_FAILED",
-			"failed to update book",
-		)
-		return
+	var workIDPtr *uuid.UUID
+	if workStr := c.Query("work_id"); workStr != "" {
+		id, err := uuid.Parse(workStr)
+		if err != nil {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_WORK_ID",
+				"work_id must be a valid UUID",
+			)
+			return repository.BookListParams{}, false
+		}
+		workIDPtr = &id
 	}
 
-	updated, err := h.repo.FindByID(ctx, book.ID)
-	if err != nil {
-		writeError(c, http.StatusInternalServerError,
-			"BOOK_FETCH_FAILED",
-			"failed to fetch updated book",
-		)
-		return
+	var publisherIDPtr *uuid.UUID
+	if publisherStr := c.Query("publisher_id"); publisherStr != "" {
+		id, err := uuid.Parse(publisherStr)
+		if err != nil {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_PUBLISHER_ID",
+				"publisher_id must be a valid UUID",
+			)
+			return repository.BookListParams{}, false
+		}
+		publisherIDPtr = &id
 	}
 
-	c.JSON(http.StatusOK, toBookResponse(*updated))
-}
+	facets := parseListQuery(c, "facets")
+	for _, facet := range facets {
+		switch facet {
+		case repository.FacetAuthor, repository.FacetDecade, repository.FacetTag, repository.FacetLanguage:
+		default:
+			writeError(c, http.StatusBadRequest,
+				"INVALID_FACETS",
+				"facets must be a comma-separated list of: author, decade, tag, language",
+			)
+			return repository.BookListParams{}, false
+		}
+	}
 
-// DeleteBook godoc
-// @Summary      Delete a book
-// @Description  Delete a book by its UUID
-// @Tags         books
-// @Produce      json
-// @Param        id   path      string  true  "Book ID (UUID)"
-// @Success      204  {string}  string  "No content"
-// @Failure      400  {object}  validation.ErrorResponse   "Invalid ID"
-// @Failure      404  {object}  validation.ErrorResponse   "Book not found"
-// @Failure      500  {object}  validation.ErrorResponse   "Internal server error"
-// @Router       /books/{id} [delete]
-func (h *BookHandler) DeleteBook(c *gin.Context) {
-	idParam := c.Param("id")
+	fuzzy := c.Query("fuzzy") == "true"
 
-	bookID, err := uuid.Parse(idParam)
+	var threshold float64
+	if s := c.Query("similarity"); s != "" {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil || v <= 0 || v > 1 {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_SIMILARITY",
+				"similarity must be a number greater than 0 and at most 1",
+			)
+			return repository.BookListParams{}, false
+		}
+		threshold = v
+	}
+
+	defaultSort := "created_at_desc"
+	if seriesIDPtr != nil {
+		defaultSort = "series_volume_asc"
+	} else if fuzzy && query != "" {
+		defaultSort = "relevance"
+	}
+	sort := c.DefaultQuery("sort", defaultSort)
+
+	pubAfter, err := parseDateQuery(c, "published_after")
 	if err != nil {
 		writeError(c, http.StatusBadRequest,
-			"INVALID_BOOK_ID",
-			"invalid book id",
+			"INVALID_PUBLISHED_AFTER",
+			"published_after must be in format YYYY-MM-DD",
 		)
-		return
+		return repository.BookListParams{}, false
 	}
 
-	if err := h.repo.Delete(c.Request.Context(), bookID); err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			writeError(c, http.StatusNotFound,
-				"BOOK_NOT_FOUND",
-				"book not found",
+	pubBefore, err := parseDateQuery(c, "published_before")
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_PUBLISHED_BEFORE",
+			"published_before must be in format YYYY-MM-DD",
+		)
+		return repository.BookListParams{}, false
+	}
+
+	var decade *int
+	if s := c.Query("decade"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v%10 != 0 {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_DECADE",
+				"decade must be a year ending in 0",
 			)
-			return
+			return repository.BookListParams{}, false
 		}
+		decade = &v
+	}
 
-		writeError(c, http.StatusInternalServerError,
-			"BOOK_DELETE_FAILED",
-			"failed to delete book",
+	tagMode := c.DefaultQuery("tags_mode", repository.TagModeAny)
+	if tagMode != repository.TagModeAny && tagMode != repository.TagModeAll {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_TAGS_MODE",
+			"tags_mode must be one of: any, all",
 		)
-		return
+		return repository.BookListParams{}, false
 	}
 
-	c.Status(http.StatusNoContent)
+	var minRating *float64
+	if s := c.Query("min_rating"); s != "" {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil || v < 1 || v > 5 {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_MIN_RATING",
+				"min_rating must be a number between 1 and 5",
+			)
+			return repository.BookListParams{}, false
+		}
+		minRating = &v
+	}
+
+	shelf := c.Query("shelf")
+	var shelfUserID string
+	if shelf != "" {
+		user, ok := auth.UserFrom(c)
+		if !ok {
+			writeError(c, http.StatusUnauthorized,
+				"UNAUTHORIZED",
+				"the shelf filter requires authentication",
+			)
+			return repository.BookListParams{}, false
+		}
+		shelfUserID = user.ID
+	}
+
+	var groupIDPtr *uuid.UUID
+	var groupViewerID string
+	if groupStr := c.Query("group_id"); groupStr != "" {
+		id, err := uuid.Parse(groupStr)
+		if err != nil {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_GROUP_ID",
+				"group_id must be a valid UUID",
+			)
+			return repository.BookListParams{}, false
+		}
+		user, ok := auth.UserFrom(c)
+		if !ok {
+			writeError(c, http.StatusUnauthorized,
+				"UNAUTHORIZED",
+				"the group filter requires authentication",
+			)
+			return repository.BookListParams{}, false
+		}
+		groupIDPtr = &id
+		groupViewerID = user.ID
+	}
+
+	params := repository.BookListParams{
+		Page:        page,
+		PageSize:    pageSize,
+		Sort:        sort,
+		Query:       query,
+		AuthorID:    authorIDPtr,
+		SeriesID:    seriesIDPtr,
+		WorkID:      workIDPtr,
+		PublisherID: publisherIDPtr,
+		PubAfter:    pubAfter,
+		PubBefore:   pubBefore,
+		MinRating:   minRating,
+		Available:   c.Query("available") == "true",
+		Language:    c.Query("language"),
+		Decade:      decade,
+
+		Fuzzy:          fuzzy,
+		FuzzyThreshold: threshold,
+
+		Facets: facets,
+
+		Tags:        parseListQuery(c, "tags"),
+		TagMode:     tagMode,
+		ExcludeTags: parseListQuery(c, "exclude_tags"),
+		Shelf:       shelf,
+		ShelfUserID: shelfUserID,
+
+		GroupID:       groupIDPtr,
+		GroupViewerID: groupViewerID,
+	}
+	return params, true
 }
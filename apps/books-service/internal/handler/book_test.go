@@ -12,6 +12,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/catalog"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
@@ -41,13 +42,21 @@ func (f *fakeBookRepo) List(ctx context.Context, params repository.BookListParam
 	return repository.BookListResult{}, nil
 }
 
-func (f *fakeBookRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.Book, error) {
+func (f *fakeBookRepo) FindByID(ctx context.Context, id uuid.UUID, include ...string) (*model.Book, error) {
 	if f.FindByIDFn != nil {
 		return f.FindByIDFn(ctx, id)
 	}
 	return nil, gorm.ErrRecordNotFound
 }
 
+func (f *fakeBookRepo) FindByIDs(ctx context.Context, ids []uuid.UUID, include ...string) ([]model.Book, error) {
+	return nil, nil
+}
+
+func (f *fakeBookRepo) ListByAuthors(ctx context.Context, authorIDs []uuid.UUID, params repository.BookListParams) (map[uuid.UUID]repository.BookListResult, error) {
+	return map[uuid.UUID]repository.BookListResult{}, nil
+}
+
 func (f *fakeBookRepo) Update(ctx context.Context, b *model.Book) error {
 	if f.UpdateFn != nil {
 		return f.UpdateFn(ctx, b)
@@ -78,7 +87,7 @@ func TestCreateBook_Success(t *testing.T) {
 
 	author := testutil.SeedAuthor(t, db, "Evans")
 
-	body := CreateBookRequest{
+	body := catalog.CreateBookInput{
 		Title:       "Clean Code",
 		AuthorID:    author.ID,
 		Description: "A handbook of Agile software craftsmanship",
@@ -211,7 +220,7 @@ func TestCreateBook_InternalError_Returns500(t *testing.T) {
 
 	router := setupBookRouterWithRepo(bookRepo)
 
-	body := CreateBookRequest{
+	body := catalog.CreateBookInput{
 		Title:       "Error book",
 		AuthorID:    uuid.New(),
 		Description: "Should fail",
@@ -255,7 +264,7 @@ func TestCreateBook_FetchCreatedBookError_Returns500(t *testing.T) {
 
 	router := setupBookRouterWithRepo(bookRepo)
 
-	body := CreateBookRequest{
+	body := catalog.CreateBookInput{
 		Title:       "Book Title",
 		AuthorID:    uuid.New(),
 		Description: "Some description",
@@ -392,6 +401,143 @@ func TestListBooks_InternalError_Returns500(t *testing.T) {
 	}
 }
 
+func TestListBooks_FuzzySearch(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := setupTestRouter(db)
+
+	dostoevsky := testutil.SeedAuthor(t, db, "Fyodor Dostoevsky")
+	rowling := testutil.SeedAuthor(t, db, "J. K. Rowling")
+	testutil.SeedBook(t, db, dostoevsky, "Crime and Punishment", "", nil)
+	testutil.SeedBook(t, db, dostoevsky, "The Brothers Karamazov", "", nil)
+	testutil.SeedBook(t, db, rowling, "Harry Potter and the Philosopher's Stone", "", nil)
+
+	if got := visibleBookTitles(t, router, "", "/books?q=Dostoyevsky&fuzzy=true"); len(got) != 2 {
+		t.Fatalf("expected both books by the author's name, got %v", got)
+	}
+	if got := visibleBookTitles(t, router, "", "/books?q=harry+poter&fuzzy=true"); len(got) != 1 || got[0] != "Harry Potter and the Philosopher's Stone" {
+		t.Fatalf("expected the misspelled title to match, got %v", got)
+	}
+	if got := visibleBookTitles(t, router, "", "/books?q=brothers+karamazof&fuzzy=true&similarity=0.3"); len(got) == 0 || got[0] != "The Brothers Karamazov" {
+		t.Fatalf("expected the closest match ranked first, got %v", got)
+	}
+
+	w := doAuthJSON(router, "", http.MethodGet, "/books?q=Dostoyevsky", nil)
+	var resp ListBooksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(resp.Data) != 0 || len(resp.DidYouMean) == 0 || resp.DidYouMean[0] != "Fyodor Dostoevsky" {
+		t.Fatalf("expected no substring matches and a suggestion, got %+v", resp)
+	}
+
+	if w := doAuthJSON(router, "", http.MethodGet, "/books?q=harry&fuzzy=true&similarity=2", nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for an invalid similarity, got %d", w.Code)
+	}
+}
+
+func TestListBooks_Facets(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := setupTestRouter(db)
+
+	year := func(y int) *time.Time {
+		d := time.Date(y, time.June, 1, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	leGuin := testutil.SeedAuthor(t, db, "Ursula K. Le Guin")
+	lem := testutil.SeedAuthor(t, db, "Stanisław Lem")
+	dispossessed := testutil.SeedBook(t, db, leGuin, "The Dispossessed", "", year(1974))
+	lathe := testutil.SeedBook(t, db, leGuin, "The Lathe of Heaven", "", year(1971))
+	solaris := testutil.SeedBook(t, db, lem, "Solaris", "", year(1961))
+	testutil.SeedBook(t, db, lem, "Untitled", "", nil)
+
+	scifi := model.Tag{Name: "scifi"}
+	if err := db.Create(&scifi).Error; err != nil {
+		t.Fatalf("failed to seed tag: %v", err)
+	}
+	for _, b := range []model.Book{dispossessed, lathe, solaris} {
+		if err := db.Model(&b).Association("Tags").Append(&scifi); err != nil {
+			t.Fatalf("failed to tag book: %v", err)
+		}
+	}
+	if err := db.Model(&model.Book{}).Where("id = ?", solaris.ID).Update("language", "pl").Error; err != nil {
+		t.Fatalf("failed to set language: %v", err)
+	}
+
+	w := doAuthJSON(router, "", http.MethodGet, "/books?page_size=1&facets=author,decade,tag,language", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var resp ListBooksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Pagination.Total != 4 {
+		t.Fatalf("expected facets not to change paging, got %+v", resp)
+	}
+
+	authors := map[string]FacetValue{}
+	for _, v := range resp.Facets["author"] {
+		authors[v.Value] = v
+	}
+	if len(authors) != 2 || authors[leGuin.ID.String()].Label != "Ursula K. Le Guin" || authors[lem.ID.String()].Count != 2 {
+		t.Fatalf("expected both authors with 2 books each, got %+v", resp.Facets["author"])
+	}
+
+	want := map[string][]FacetValue{
+		"decade":   {{Value: "1970", Label: "1970s", Count: 2}, {Value: "1960", Label: "1960s", Count: 1}},
+		"tag":      {{Value: "scifi", Label: "scifi", Count: 3}},
+		"language": {{Value: "pl", Label: "pl", Count: 1}},
+	}
+	for facet, values := range want {
+		got := resp.Facets[facet]
+		if len(got) != len(values) {
+			t.Fatalf("expected %s facet %+v, got %+v", facet, values, got)
+		}
+		for i := range values {
+			if got[i] != values[i] {
+				t.Fatalf("expected %s facet %+v, got %+v", facet, values, got)
+			}
+		}
+	}
+
+	// Facets count under the current filters.
+	w = doAuthJSON(router, "", http.MethodGet, "/books?author_id="+leGuin.ID.String()+"&facets=decade", nil)
+	resp = ListBooksResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if got := resp.Facets["decade"]; len(got) != 1 || got[0].Count != 2 || len(resp.Facets) != 1 {
+		t.Fatalf("expected only the 1970s with 2 books, got %+v", resp.Facets)
+	}
+
+	// Each facet value filters to the books it counted.
+	for query, total := range map[string]int64{
+		"author_id=" + lem.ID.String(): 2,
+		"decade=1970":                  2,
+		"decade=1960&language=pl":      1,
+		"tags=scifi":                   3,
+		"language=en":                  0,
+	} {
+		w = doAuthJSON(router, "", http.MethodGet, "/books?"+query, nil)
+		resp = ListBooksResponse{}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if w.Code != http.StatusOK || resp.Pagination.Total != total {
+			t.Fatalf("expected %d books for %s, got status %d, body=%s", total, query, w.Code, w.Body.String())
+		}
+	}
+
+	if w := doAuthJSON(router, "", http.MethodGet, "/books?decade=1975", nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a decade not ending in 0, got %d", w.Code)
+	}
+
+	if w := doAuthJSON(router, "", http.MethodGet, "/books?facets=color", nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for an unknown facet, got %d", w.Code)
+	}
+}
+
 func TestGetBookByID_Success(t *testing.T) {
 	db := testutil.NewTestDB(t)
 	router := setupTestRouter(db)
@@ -678,6 +824,33 @@ func TestUpdateBook_ValidationError_InvalidTitle(t *testing.T) {
 	}
 }
 
+func TestUpdateBook_ValidationError_ShortLanguage(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := setupTestRouter(db)
+
+	author := testutil.SeedAuthor(t, db, "Author")
+	book := testutil.SeedBook(t, db, author, "Title", "Desc", nil)
+
+	payload := map[string]any{
+		"language": "e",
+	}
+	b, _ := json.Marshal(payload)
+
+	req, _ := http.NewRequest(
+		http.MethodPatch,
+		"/books/"+book.ID.String(),
+		bytes.NewReader(b),
+	)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d, body=%s", w.Code, w.Body.String())
+	}
+}
+
 func TestUpdateBook_ClearPublishedAt_WhenZeroDate(t *testing.T) {
 	db := testutil.NewTestDB(t)
 	router := setupTestRouter(db)
@@ -916,6 +1089,9 @@ func TestDeleteBook_NotFound(t *testing.T) {
 
 func TestDeleteBook_InternalError_Returns500(t *testing.T) {
 	bookRepo := &fakeBookRepo{
+		FindByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Book, error) {
+			return &model.Book{ID: id}, nil
+		},
 		DeleteFn: func(ctx context.Context, id uuid.UUID) error {
 			return errors.New("forced delete error")
 		},
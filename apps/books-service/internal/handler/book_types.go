@@ -5,34 +5,42 @@ import (
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
 )
 
-type CreateBookRequest struct {
-	Title       string      `json:"title" binding:"required"`
-	AuthorID    uuid.UUID   `json:"author_id" binding:"required,uuid4"`
-	Description string      `json:"description"`
-	PublishedAt *model.Date `json:"published_at" swaggertype:"string" example:"2025-11-24"`
-}
-
-type UpdateBookRequest struct {
-	Title       *string     `json:"title" binding:"omitempty,min=1"`
-	AuthorID    *uuid.UUID  `json:"author_id" binding:"omitempty,uuid4"`
-	Description *string     `json:"description" binding:"omitempty,max=2000"`
-	PublishedAt *model.Date `json:"published_at" swaggertype:"string" example:"2025-11-24"`
-}
-
 type Book struct {
-	ID          uuid.UUID     `json:"id"`
-	Title       string        `json:"title"`
-	Author      AuthorSummary `json:"author"`
-	Description string        `json:"description"`
-	PublishedAt *model.Date   `json:"published_at,omitempty" swaggertype:"string" example:"2025-11-24"`
-	CreatedAt   model.Date    `json:"created_at" swaggertype:"string" example:"2025-11-24"`
-	UpdatedAt   model.Date    `json:"updated_at" swaggertype:"string" example:"2025-11-24"`
+	ID                 uuid.UUID         `json:"id"`
+	Title              string            `json:"title"`
+	Author             AuthorSummary     `json:"author"`
+	Description        string            `json:"description"`
+	PublishedAt        *model.Date       `json:"published_at,omitempty" swaggertype:"string" example:"2025-11-24"`
+	CoverURL           string            `json:"cover_url,omitempty"`
+	CoverThumbnailURLs map[string]string `json:"cover_thumbnail_urls,omitempty"`
+	Tags               []TagSummary      `json:"tags"`
+	Series             *SeriesSummary    `json:"series,omitempty"`
+	PreviousVolume     *SeriesVolumeLink `json:"previous_volume,omitempty"`
+	NextVolume         *SeriesVolumeLink `json:"next_volume,omitempty"`
+	Work               *WorkSummary      `json:"work,omitempty"`
+	Format             string            `json:"format,omitempty" example:"paperback"`
+	Language           string            `json:"language,omitempty" example:"en"`
+	Publisher          *PublisherSummary `json:"publisher,omitempty"`
+	PageCount          *int              `json:"page_count,omitempty"`
+	ISBN               string            `json:"isbn,omitempty" example:"9780441172719"`
+	RatingAverage      float64           `json:"rating_average" example:"4.25"`
+	RatingCount        int64             `json:"rating_count" example:"12"`
+	CopyCount          int64             `json:"copy_count" example:"3"`
+	AvailableCopies    int64             `json:"available_copies" example:"1"`
+	OwnerID            *string           `json:"owner_id,omitempty"`
+	Visibility         string            `json:"visibility" example:"public"`
+	CreatedAt          model.Date        `json:"created_at" swaggertype:"string" example:"2025-11-24"`
+	UpdatedAt          model.Date        `json:"updated_at" swaggertype:"string" example:"2025-11-24"`
 }
 
 type BookResponse struct {
 	Data Book `json:"data"`
 }
 
+type SimilarBooksResponse struct {
+	Data []Book `json:"data"`
+}
+
 type BookSummary struct {
 	ID          uuid.UUID   `json:"id"`
 	Title       string      `json:"title"`
@@ -56,4 +64,18 @@ type Pagination struct {
 type ListBooksResponse struct {
 	Data       []Book     `json:"data"`
 	Pagination Pagination `json:"pagination"`
+	// DidYouMean offers titles and author names close to q when the
+	// search found nothing.
+	DidYouMean []string `json:"did_you_mean,omitempty" example:"Fyodor Dostoevsky"`
+	// Facets maps each requested facet to its most common values among
+	// all matching books, most common first.
+	Facets map[string][]FacetValue `json:"facets,omitempty"`
+}
+
+type FacetValue struct {
+	// Value is what to filter by: an author_id, decade, tags or language
+	// parameter.
+	Value string `json:"value" example:"1990"`
+	Label string `json:"label" example:"1990s"`
+	Count int64  `json:"count" example:"4"`
 }
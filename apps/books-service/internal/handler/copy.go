@@ -0,0 +1,463 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"gorm.io/gorm"
+)
+
+var copyStatuses = map[string]bool{
+	model.CopyStatusAvailable: true,
+	model.CopyStatusOnLoan:    true,
+	model.CopyStatusLost:      true,
+	model.CopyStatusWithdrawn: true,
+	model.CopyStatusReserved:  true,
+}
+
+type CopyHandler struct {
+	repo repository.CopyRepository
+}
+
+func NewCopyHandler(repo repository.CopyRepository) *CopyHandler {
+	return &CopyHandler{repo: repo}
+}
+
+func (h *CopyHandler) RegisterRoutes(r *gin.RouterGroup) {
+	copies := r.Group("/books/:id/copies")
+	{
+		copies.GET("", h.ListCopies)
+		copies.POST("", auth.Required(), h.CreateCopy)
+		copies.GET("/:copy_id", h.GetCopyByID)
+		copies.PATCH("/:copy_id", auth.Required(), h.UpdateCopy)
+		copies.DELETE("/:copy_id", auth.Required(), h.DeleteCopy)
+		copies.PUT("/:copy_id/groups", auth.Required(), h.SetCopyGroups)
+	}
+}
+
+func toCopyResponse(cp model.Copy) CopyResponse {
+	return CopyResponse{Data: toCopy(cp)}
+}
+
+func toCopy(cp model.Copy) Copy {
+	groupIDs := cp.GroupIDs
+	if groupIDs == nil {
+		groupIDs = []uuid.UUID{}
+	}
+
+	return Copy{
+		ID:         cp.ID,
+		BookID:     cp.BookID,
+		OwnerID:    cp.OwnerID,
+		Condition:  cp.Condition,
+		AcquiredAt: toOptionalDate(cp.AcquiredAt),
+		Notes:      cp.Notes,
+		Status:     cp.Status,
+		Visibility: cp.Visibility,
+		BorrowerID: cp.BorrowerID,
+		DueAt:      toOptionalDate(cp.DueAt),
+		Overdue:    cp.OverdueAt != nil,
+		GroupIDs:   groupIDs,
+		CreatedAt:  model.Date{Time: cp.CreatedAt},
+		UpdatedAt:  model.Date{Time: cp.UpdatedAt},
+	}
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// ListCopies godoc
+// @Summary      List copies of a book
+// @Description  Get the physical copies members own of a book, oldest first. Private copies are only listed for their owner, and groups-only copies for their owner and the members of the groups they are shared with.
+// @Tags         copies
+// @Produce      json
+// @Param        id      path      string  true   "Book ID (UUID)"
+// @Param        status  query     string  false  "Only copies with this status" Enums(available,on-loan,lost,withdrawn,reserved)
+// @Success      200     {object}  ListCopiesResponse
+// @Failure      400     {object}  validation.ErrorResponse  "Invalid book ID or status"
+// @Failure      500     {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/copies [get]
+func (h *CopyHandler) ListCopies(c *gin.Context) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	status := c.Query("status")
+	if status != "" && !copyStatuses[status] {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_COPY_STATUS",
+			"status must be one of: available, on-loan, lost, withdrawn, reserved",
+		)
+		return
+	}
+
+	viewer, _ := auth.UserFrom(c)
+	copies, err := h.repo.List(c.Request.Context(), bookID, status, viewer.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"COPY_LIST_FAILED",
+			"failed to fetch copies",
+		)
+		return
+	}
+
+	res := make([]Copy, 0, len(copies))
+	for _, cp := range copies {
+		res = append(res, toCopy(cp))
+	}
+
+	c.JSON(http.StatusOK, ListCopiesResponse{Data: res})
+}
+
+// CreateCopy godoc
+// @Summary      Add a copy of a book
+// @Description  Register a physical copy of a book owned by the caller. An available copy is offered to the head of the book's hold queue straight away.
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                    true  "Book ID (UUID)"
+// @Param        payload  body      CreateCopyRequest         true  "Copy to add"
+// @Success      201      {object}  CopyResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404      {object}  validation.ErrorResponse  "Book not found"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/copies [post]
+func (h *CopyHandler) CreateCopy(c *gin.Context) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req CreateCopyRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	cp := model.Copy{
+		BookID:     bookID,
+		OwnerID:    user.ID,
+		Condition:  req.Condition,
+		AcquiredAt: fromOptionalDate(req.AcquiredAt),
+		Notes:      req.Notes,
+		Status:     req.Status,
+		Visibility: req.Visibility,
+	}
+	if cp.Status == model.CopyStatusOnLoan {
+		cp.BorrowerID = optionalString(req.BorrowerID)
+		cp.DueAt = fromOptionalDate(req.DueAt)
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &cp); err != nil {
+		if errors.Is(err, repository.ErrBookNotFound) {
+			writeError(c, http.StatusNotFound,
+				"BOOK_NOT_FOUND",
+				"book not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"COPY_CREATE_FAILED",
+			"failed to add copy",
+		)
+		return
+	}
+
+	updated, err := h.repo.FindByID(c.Request.Context(), cp.BookID, cp.ID, user.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"COPY_FETCH_FAILED",
+			"failed to fetch created copy",
+		)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toCopyResponse(*updated))
+}
+
+// GetCopyByID godoc
+// @Summary      Get a copy
+// @Description  Get a single physical copy of a book
+// @Tags         copies
+// @Produce      json
+// @Param        id       path      string                    true  "Book ID (UUID)"
+// @Param        copy_id  path      string                    true  "Copy ID (UUID)"
+// @Success      200      {object}  CopyResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      404      {object}  validation.ErrorResponse  "Copy not found"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/copies/{copy_id} [get]
+func (h *CopyHandler) GetCopyByID(c *gin.Context) {
+	cp, ok := h.findCopy(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, toCopyResponse(*cp))
+}
+
+// UpdateCopy godoc
+// @Summary      Update a copy
+// @Description  Change the condition, acquisition date, notes or status of a copy the caller owns, or who it is lent to and when it is due back. The borrower is reminded before the due date, and both of you are told if it passes. Making a copy available offers it to the head of the book's hold queue; the status of a copy reserved for a hold cannot be changed until the offer is taken up, declined or expires.
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                    true  "Book ID (UUID)"
+// @Param        copy_id  path      string                    true  "Copy ID (UUID)"
+// @Param        payload  body      UpdateCopyRequest         true  "Fields to update"
+// @Success      200      {object}  CopyResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403      {object}  validation.ErrorResponse  "Not the owner of the copy"
+// @Failure      404      {object}  validation.ErrorResponse  "Copy not found"
+// @Failure      409      {object}  validation.ErrorResponse  "Copy is reserved for a hold"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/copies/{copy_id} [patch]
+func (h *CopyHandler) UpdateCopy(c *gin.Context) {
+	cp, ok := h.findOwnCopy(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateCopyRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	if req.Condition == nil && req.AcquiredAt == nil && req.Notes == nil && req.Status == nil && req.Visibility == nil &&
+		req.BorrowerID == nil && req.DueAt == nil {
+		writeError(c, http.StatusBadRequest,
+			"NO_FIELDS_TO_UPDATE",
+			"at least one field must be provided",
+		)
+		return
+	}
+
+	if req.Condition != nil {
+		cp.Condition = *req.Condition
+	}
+	if req.AcquiredAt != nil {
+		cp.AcquiredAt = fromOptionalDate(req.AcquiredAt)
+	}
+	if req.Notes != nil {
+		cp.Notes = *req.Notes
+	}
+	// The stored status is re-read when saving, so one left out isn't
+	// written back over a reservation made in the meantime.
+	cp.Status = ""
+	if req.Status != nil {
+		cp.Status = *req.Status
+	}
+	if req.Visibility != nil {
+		cp.Visibility = *req.Visibility
+	}
+	if req.BorrowerID != nil {
+		cp.BorrowerID = optionalString(*req.BorrowerID)
+	}
+	if req.DueAt != nil {
+		cp.DueAt = fromOptionalDate(req.DueAt)
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.repo.Update(ctx, cp); err != nil {
+		if errors.Is(err, repository.ErrCopyReserved) {
+			writeCopyReserved(c)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"COPY_UPDATE_FAILED",
+			"failed to update copy",
+		)
+		return
+	}
+
+	updated, err := h.repo.FindByID(ctx, cp.BookID, cp.ID, cp.OwnerID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"COPY_FETCH_FAILED",
+			"failed to fetch updated copy",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCopyResponse(*updated))
+}
+
+// SetCopyGroups godoc
+// @Summary      Restrict a copy to groups
+// @Description  Make a copy the caller owns groups-only and share it with the given groups, which the caller must belong to. An empty list makes it public again.
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                    true  "Book ID (UUID)"
+// @Param        copy_id  path      string                    true  "Copy ID (UUID)"
+// @Param        payload  body      SetCopyGroupsRequest      true  "Groups to share the copy with"
+// @Success      200      {object}  CopyResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403      {object}  validation.ErrorResponse  "Not the owner of the copy, or not a member of a group"
+// @Failure      404      {object}  validation.ErrorResponse  "Copy not found"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/copies/{copy_id}/groups [put]
+func (h *CopyHandler) SetCopyGroups(c *gin.Context) {
+	cp, ok := h.findOwnCopy(c)
+	if !ok {
+		return
+	}
+
+	var req SetCopyGroupsRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	if err := h.repo.SetGroups(c.Request.Context(), cp, req.GroupIDs); err != nil {
+		if errors.Is(err, repository.ErrNotGroupMember) {
+			writeError(c, http.StatusForbidden,
+				"NOT_GROUP_MEMBER",
+				"you can only share copies with groups you belong to",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"COPY_UPDATE_FAILED",
+			"failed to update copy groups",
+		)
+		return
+	}
+
+	updated, err := h.repo.FindByID(c.Request.Context(), cp.BookID, cp.ID, cp.OwnerID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"COPY_FETCH_FAILED",
+			"failed to fetch updated copy",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCopyResponse(*updated))
+}
+
+// DeleteCopy godoc
+// @Summary      Delete a copy
+// @Description  Remove a copy the caller owns. To keep its history, set the status to withdrawn instead.
+// @Tags         copies
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string  true  "Book ID (UUID)"
+// @Param        copy_id  path  string  true  "Copy ID (UUID)"
+// @Success      204      "No Content"
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403      {object}  validation.ErrorResponse  "Not the owner of the copy"
+// @Failure      404      {object}  validation.ErrorResponse  "Copy not found"
+// @Failure      409      {object}  validation.ErrorResponse  "Copy is reserved for a hold"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/copies/{copy_id} [delete]
+func (h *CopyHandler) DeleteCopy(c *gin.Context) {
+	cp, ok := h.findOwnCopy(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), cp.ID); err != nil {
+		if errors.Is(err, repository.ErrCopyReserved) {
+			writeCopyReserved(c)
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"COPY_NOT_FOUND",
+				"copy not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"COPY_DELETE_FAILED",
+			"failed to delete copy",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func writeCopyReserved(c *gin.Context) {
+	writeError(c, http.StatusConflict,
+		"COPY_RESERVED",
+		"the copy is reserved for a hold and cannot be changed until the offer ends",
+	)
+}
+
+func (h *CopyHandler) findCopy(c *gin.Context) (*model.Copy, bool) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return nil, false
+	}
+
+	copyID, err := uuid.Parse(c.Param("copy_id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_COPY_ID",
+			"copy_id must be a valid UUID",
+		)
+		return nil, false
+	}
+
+	viewer, _ := auth.UserFrom(c)
+	cp, err := h.repo.FindByID(c.Request.Context(), bookID, copyID, viewer.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"COPY_NOT_FOUND",
+				"copy not found",
+			)
+			return nil, false
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"COPY_FETCH_FAILED",
+			"failed to fetch copy",
+		)
+		return nil, false
+	}
+
+	return cp, true
+}
+
+func (h *CopyHandler) findOwnCopy(c *gin.Context) (*model.Copy, bool) {
+	cp, ok := h.findCopy(c)
+	if !ok {
+		return nil, false
+	}
+
+	user, _ := auth.UserFrom(c)
+	if cp.OwnerID != user.ID {
+		writeError(c, http.StatusForbidden,
+			"FORBIDDEN",
+			"you can only change your own copies",
+		)
+		return nil, false
+	}
+
+	return cp, true
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func createCopy(t *testing.T, router *gin.Engine, token, bookID string, body map[string]any) Copy {
+	t.Helper()
+
+	w := doAuthJSON(router, token, http.MethodPost, "/books/"+bookID+"/copies", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp CopyResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return resp.Data
+}
+
+func TestCopies_CRUDAndOwnership(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewCopyHandler(repository.NewCopyRepository(db)))
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+
+	author := testutil.SeedAuthor(t, db, "Octavia E. Butler")
+	book := testutil.SeedBook(t, db, author, "Kindred", "", nil)
+	bookID := book.ID.String()
+
+	cp := createCopy(t, router, alice, bookID, map[string]any{
+		"condition":   "like-new",
+		"acquired_at": "2024-06-01",
+	})
+	if cp.OwnerID != "6563a1f0c2a4b5d6e7f80911" || cp.Status != "available" {
+		t.Fatalf("expected an available copy owned by alice, got %+v", cp)
+	}
+
+	if w := doAuthJSON(router, "", http.MethodPost, "/books/"+bookID+"/copies", map[string]any{}); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, alice, http.MethodPost, "/books/"+bookID+"/copies", map[string]any{"condition": "mint"}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for unknown condition, got %d", w.Code)
+	}
+
+	path := "/books/" + bookID + "/copies/" + cp.ID.String()
+	if w := doAuthJSON(router, bob, http.MethodPatch, path, map[string]any{"status": "lost"}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for another member's copy, got %d", w.Code)
+	}
+
+	w := doAuthJSON(router, alice, http.MethodPatch, path, map[string]any{
+		"status":      "on-loan",
+		"notes":       "lent to Bob",
+		"borrower_id": "6563a1f0c2a4b5d6e7f80922",
+		"due_at":      "2024-07-01",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var updated CopyResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &updated); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if updated.Data.Status != "on-loan" || updated.Data.Condition != "like-new" || updated.Data.AcquiredAt == nil {
+		t.Fatalf("expected only status and notes to change, got %+v", updated.Data)
+	}
+	if updated.Data.BorrowerID == nil || *updated.Data.BorrowerID != "6563a1f0c2a4b5d6e7f80922" ||
+		updated.Data.DueAt == nil || updated.Data.DueAt.Format("2006-01-02") != "2024-07-01" {
+		t.Fatalf("expected the loan to bob due on July 1, got %+v", updated.Data)
+	}
+
+	if got := getJSON[CopyResponse](t, router, alice, path).Data; got.Status != "on-loan" || got.DueAt == nil {
+		t.Fatalf("expected the loan to be stored, got %+v", got)
+	}
+	w = doAuthJSON(router, alice, http.MethodPatch, path, map[string]any{"status": "available"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if got := getJSON[CopyResponse](t, router, alice, path).Data; got.BorrowerID != nil || got.DueAt != nil {
+		t.Fatalf("expected a returned copy to drop its loan, got %+v", got)
+	}
+
+	if w := doAuthJSON(router, alice, http.MethodDelete, path, nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if w := doJSON(router, http.MethodGet, path, nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestCopies_AvailabilityOnBooks(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewCopyHandler(repository.NewCopyRepository(db)))
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+
+	author := testutil.SeedAuthor(t, db, "Octavia E. Butler")
+	kindred := testutil.SeedBook(t, db, author, "Kindred", "", nil)
+	dawn := testutil.SeedBook(t, db, author, "Dawn", "", nil)
+	testutil.SeedBook(t, db, author, "Fledgling", "", nil)
+
+	createCopy(t, router, alice, kindred.ID.String(), map[string]any{})
+	createCopy(t, router, bob, kindred.ID.String(), map[string]any{"status": "on-loan"})
+	createCopy(t, router, bob, kindred.ID.String(), map[string]any{"status": "withdrawn"})
+	createCopy(t, router, alice, dawn.ID.String(), map[string]any{"status": "on-loan"})
+
+	w := doJSON(router, http.MethodGet, "/books/"+kindred.ID.String(), nil)
+	var got BookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if got.Data.CopyCount != 2 || got.Data.AvailableCopies != 1 {
+		t.Fatalf("expected 2 copies with 1 available, got %d / %d", got.Data.CopyCount, got.Data.AvailableCopies)
+	}
+
+	w = doJSON(router, http.MethodGet, "/books?available=true", nil)
+	var list ListBooksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(list.Data) != 1 || list.Data[0].Title != "Kindred" {
+		t.Fatalf("expected only Kindred to be available, got %+v", list.Data)
+	}
+
+	w = doJSON(router, http.MethodGet, "/books/"+kindred.ID.String()+"/copies?status=on-loan", nil)
+	var copies ListCopiesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &copies); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(copies.Data) != 1 || copies.Data[0].OwnerID != "6563a1f0c2a4b5d6e7f80922" {
+		t.Fatalf("expected bob's copy on loan, got %+v", copies.Data)
+	}
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+)
+
+type CreateCopyRequest struct {
+	Condition  string      `json:"condition" binding:"omitempty,oneof=new like-new good fair poor" example:"good"`
+	AcquiredAt *model.Date `json:"acquired_at" swaggertype:"string" example:"2025-11-24"`
+	Notes      string      `json:"notes" binding:"omitempty,max=2000"`
+	Status     string      `json:"status" binding:"omitempty,oneof=available on-loan lost withdrawn" example:"available"`
+	Visibility string      `json:"visibility" binding:"omitempty,oneof=public groups private" example:"public"`
+	// BorrowerID and DueAt are only kept for a copy created on loan.
+	BorrowerID string      `json:"borrower_id" binding:"omitempty,max=64" example:"6563a1f0c2a4b5d6e7f80922"`
+	DueAt      *model.Date `json:"due_at" swaggertype:"string" example:"2025-12-08"`
+}
+
+type UpdateCopyRequest struct {
+	Condition  *string     `json:"condition" binding:"omitempty,oneof=new like-new good fair poor" example:"fair"`
+	AcquiredAt *model.Date `json:"acquired_at" swaggertype:"string" example:"2025-11-24"`
+	Notes      *string     `json:"notes" binding:"omitempty,max=2000"`
+	Status     *string     `json:"status" binding:"omitempty,oneof=available on-loan lost withdrawn" example:"on-loan"`
+	Visibility *string     `json:"visibility" binding:"omitempty,oneof=public groups private" example:"groups"`
+	// BorrowerID and DueAt describe the loan; they are cleared when the
+	// copy stops being on loan. An empty value clears either.
+	BorrowerID *string     `json:"borrower_id" binding:"omitempty,max=64" example:"6563a1f0c2a4b5d6e7f80922"`
+	DueAt      *model.Date `json:"due_at" swaggertype:"string" example:"2025-12-08"`
+}
+
+type SetCopyGroupsRequest struct {
+	GroupIDs []uuid.UUID `json:"group_ids" binding:"max=50"`
+}
+
+type Copy struct {
+	ID         uuid.UUID   `json:"id"`
+	BookID     uuid.UUID   `json:"book_id"`
+	OwnerID    string      `json:"owner_id"`
+	Condition  string      `json:"condition" example:"good"`
+	AcquiredAt *model.Date `json:"acquired_at,omitempty" swaggertype:"string" example:"2025-11-24"`
+	Notes      string      `json:"notes"`
+	Status     string      `json:"status" example:"available"`
+	Visibility string      `json:"visibility" example:"public"`
+	BorrowerID *string     `json:"borrower_id,omitempty" example:"6563a1f0c2a4b5d6e7f80922"`
+	DueAt      *model.Date `json:"due_at,omitempty" swaggertype:"string" example:"2025-12-08"`
+	// Overdue is set once a copy on loan has passed its due date.
+	Overdue bool `json:"overdue"`
+	// GroupIDs lists the groups a groups-only copy is shared with; empty
+	// means all of the owner's groups.
+	GroupIDs  []uuid.UUID `json:"group_ids"`
+	CreatedAt model.Date  `json:"created_at" swaggertype:"string" example:"2025-11-24"`
+	UpdatedAt model.Date  `json:"updated_at" swaggertype:"string" example:"2025-11-24"`
+}
+
+type CopyResponse struct {
+	Data Copy `json:"data"`
+}
+
+type ListCopiesResponse struct {
+	Data []Copy `json:"data"`
+}
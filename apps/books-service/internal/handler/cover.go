@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/cover"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"gorm.io/gorm"
+)
+
+// multipartOverhead leaves room for boundaries and part headers on top of
+// the image itself when capping the request body.
+const multipartOverhead = 64 << 10
+
+type CoverHandler struct {
+	repo   repository.BookRepository
+	covers *cover.Service
+}
+
+func NewCoverHandler(repo repository.BookRepository, covers *cover.Service) *CoverHandler {
+	return &CoverHandler{repo: repo, covers: covers}
+}
+
+func (h *CoverHandler) RegisterRoutes(r *gin.RouterGroup) {
+	books := r.Group("/books")
+	{
+		books.PUT("/:id/cover", h.UploadCover)
+		books.DELETE("/:id/cover", h.DeleteCover)
+	}
+}
+
+// UploadCover godoc
+// @Summary      Upload a book cover
+// @Description  Upload or replace the cover image of a book. Accepts JPEG, PNG or WebP; thumbnails are generated server-side.
+// @Tags         books
+// @Accept       mpfd
+// @Produce      json
+// @Param        id     path      string  true  "Book ID (UUID)"
+// @Param        cover  formData  file    true  "Cover image"
+// @Success      200    {object}  BookResponse
+// @Failure      400    {object}  validation.ErrorResponse   "Invalid ID or missing file"
+// @Failure      404    {object}  validation.ErrorResponse   "Book not found"
+// @Failure      413    {object}  validation.ErrorResponse   "Image too large"
+// @Failure      415    {object}  validation.ErrorResponse   "Unsupported image type"
+// @Failure      500    {object}  validation.ErrorResponse   "Internal server error"
+// @Router       /books/{id}/cover [put]
+func (h *CoverHandler) UploadCover(c *gin.Context) {
+	bookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_BOOK_ID",
+			"invalid book id",
+		)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	book, err := h.repo.FindByID(ctx, bookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"BOOK_NOT_FOUND",
+				"book not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"BOOK_FETCH_FAILED",
+			"failed to fetch book",
+		)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.covers.MaxBytes()+multipartOverhead)
+
+	file, header, err := c.Request.FormFile("cover")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			writeError(c, http.StatusRequestEntityTooLarge,
+				"COVER_TOO_LARGE",
+				"cover image exceeds the maximum allowed size",
+			)
+			return
+		}
+
+		writeError(c, http.StatusBadRequest,
+			"COVER_FILE_REQUIRED",
+			"multipart field 'cover' is required",
+		)
+		return
+	}
+	defer file.Close()
+
+	if header.Size > h.covers.MaxBytes() {
+		writeError(c, http.StatusRequestEntityTooLarge,
+			"COVER_TOO_LARGE",
+			"cover image exceeds the maximum allowed size",
+		)
+		return
+	}
+
+	key, err := h.covers.Upload(ctx, bookID, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, cover.ErrTooLarge):
+			writeError(c, http.StatusRequestEntityTooLarge,
+				"COVER_TOO_LARGE",
+				"cover image exceeds the maximum allowed size",
+			)
+		case errors.Is(err, cover.ErrUnsupportedType):
+			writeError(c, http.StatusUnsupportedMediaType,
+				"COVER_UNSUPPORTED_TYPE",
+				"cover must be a JPEG, PNG or WebP image",
+			)
+		case errors.Is(err, cover.ErrInvalidImage):
+			writeError(c, http.StatusBadRequest,
+				"COVER_INVALID_IMAGE",
+				"cover image could not be decoded",
+			)
+		default:
+			writeError(c, http.StatusInternalServerError,
+				"COVER_UPLOAD_FAILED",
+				"failed to store cover image",
+			)
+		}
+		return
+	}
+
+	oldKey := book.CoverKey
+	book.CoverKey = key
+
+	if err := h.repo.Update(ctx, book); err != nil {
+		if derr := h.covers.Delete(ctx, key); derr != nil {
+			log.Printf("failed to clean up cover blobs for book %s: %v", bookID, derr)
+		}
+		writeError(c, http.StatusInternalServerError,
+			"BOOK_UPDATE_FAILED",
+			"failed to update book",
+		)
+		return
+	}
+
+	if oldKey != "" {
+		if err := h.covers.Delete(ctx, oldKey); err != nil {
+			log.Printf("failed to delete previous cover blobs for book %s: %v", bookID, err)
+		}
+	}
+
+	c.JSON(http.StatusOK, toBookResponse(*book, h.covers))
+}
+
+// DeleteCover godoc
+// @Summary      Delete a book cover
+// @Description  Remove the cover image and its thumbnails from a book
+// @Tags         books
+// @Produce      json
+// @Param        id   path      string  true  "Book ID (UUID)"
+// @Success      204  {string}  string  "No content"
+// @Failure      400  {object}  validation.ErrorResponse   "Invalid ID"
+// @Failure      404  {object}  validation.ErrorResponse   "Book or cover not found"
+// @Failure      500  {object}  validation.ErrorResponse   "Internal server error"
+// @Router       /books/{id}/cover [delete]
+func (h *CoverHandler) DeleteCover(c *gin.Context) {
+	bookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_BOOK_ID",
+			"invalid book id",
+		)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	book, err := h.repo.FindByID(ctx, bookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"BOOK_NOT_FOUND",
+				"book not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"BOOK_FETCH_FAILED",
+			"failed to fetch book",
+		)
+		return
+	}
+
+	if book.CoverKey == "" {
+		writeError(c, http.StatusNotFound,
+			"COVER_NOT_FOUND",
+			"book has no cover",
+		)
+		return
+	}
+
+	key := book.CoverKey
+	book.CoverKey = ""
+
+	if err := h.repo.Update(ctx, book); err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"BOOK_UPDATE_FAILED",
+			"failed to update book",
+		)
+		return
+	}
+
+	if err := h.covers.Delete(ctx, key); err != nil {
+		log.Printf("failed to delete cover blobs for book %s: %v", bookID, err)
+	}
+
+	c.Status(http.StatusNoContent)
+}
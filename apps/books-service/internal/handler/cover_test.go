@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/cover"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/storage"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func setupCoverRouter(t *testing.T, db *gorm.DB, maxBytes int64) (*gin.Engine, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	store, err := storage.NewLocalStore(dir, "http://cdn.test")
+	if err != nil {
+		t.Fatalf("failed to create local store: %v", err)
+	}
+	covers := cover.NewService(store, maxBytes)
+
+	bookRepo := repository.NewGormBookRepository(db)
+	r := newTestRouter(NewBookHandler(bookRepo, WithCovers(covers)), NewCoverHandler(bookRepo, covers))
+	return r, dir
+}
+
+func pngBytes(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func newCoverRequest(t *testing.T, bookID uuid.UUID, data []byte) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("cover", "cover.png")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	if _, err := fw.Write(data); err != nil {
+		t.Fatalf("failed to write form file: %v", err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatalf("failed to close multipart writer: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, "/books/"+bookID.String()+"/cover", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestUploadCover_Success(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router, dir := setupCoverRouter(t, db, cover.DefaultMaxBytes)
+
+	author := testutil.SeedAuthor(t, db, "Le Guin")
+	book := testutil.SeedBook(t, db, author, "The Dispossessed", "", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCoverRequest(t, book.ID, pngBytes(t, 800, 1200)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp BookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if resp.Data.CoverURL == "" {
+		t.Fatalf("expected cover_url in response")
+	}
+	for _, size := range cover.ThumbnailSizes {
+		if resp.Data.CoverThumbnailURLs[size.Name] == "" {
+			t.Errorf("expected thumbnail url for size %q", size.Name)
+		}
+	}
+
+	var stored model.Book
+	if err := db.First(&stored, "id = ?", book.ID).Error; err != nil {
+		t.Fatalf("failed to load book: %v", err)
+	}
+	if stored.CoverKey == "" {
+		t.Fatalf("expected stored cover key")
+	}
+
+	f, err := os.Open(filepath.Join(dir, filepath.Dir(stored.CoverKey), "small.jpg"))
+	if err != nil {
+		t.Fatalf("expected small thumbnail on disk: %v", err)
+	}
+	defer f.Close()
+
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		t.Fatalf("failed to decode thumbnail: %v", err)
+	}
+	if cfg.Width != 128 || cfg.Height != 192 {
+		t.Errorf("expected 128x192 thumbnail, got %dx%d", cfg.Width, cfg.Height)
+	}
+}
+
+func TestUploadCover_UnsupportedType(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router, _ := setupCoverRouter(t, db, cover.DefaultMaxBytes)
+
+	author := testutil.SeedAuthor(t, db, "Le Guin")
+	book := testutil.SeedBook(t, db, author, "The Dispossessed", "", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCoverRequest(t, book.ID, []byte("definitely not an image")))
+
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected status 415, got %d, body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestUploadCover_TooLarge(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router, _ := setupCoverRouter(t, db, 1024)
+
+	author := testutil.SeedAuthor(t, db, "Le Guin")
+	book := testutil.SeedBook(t, db, author, "The Dispossessed", "", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCoverRequest(t, book.ID, pngBytes(t, 400, 400)))
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status 413, got %d, body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestUploadCover_BookNotFound(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router, _ := setupCoverRouter(t, db, cover.DefaultMaxBytes)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCoverRequest(t, uuid.New(), pngBytes(t, 10, 10)))
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d, body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestDeleteBook_RemovesCoverBlobs(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router, dir := setupCoverRouter(t, db, cover.DefaultMaxBytes)
+
+	author := testutil.SeedAuthor(t, db, "Le Guin")
+	book := testutil.SeedBook(t, db, author, "The Dispossessed", "", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCoverRequest(t, book.ID, pngBytes(t, 50, 50)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected upload status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var stored model.Book
+	if err := db.First(&stored, "id = ?", book.ID).Error; err != nil {
+		t.Fatalf("failed to load book: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, "/books/"+book.ID.String(), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, stored.CoverKey)); !os.IsNotExist(err) {
+		t.Errorf("expected original cover to be removed, stat err=%v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.Dir(stored.CoverKey), "large.jpg")); !os.IsNotExist(err) {
+		t.Errorf("expected large thumbnail to be removed, stat err=%v", err)
+	}
+}
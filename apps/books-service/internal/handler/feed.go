@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+)
+
+type FeedHandler struct {
+	repo repository.ActivityRepository
+}
+
+func NewFeedHandler(repo repository.ActivityRepository) *FeedHandler {
+	return &FeedHandler{repo: repo}
+}
+
+func (h *FeedHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/feed", auth.Required(), h.GetFeed)
+}
+
+func toActivity(a model.Activity) Activity {
+	out := Activity{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		Type:      a.Type,
+		Book:      toBookSummaryResponse(a.Book).Data,
+		ReviewID:  a.ReviewID,
+		CopyID:    a.CopyID,
+		CreatedAt: a.CreatedAt,
+	}
+	if a.Review != nil {
+		rating := a.Review.Rating
+		out.Rating = &rating
+	}
+	return out
+}
+
+// GetFeed godoc
+// @Summary      Get my activity feed
+// @Description  What the users the caller follows have been doing, newest first: books they added, finished and reviewed, and copies they lent. Activities about books or copies the caller can't see are left out. Pass next_cursor from a response as cursor to get the following page.
+// @Tags         feed
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit   query     int     false  "Items per page"  default(20) minimum(1) maximum(100)
+// @Param        cursor  query     string  false  "Cursor from the previous page"
+// @Success      200     {object}  ListActivitiesResponse
+// @Failure      400     {object}  validation.ErrorResponse  "Invalid cursor"
+// @Failure      401     {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      500     {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /feed [get]
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	limit := parseIntQuery(c, "limit", 20)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	// One extra tells whether there is another page.
+	params := repository.ActivityFeedParams{Limit: limit + 1}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_CURSOR",
+				"cursor is not valid",
+			)
+			return
+		}
+		params.After = after
+	}
+
+	user, _ := auth.UserFrom(c)
+
+	activities, err := h.repo.Feed(c.Request.Context(), user.ID, params)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"FEED_FAILED",
+			"failed to fetch feed",
+		)
+		return
+	}
+
+	var next *string
+	if len(activities) > limit {
+		activities = activities[:limit]
+		last := activities[limit-1]
+		cursor := encodeCursor(last.CreatedAt, last.ID)
+		next = &cursor
+	}
+
+	data := make([]Activity, 0, len(activities))
+	for _, a := range activities {
+		data = append(data, toActivity(a))
+	}
+
+	c.JSON(http.StatusOK, ListActivitiesResponse{Data: data, NextCursor: next})
+}
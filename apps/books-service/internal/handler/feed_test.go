@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func TestFeed(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	books := repository.NewGormBookRepository(db)
+	router := newTestRouter(
+		NewBookHandler(books),
+		NewShelfHandler(repository.NewShelfRepository(db)),
+		NewReviewHandler(repository.NewReviewRepository(db), books),
+		NewCopyHandler(repository.NewCopyRepository(db)),
+		NewFollowHandler(repository.NewFollowRepository(db)),
+		NewFeedHandler(repository.NewActivityRepository(db)),
+	)
+	const bobID = "6563a1f0c2a4b5d6e7f80922"
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, bobID)
+	carol := tokenFor(t, "6563a1f0c2a4b5d6e7f80933")
+
+	if w := doAuthJSON(router, "", http.MethodGet, "/feed", nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, alice, http.MethodPost, "/users/"+bobID+"/follow", nil); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	author := testutil.SeedAuthor(t, db, "N. K. Jemisin")
+	fifth := testutil.SeedBook(t, db, author, "The Fifth Season", "", nil)
+	fifthID := fifth.ID.String()
+
+	for _, visibility := range []string{"public", "private"} {
+		w := doAuthJSON(router, bob, http.MethodPost, "/books", map[string]any{
+			"title": "The City We Became (" + visibility + ")", "author_id": author.ID.String(), "visibility": visibility,
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+		}
+	}
+	if w := doAuthJSON(router, bob, http.MethodPut, "/shelves/read/books/"+fifthID, nil); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if w := doAuthJSON(router, bob, http.MethodPost, "/books/"+fifthID+"/reviews", map[string]any{"rating": 5}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+	lent := createCopy(t, router, bob, fifthID, nil)
+	if w := doAuthJSON(router, bob, http.MethodPatch, "/books/"+fifthID+"/copies/"+lent.ID.String(), map[string]any{"status": "on-loan"}); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	hidden := createCopy(t, router, bob, fifthID, map[string]any{"visibility": "private"})
+	if w := doAuthJSON(router, bob, http.MethodPatch, "/books/"+fifthID+"/copies/"+hidden.ID.String(), map[string]any{"status": "on-loan"}); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	// Users alice doesn't follow stay out of her feed.
+	if w := doAuthJSON(router, carol, http.MethodPut, "/shelves/read/books/"+fifthID, nil); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	first := getJSON[ListActivitiesResponse](t, router, alice, "/feed?limit=2")
+	if len(first.Data) != 2 || first.NextCursor == nil {
+		t.Fatalf("expected a first page of 2 with a cursor, got %+v", first)
+	}
+	rest := getJSON[ListActivitiesResponse](t, router, alice, "/feed?limit=2&cursor="+*first.NextCursor)
+	if len(rest.Data) != 2 || rest.NextCursor != nil {
+		t.Fatalf("expected a last page of 2, got %+v", rest)
+	}
+
+	feed := append(first.Data, rest.Data...)
+	want := []string{"copy-lent", "review-written", "finished-reading", "book-added"}
+	for i, a := range feed {
+		if a.Type != want[i] || a.UserID != bobID {
+			t.Fatalf("expected bob's %v, got %+v", want, feed)
+		}
+	}
+	if feed[0].CopyID == nil || *feed[0].CopyID != lent.ID {
+		t.Fatalf("expected the public copy to be the lent one, got %+v", feed[0])
+	}
+	if feed[1].Rating == nil || *feed[1].Rating != 5 {
+		t.Fatalf("expected the review's rating, got %+v", feed[1])
+	}
+	if feed[3].Book.Title != "The City We Became (public)" {
+		t.Fatalf("expected only the public book, got %+v", feed[3].Book)
+	}
+
+	if got := getJSON[ListActivitiesResponse](t, router, bob, "/feed"); len(got.Data) != 0 {
+		t.Fatalf("expected an empty feed for someone following no one, got %+v", got.Data)
+	}
+	if w := doAuthJSON(router, alice, http.MethodGet, "/feed?cursor=nope", nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a bad cursor, got %d", w.Code)
+	}
+}
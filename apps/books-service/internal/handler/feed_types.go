@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Activity struct {
+	ID     uuid.UUID   `json:"id"`
+	UserID string      `json:"user_id" example:"6563a1f0c2a4b5d6e7f80911"`
+	Type   string      `json:"type" example:"finished-reading"`
+	Book   BookSummary `json:"book"`
+	// ReviewID and Rating are set on review-written activities.
+	ReviewID *uuid.UUID `json:"review_id,omitempty"`
+	Rating   *int       `json:"rating,omitempty" example:"4"`
+	// CopyID is set on copy-lent activities.
+	CopyID    *uuid.UUID `json:"copy_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at" example:"2025-11-24T08:00:00Z"`
+}
+
+type ListActivitiesResponse struct {
+	Data []Activity `json:"data"`
+	// NextCursor is passed as cursor to fetch the next page; it is null on
+	// the last page.
+	NextCursor *string `json:"next_cursor"`
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+)
+
+// bookRelationFields and authorRelationFields map each relation a request
+// can include to the response fields it fills.
+var (
+	bookRelationFields = map[string][]string{
+		repository.IncludeAuthor:    {"author"},
+		repository.IncludeTags:      {"tags"},
+		repository.IncludeSeries:    {"series", "previous_volume", "next_volume"},
+		repository.IncludeWork:      {"work"},
+		repository.IncludePublisher: {"publisher"},
+	}
+	authorRelationFields = map[string][]string{
+		repository.IncludeBooks: {"books"},
+	}
+)
+
+// fieldSet is what a request's fields and include parameters select.
+type fieldSet struct {
+	// include names the relations to load; nil loads them all.
+	include []string
+	// keep names the response fields to return; nil returns them all.
+	keep map[string]bool
+}
+
+// parseFieldSet reads the fields and include parameters for a response
+// of type resource, writing a 400 and returning false if either names
+// something unknown.
+//
+// include picks the relations to load, and an empty include loads none.
+// Without include, fields loads just the relations it names. The id is
+// always returned.
+func parseFieldSet(c *gin.Context, resource any, relationFields map[string][]string) (fieldSet, bool) {
+	fields := parseListQuery(c, "fields")
+	_, hasInclude := c.GetQuery("include")
+	include := parseListQuery(c, "include")
+
+	known := jsonFieldNames(reflect.TypeOf(resource))
+	for _, f := range fields {
+		if !slices.Contains(known, f) {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_FIELDS",
+				"unknown field "+f+"; must be one of: "+strings.Join(known, ", "),
+			)
+			return fieldSet{}, false
+		}
+	}
+	for _, rel := range include {
+		if _, ok := relationFields[rel]; !ok {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_INCLUDE",
+				"unknown include "+rel+"; must be one of: "+strings.Join(sortedKeys(relationFields), ", "),
+			)
+			return fieldSet{}, false
+		}
+	}
+
+	if len(fields) == 0 && !hasInclude {
+		return fieldSet{}, true
+	}
+
+	set := fieldSet{include: []string{}, keep: map[string]bool{"id": true}}
+	for _, rel := range sortedKeys(relationFields) {
+		wanted := slices.Contains(include, rel)
+		if !hasInclude {
+			wanted = slices.ContainsFunc(relationFields[rel], func(f string) bool {
+				return slices.Contains(fields, f)
+			})
+		}
+		if wanted {
+			set.include = append(set.include, rel)
+		}
+	}
+
+	if len(fields) == 0 {
+		for _, f := range known {
+			set.keep[f] = true
+		}
+	}
+	for _, f := range fields {
+		set.keep[f] = true
+	}
+	// Relations that aren't loaded would come back empty, so their fields
+	// go. Without include, fields has already chosen among the rest.
+	for rel, relFields := range relationFields {
+		for _, f := range relFields {
+			set.keep[f] = slices.Contains(set.include, rel) && (hasInclude || set.keep[f])
+		}
+	}
+	return set, true
+}
+
+// apply trims v, a response struct, to the selected fields.
+func (s fieldSet) apply(v any) (any, error) {
+	if s.keep == nil {
+		return v, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for f := range fields {
+		if !s.keep[f] {
+			delete(fields, f)
+		}
+	}
+	return fields, nil
+}
+
+// applyAll trims each of vs to the selected fields.
+func applyAll[T any](s fieldSet, vs []T) (any, error) {
+	if s.keep == nil {
+		return vs, nil
+	}
+
+	out := make([]any, 0, len(vs))
+	for _, v := range vs {
+		trimmed, err := s.apply(v)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, trimmed)
+	}
+	return out, nil
+}
+
+// jsonFieldNames lists the JSON names of t's fields, in order.
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+// responseKeys lists the fields of each object in the data of the
+// response at path, sorted.
+func responseKeys(t *testing.T, router *gin.Engine, path string) [][]string {
+	t.Helper()
+
+	resp := getJSON[struct {
+		Data json.RawMessage `json:"data"`
+	}](t, router, "", path)
+
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal(resp.Data, &objects); err != nil {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(resp.Data, &object); err != nil {
+			t.Fatalf("failed to unmarshal data: %v", err)
+		}
+		objects = append(objects, object)
+	}
+
+	keys := make([][]string, 0, len(objects))
+	for _, o := range objects {
+		k := make([]string, 0, len(o))
+		for f := range o {
+			k = append(k, f)
+		}
+		slices.Sort(k)
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestSparseFieldsets(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewAuthorHandler(repository.NewAuthorRepository(db)))
+
+	author := testutil.SeedAuthor(t, db, "Octavia E. Butler")
+	book := testutil.SeedBook(t, db, author, "Kindred", "Time travel", nil)
+	bookPath := "/books/" + book.ID.String()
+	authorPath := "/authors/" + author.ID.String()
+
+	cases := []struct {
+		path string
+		want []string
+	}{
+		{"/books?fields=id,title,published_at", []string{"id", "title"}},
+		{"/books?fields=title,author", []string{"author", "id", "title"}},
+		{bookPath + "?fields=title&include=tags", []string{"id", "tags", "title"}},
+		{authorPath + "?fields=name", []string{"id", "name"}},
+		{authorPath + "?include=", []string{"bio", "created_at", "id", "name", "updated_at"}},
+	}
+	for _, tc := range cases {
+		got := responseKeys(t, router, tc.path)
+		if len(got) != 1 || !slices.Equal(got[0], tc.want) {
+			t.Fatalf("%s: expected fields %v, got %v", tc.path, tc.want, got)
+		}
+	}
+
+	full := responseKeys(t, router, bookPath+"?include=author")
+	if slices.Contains(full[0], "tags") || slices.Contains(full[0], "series") {
+		t.Fatalf("expected relations that weren't included to be dropped, got %v", full[0])
+	}
+	if !slices.Contains(full[0], "author") || !slices.Contains(full[0], "description") {
+		t.Fatalf("expected every field and the author, got %v", full[0])
+	}
+
+	for _, path := range []string{"/books?fields=nope", bookPath + "?include=reviews", authorPath + "?include=tags"} {
+		if w := doAuthJSON(router, "", http.MethodGet, path, nil); w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected status 400, got %d, body=%s", path, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,541 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+)
+
+type FollowHandler struct {
+	repo repository.FollowRepository
+}
+
+func NewFollowHandler(repo repository.FollowRepository) *FollowHandler {
+	return &FollowHandler{repo: repo}
+}
+
+func (h *FollowHandler) RegisterRoutes(r *gin.RouterGroup) {
+	users := r.Group("/users/:user_id")
+	{
+		users.GET("/followers", h.ListFollowers)
+		users.GET("/following", h.ListFollowing)
+		users.POST("/follow", auth.Required(), h.FollowUser)
+		users.DELETE("/follow", auth.Required(), h.UnfollowUser)
+		users.POST("/block", auth.Required(), h.BlockUser)
+		users.DELETE("/block", auth.Required(), h.UnblockUser)
+	}
+
+	account := r.Group("/account", auth.Required())
+	{
+		account.GET("/settings", h.GetAccountSettings)
+		account.PUT("/settings", h.UpdateAccountSettings)
+		account.GET("/follow-requests", h.ListFollowRequests)
+		account.POST("/follow-requests/:user_id/approve", h.ApproveFollowRequest)
+		account.DELETE("/follow-requests/:user_id", h.RejectFollowRequest)
+		account.GET("/blocks", h.ListBlocks)
+	}
+}
+
+func toFollow(f model.Follow) Follow {
+	return Follow{
+		FollowerID: f.FollowerID,
+		FolloweeID: f.FolloweeID,
+		Status:     f.Status,
+		CreatedAt:  f.CreatedAt,
+		AcceptedAt: f.AcceptedAt,
+	}
+}
+
+// FollowUser godoc
+// @Summary      Follow a user
+// @Description  Follow a user. Following a private account sends a request its owner has to approve, and the follow stays pending until then.
+// @Tags         follows
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id  path      string  true  "User ID"
+// @Success      201      {object}  FollowResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid user ID, or following yourself"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403      {object}  validation.ErrorResponse  "One of the two users blocks the other"
+// @Failure      409      {object}  validation.ErrorResponse  "Already following or requested"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /users/{user_id}/follow [post]
+func (h *FollowHandler) FollowUser(c *gin.Context) {
+	target, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	if target == user.ID {
+		writeError(c, http.StatusBadRequest,
+			"CANNOT_FOLLOW_SELF",
+			"you can't follow yourself",
+		)
+		return
+	}
+
+	follow, err := h.repo.Follow(c.Request.Context(), user.ID, target)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrBlocked):
+			writeError(c, http.StatusForbidden,
+				"FOLLOW_BLOCKED",
+				"you can't follow this user",
+			)
+		case errors.Is(err, repository.ErrAlreadyFollowing):
+			writeError(c, http.StatusConflict,
+				"ALREADY_FOLLOWING",
+				"you already follow or asked to follow this user",
+			)
+		default:
+			writeError(c, http.StatusInternalServerError,
+				"FOLLOW_FAILED",
+				"failed to follow user",
+			)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, FollowResponse{Data: toFollow(*follow)})
+}
+
+// UnfollowUser godoc
+// @Summary      Unfollow a user
+// @Description  Stop following a user, or withdraw a pending request to follow them
+// @Tags         follows
+// @Security     BearerAuth
+// @Param        user_id  path  string  true  "User ID"
+// @Success      204      "No Content"
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid user ID"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404      {object}  validation.ErrorResponse  "Not following the user"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /users/{user_id}/follow [delete]
+func (h *FollowHandler) UnfollowUser(c *gin.Context) {
+	target, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	if err := h.repo.Unfollow(c.Request.Context(), user.ID, target); err != nil {
+		if errors.Is(err, repository.ErrNotFollowing) {
+			writeError(c, http.StatusNotFound,
+				"NOT_FOLLOWING",
+				"you don't follow this user",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"UNFOLLOW_FAILED",
+			"failed to unfollow user",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListFollowers godoc
+// @Summary      List a user's followers
+// @Description  Get who follows a user, most recent first. The followers of a private account are only listed for its owner and their followers.
+// @Tags         follows
+// @Produce      json
+// @Param        user_id    path      string  true   "User ID"
+// @Param        page       query     int     false  "Page number"     default(1) minimum(1)
+// @Param        page_size  query     int     false  "Items per page"  default(20) minimum(1) maximum(100)
+// @Success      200        {object}  ListFollowsResponse
+// @Failure      400        {object}  validation.ErrorResponse  "Invalid user ID"
+// @Failure      403        {object}  validation.ErrorResponse  "Private account"
+// @Failure      500        {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /users/{user_id}/followers [get]
+func (h *FollowHandler) ListFollowers(c *gin.Context) {
+	h.listConnections(c, h.repo.Followers)
+}
+
+// ListFollowing godoc
+// @Summary      List who a user follows
+// @Description  Get who a user follows, most recent first. For a private account this is only listed for its owner and their followers.
+// @Tags         follows
+// @Produce      json
+// @Param        user_id    path      string  true   "User ID"
+// @Param        page       query     int     false  "Page number"     default(1) minimum(1)
+// @Param        page_size  query     int     false  "Items per page"  default(20) minimum(1) maximum(100)
+// @Success      200        {object}  ListFollowsResponse
+// @Failure      400        {object}  validation.ErrorResponse  "Invalid user ID"
+// @Failure      403        {object}  validation.ErrorResponse  "Private account"
+// @Failure      500        {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /users/{user_id}/following [get]
+func (h *FollowHandler) ListFollowing(c *gin.Context) {
+	h.listConnections(c, h.repo.Following)
+}
+
+// BlockUser godoc
+// @Summary      Block a user
+// @Description  Block a user. Follows between the two of you are removed, they can no longer follow you, and your books, copies and reviews are hidden from them.
+// @Tags         follows
+// @Security     BearerAuth
+// @Param        user_id  path  string  true  "User ID"
+// @Success      204      "No Content"
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid user ID, or blocking yourself"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      409      {object}  validation.ErrorResponse  "Already blocking the user"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /users/{user_id}/block [post]
+func (h *FollowHandler) BlockUser(c *gin.Context) {
+	target, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	if target == user.ID {
+		writeError(c, http.StatusBadRequest,
+			"CANNOT_BLOCK_SELF",
+			"you can't block yourself",
+		)
+		return
+	}
+
+	if err := h.repo.Block(c.Request.Context(), user.ID, target); err != nil {
+		if errors.Is(err, repository.ErrAlreadyBlocked) {
+			writeError(c, http.StatusConflict,
+				"ALREADY_BLOCKED",
+				"you already block this user",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"BLOCK_FAILED",
+			"failed to block user",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnblockUser godoc
+// @Summary      Unblock a user
+// @Description  Lift a block. Follows it removed are not restored.
+// @Tags         follows
+// @Security     BearerAuth
+// @Param        user_id  path  string  true  "User ID"
+// @Success      204      "No Content"
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid user ID"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404      {object}  validation.ErrorResponse  "Not blocking the user"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /users/{user_id}/block [delete]
+func (h *FollowHandler) UnblockUser(c *gin.Context) {
+	target, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	if err := h.repo.Unblock(c.Request.Context(), user.ID, target); err != nil {
+		if errors.Is(err, repository.ErrNotBlocked) {
+			writeError(c, http.StatusNotFound,
+				"NOT_BLOCKED",
+				"you don't block this user",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"UNBLOCK_FAILED",
+			"failed to unblock user",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetAccountSettings godoc
+// @Summary      Get my account settings
+// @Description  Get whether the caller's account is private
+// @Tags         follows
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  AccountSettingsResponse
+// @Failure      401  {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /account/settings [get]
+func (h *FollowHandler) GetAccountSettings(c *gin.Context) {
+	user, _ := auth.UserFrom(c)
+
+	settings, err := h.repo.Settings(c.Request.Context(), user.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"ACCOUNT_SETTINGS_FETCH_FAILED",
+			"failed to fetch account settings",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, AccountSettingsResponse{Data: AccountSettings{Private: settings.Private}})
+}
+
+// UpdateAccountSettings godoc
+// @Summary      Update my account settings
+// @Description  Make the caller's account private, so new followers need approval, or public again, which approves every pending request
+// @Tags         follows
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        payload  body      UpdateAccountSettingsRequest  true  "Settings"
+// @Success      200      {object}  AccountSettingsResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /account/settings [put]
+func (h *FollowHandler) UpdateAccountSettings(c *gin.Context) {
+	var req UpdateAccountSettingsRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	settings, err := h.repo.SetPrivate(c.Request.Context(), user.ID, *req.Private)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"ACCOUNT_SETTINGS_UPDATE_FAILED",
+			"failed to update account settings",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, AccountSettingsResponse{Data: AccountSettings{Private: settings.Private}})
+}
+
+// ListFollowRequests godoc
+// @Summary      List my follow requests
+// @Description  Get the pending requests to follow the caller, oldest first
+// @Tags         follows
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page       query     int  false  "Page number"     default(1) minimum(1)
+// @Param        page_size  query     int  false  "Items per page"  default(20) minimum(1) maximum(100)
+// @Success      200        {object}  ListFollowsResponse
+// @Failure      401        {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      500        {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /account/follow-requests [get]
+func (h *FollowHandler) ListFollowRequests(c *gin.Context) {
+	user, _ := auth.UserFrom(c)
+	params := parseFollowListParams(c)
+
+	result, err := h.repo.Requests(c.Request.Context(), user.ID, params)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"FOLLOW_LIST_FAILED",
+			"failed to fetch follow requests",
+		)
+		return
+	}
+
+	writeFollows(c, params, result)
+}
+
+// ApproveFollowRequest godoc
+// @Summary      Approve a follow request
+// @Description  Let a user who asked to follow the caller do so
+// @Tags         follows
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id  path      string  true  "ID of the user who asked"
+// @Success      200      {object}  FollowResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid user ID"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404      {object}  validation.ErrorResponse  "Follow request not found"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /account/follow-requests/{user_id}/approve [post]
+func (h *FollowHandler) ApproveFollowRequest(c *gin.Context) {
+	follower, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	follow, err := h.repo.Approve(c.Request.Context(), user.ID, follower)
+	if err != nil {
+		writeFollowRequestError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, FollowResponse{Data: toFollow(*follow)})
+}
+
+// RejectFollowRequest godoc
+// @Summary      Reject a follow request
+// @Description  Turn down a user who asked to follow the caller
+// @Tags         follows
+// @Security     BearerAuth
+// @Param        user_id  path  string  true  "ID of the user who asked"
+// @Success      204      "No Content"
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid user ID"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404      {object}  validation.ErrorResponse  "Follow request not found"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /account/follow-requests/{user_id} [delete]
+func (h *FollowHandler) RejectFollowRequest(c *gin.Context) {
+	follower, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	if err := h.repo.Reject(c.Request.Context(), user.ID, follower); err != nil {
+		writeFollowRequestError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListBlocks godoc
+// @Summary      List who I block
+// @Description  Get the users the caller blocks, most recent first
+// @Tags         follows
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page       query     int  false  "Page number"     default(1) minimum(1)
+// @Param        page_size  query     int  false  "Items per page"  default(20) minimum(1) maximum(100)
+// @Success      200        {object}  ListBlocksResponse
+// @Failure      401        {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      500        {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /account/blocks [get]
+func (h *FollowHandler) ListBlocks(c *gin.Context) {
+	user, _ := auth.UserFrom(c)
+	params := parseFollowListParams(c)
+
+	result, err := h.repo.Blocks(c.Request.Context(), user.ID, params)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"BLOCK_LIST_FAILED",
+			"failed to fetch blocked users",
+		)
+		return
+	}
+
+	data := make([]Block, 0, len(result.Blocks))
+	for _, b := range result.Blocks {
+		data = append(data, Block{UserID: b.BlockedID, CreatedAt: b.CreatedAt})
+	}
+
+	c.JSON(http.StatusOK, ListBlocksResponse{
+		Data:       data,
+		Pagination: followPagination(params, result.Total),
+	})
+}
+
+// listConnections writes a page of the path user's followers or
+// followings, if the caller may see them.
+func (h *FollowHandler) listConnections(c *gin.Context, list func(ctx context.Context, userID string, params repository.FollowListParams) (repository.FollowListResult, error)) {
+	target, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	viewer, _ := auth.UserFrom(c)
+
+	allowed, err := h.repo.CanSeeConnections(ctx, viewer.ID, target)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"FOLLOW_LIST_FAILED",
+			"failed to fetch follows",
+		)
+		return
+	}
+	if !allowed {
+		writeError(c, http.StatusForbidden,
+			"PRIVATE_ACCOUNT",
+			"this account is private",
+		)
+		return
+	}
+
+	params := parseFollowListParams(c)
+	result, err := list(ctx, target, params)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"FOLLOW_LIST_FAILED",
+			"failed to fetch follows",
+		)
+		return
+	}
+
+	writeFollows(c, params, result)
+}
+
+func writeFollows(c *gin.Context, params repository.FollowListParams, result repository.FollowListResult) {
+	data := make([]Follow, 0, len(result.Follows))
+	for _, f := range result.Follows {
+		data = append(data, toFollow(f))
+	}
+
+	c.JSON(http.StatusOK, ListFollowsResponse{
+		Data:       data,
+		Pagination: followPagination(params, result.Total),
+	})
+}
+
+func writeFollowRequestError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrFollowRequestNotFound) {
+		writeError(c, http.StatusNotFound,
+			"FOLLOW_REQUEST_NOT_FOUND",
+			"follow request not found",
+		)
+		return
+	}
+
+	writeError(c, http.StatusInternalServerError,
+		"FOLLOW_REQUEST_UPDATE_FAILED",
+		"failed to update follow request",
+	)
+}
+
+func parseFollowListParams(c *gin.Context) repository.FollowListParams {
+	page := parseIntQuery(c, "page", 1)
+	if page < 1 {
+		page = 1
+	}
+	pageSize := parseIntQuery(c, "page_size", 20)
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	return repository.FollowListParams{Page: page, PageSize: pageSize}
+}
+
+func followPagination(params repository.FollowListParams, total int64) Pagination {
+	return Pagination{
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		Total:      total,
+		TotalPages: int((total + int64(params.PageSize) - 1) / int64(params.PageSize)),
+	}
+}
+
+// parseUserIDParam reads the user_id path parameter.
+func parseUserIDParam(c *gin.Context) (string, bool) {
+	userID := c.Param("user_id")
+	if userID == "" || len(userID) > 64 {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_USER_ID",
+			"user_id must be between 1 and 64 characters",
+		)
+		return "", false
+	}
+	return userID, true
+}
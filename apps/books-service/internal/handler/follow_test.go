@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func TestFollows_FollowListsAndApproval(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	books := repository.NewGormBookRepository(db)
+	router := newTestRouter(
+		NewBookHandler(books),
+		NewReviewHandler(repository.NewReviewRepository(db), books),
+		NewFollowHandler(repository.NewFollowRepository(db)),
+		NewNotificationHandler(repository.NewNotificationRepository(db)),
+	)
+	const aliceID = "6563a1f0c2a4b5d6e7f80911"
+	alice := tokenFor(t, aliceID)
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+	carol := tokenFor(t, "6563a1f0c2a4b5d6e7f80933")
+	dave := tokenFor(t, "6563a1f0c2a4b5d6e7f80944")
+
+	if w := doAuthJSON(router, "", http.MethodPost, "/users/"+aliceID+"/follow", nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, alice, http.MethodPost, "/users/"+aliceID+"/follow", nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 following yourself, got %d", w.Code)
+	}
+
+	for _, token := range []string{bob, carol, dave} {
+		w := doAuthJSON(router, token, http.MethodPost, "/users/"+aliceID+"/follow", nil)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+		}
+	}
+	if w := doAuthJSON(router, bob, http.MethodPost, "/users/"+aliceID+"/follow", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 following twice, got %d", w.Code)
+	}
+
+	first := getJSON[ListFollowsResponse](t, router, "", "/users/"+aliceID+"/followers?page_size=2")
+	if len(first.Data) != 2 || first.Pagination.Total != 3 || first.Pagination.TotalPages != 2 {
+		t.Fatalf("expected a first page of 2 out of 3 followers, got %+v", first)
+	}
+	if got := getJSON[ListFollowsResponse](t, router, "", "/users/6563a1f0c2a4b5d6e7f80922/following"); len(got.Data) != 1 || got.Data[0].FolloweeID != aliceID {
+		t.Fatalf("expected bob to follow alice, got %+v", got.Data)
+	}
+	if inbox := getJSON[ListNotificationsResponse](t, router, alice, "/notifications"); len(inbox.Data) != 3 || inbox.Data[0].Type != "new-follower" {
+		t.Fatalf("expected three new-follower notifications, got %+v", inbox.Data)
+	}
+
+	if w := doAuthJSON(router, dave, http.MethodDelete, "/users/"+aliceID+"/follow", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, dave, http.MethodDelete, "/users/"+aliceID+"/follow", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 unfollowing twice, got %d", w.Code)
+	}
+
+	// A private account approves new followers and hides its lists.
+	if w := doAuthJSON(router, alice, http.MethodPut, "/account/settings", map[string]any{"private": true}); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	w := doAuthJSON(router, dave, http.MethodPost, "/users/"+aliceID+"/follow", nil)
+	var pending FollowResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &pending); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if pending.Data.Status != "pending" || pending.Data.AcceptedAt != nil {
+		t.Fatalf("expected a pending follow, got %+v", pending.Data)
+	}
+
+	if w := doAuthJSON(router, dave, http.MethodGet, "/users/"+aliceID+"/followers", nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for a pending follower, got %d", w.Code)
+	}
+	if got := getJSON[ListFollowsResponse](t, router, bob, "/users/"+aliceID+"/followers"); len(got.Data) != 2 {
+		t.Fatalf("expected an accepted follower to see the 2 followers, got %+v", got.Data)
+	}
+
+	requests := getJSON[ListFollowsResponse](t, router, alice, "/account/follow-requests")
+	if len(requests.Data) != 1 || requests.Data[0].FollowerID != "6563a1f0c2a4b5d6e7f80944" {
+		t.Fatalf("expected dave's request, got %+v", requests.Data)
+	}
+	if w := doAuthJSON(router, alice, http.MethodPost, "/account/follow-requests/6563a1f0c2a4b5d6e7f80933/approve", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 approving a follow that isn't pending, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, alice, http.MethodPost, "/account/follow-requests/6563a1f0c2a4b5d6e7f80944/approve", nil); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if got := getJSON[ListFollowsResponse](t, router, dave, "/users/"+aliceID+"/followers"); len(got.Data) != 3 {
+		t.Fatalf("expected 3 followers after approval, got %+v", got.Data)
+	}
+}
+
+func TestFollows_BlockingHidesBooksAndReviews(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	books := repository.NewGormBookRepository(db)
+	router := newTestRouter(
+		NewBookHandler(books),
+		NewReviewHandler(repository.NewReviewRepository(db), books),
+		NewFollowHandler(repository.NewFollowRepository(db)),
+		NewNotificationHandler(repository.NewNotificationRepository(db)),
+	)
+	const aliceID, bobID = "6563a1f0c2a4b5d6e7f80911", "6563a1f0c2a4b5d6e7f80922"
+	alice := tokenFor(t, aliceID)
+	bob := tokenFor(t, bobID)
+
+	author := testutil.SeedAuthor(t, db, "Octavia E. Butler")
+	catalog := testutil.SeedBook(t, db, author, "Kindred", "", nil)
+
+	w := doAuthJSON(router, alice, http.MethodPost, "/books", map[string]any{
+		"title": "Parable of the Sower", "author_id": author.ID.String(),
+	})
+	var owned BookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &owned); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	reviewsPath := "/books/" + catalog.ID.String() + "/reviews"
+	if w := doAuthJSON(router, alice, http.MethodPost, reviewsPath, map[string]any{"rating": 5}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+	doAuthJSON(router, bob, http.MethodPost, "/users/"+aliceID+"/follow", nil)
+
+	if w := doAuthJSON(router, alice, http.MethodPost, "/users/"+bobID+"/block", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, alice, http.MethodPost, "/users/"+bobID+"/block", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 blocking twice, got %d", w.Code)
+	}
+
+	if got := getJSON[ListFollowsResponse](t, router, "", "/users/"+aliceID+"/followers"); len(got.Data) != 0 {
+		t.Fatalf("expected blocking to remove bob's follow, got %+v", got.Data)
+	}
+	if w := doAuthJSON(router, bob, http.MethodPost, "/users/"+aliceID+"/follow", nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 following a user who blocks you, got %d", w.Code)
+	}
+
+	if w := doAuthJSON(router, bob, http.MethodGet, "/books/"+owned.Data.ID.String(), nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for the blocker's book, got %d", w.Code)
+	}
+	if got := visibleBookTitles(t, router, bob, "/books"); len(got) != 1 || got[0] != "Kindred" {
+		t.Fatalf("expected bob to see only the catalog book, got %v", got)
+	}
+	if got := visibleBookTitles(t, router, "", "/books"); len(got) != 2 {
+		t.Fatalf("expected others to still see both books, got %v", got)
+	}
+
+	var reviews ListReviewsResponse
+	w = doAuthJSON(router, bob, http.MethodGet, reviewsPath, nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &reviews); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(reviews.Data) != 0 {
+		t.Fatalf("expected the blocker's review hidden from bob, got %+v", reviews.Data)
+	}
+
+	var blocks ListBlocksResponse
+	w = doAuthJSON(router, alice, http.MethodGet, "/account/blocks", nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &blocks); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(blocks.Data) != 1 || blocks.Data[0].UserID != bobID {
+		t.Fatalf("expected alice to block bob, got %+v", blocks.Data)
+	}
+
+	if w := doAuthJSON(router, alice, http.MethodDelete, "/users/"+bobID+"/block", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, bob, http.MethodGet, "/books/"+owned.Data.ID.String(), nil); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 once unblocked, got %d", w.Code)
+	}
+}
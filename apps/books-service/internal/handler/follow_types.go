@@ -0,0 +1,42 @@
+package handler
+
+import "time"
+
+type Follow struct {
+	FollowerID string     `json:"follower_id" example:"6563a1f0c2a4b5d6e7f80911"`
+	FolloweeID string     `json:"followee_id" example:"6563a1f0c2a4b5d6e7f80922"`
+	Status     string     `json:"status" example:"accepted"`
+	CreatedAt  time.Time  `json:"created_at" example:"2025-11-24T08:00:00Z"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty" example:"2025-11-24T09:00:00Z"`
+}
+
+type FollowResponse struct {
+	Data Follow `json:"data"`
+}
+
+type ListFollowsResponse struct {
+	Data       []Follow   `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type Block struct {
+	UserID    string    `json:"user_id" example:"6563a1f0c2a4b5d6e7f80922"`
+	CreatedAt time.Time `json:"created_at" example:"2025-11-24T08:00:00Z"`
+}
+
+type ListBlocksResponse struct {
+	Data       []Block    `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type AccountSettings struct {
+	Private bool `json:"private" example:"false"`
+}
+
+type AccountSettingsResponse struct {
+	Data AccountSettings `json:"data"`
+}
+
+type UpdateAccountSettingsRequest struct {
+	Private *bool `json:"private" binding:"required" example:"true"`
+}
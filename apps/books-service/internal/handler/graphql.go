@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/graph"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+)
+
+type GraphQLHandler struct {
+	server *graph.Server
+}
+
+func NewGraphQLHandler(server *graph.Server) *GraphQLHandler {
+	return &GraphQLHandler{server: server}
+}
+
+func (h *GraphQLHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/graphql", h.GraphQL)
+}
+
+// GraphQL godoc
+// @Summary      Run a GraphQL query
+// @Description  Query books, authors, reviews and your shelves, and change books and authors, with GraphQL; also served at /graphql outside the /api prefix. The schema is in internal/graph/schema.graphql. Errors carry a code in their extensions, and validation errors list the failing fields like the REST endpoints do. Selections may nest 10 levels deep, and operations whose estimated cost, counting each field once per item of the pages around it, exceeds 5000 are rejected with QUERY_TOO_COMPLEX.
+// @Tags         graphql
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      graph.Request             true  "GraphQL request"
+// @Success      200      {object}  object                    "GraphQL response with data and errors"
+// @Failure      400      {object}  validation.ErrorResponse  "Malformed request body"
+// @Router       /graphql [post]
+func (h *GraphQLHandler) GraphQL(c *gin.Context) {
+	var req graph.Request
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	c.JSON(http.StatusOK, h.server.Exec(c.Request.Context(), req))
+}
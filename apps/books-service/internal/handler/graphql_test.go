@@ -0,0 +1,530 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/cover"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/graph"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/storage"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func setupGraphQLRouter(t *testing.T, db *gorm.DB, opts ...graph.Option) *gin.Engine {
+	t.Helper()
+
+	server, err := graph.NewServer(
+		repository.NewGormBookRepository(db),
+		repository.NewAuthorRepository(db),
+		repository.NewReviewRepository(db),
+		repository.NewShelfRepository(db),
+		opts...,
+	)
+	if err != nil {
+		t.Fatalf("failed to build graphql server: %v", err)
+	}
+	return newTestRouter(NewGraphQLHandler(server))
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func doGraphQL(t *testing.T, router *gin.Engine, token, query string, variables map[string]any, data any) graphQLResponse {
+	t.Helper()
+
+	w := doAuthJSON(router, token, http.MethodPost, "/graphql", map[string]any{
+		"query": query, "variables": variables,
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp graphQLResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if data != nil && len(resp.Data) > 0 && string(resp.Data) != "null" {
+		if err := json.Unmarshal(resp.Data, data); err != nil {
+			t.Fatalf("failed to unmarshal data: %v", err)
+		}
+	}
+	return resp
+}
+
+func errorCode(resp graphQLResponse) string {
+	if len(resp.Errors) == 0 {
+		return ""
+	}
+	code, _ := resp.Errors[0].Extensions["code"].(string)
+	return code
+}
+
+// countQueries counts the SELECTs run against db.
+func countQueries(t *testing.T, db *gorm.DB) *atomic.Int64 {
+	t.Helper()
+
+	var n atomic.Int64
+	err := db.Callback().Query().Before("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		n.Add(1)
+	})
+	if err != nil {
+		t.Fatalf("failed to register callback: %v", err)
+	}
+	err = db.Callback().Raw().Before("gorm:raw").Register("test:count_raw", func(*gorm.DB) {
+		n.Add(1)
+	})
+	if err != nil {
+		t.Fatalf("failed to register callback: %v", err)
+	}
+	return &n
+}
+
+const nestedBooksQuery = `query {
+  books(first: 50, sort: TITLE_ASC) {
+    totalCount
+    edges {
+      node {
+        title
+        author {
+          name
+          books(first: 2) {
+            totalCount
+            edges { node { title tags } }
+          }
+        }
+        reviews { totalCount edges { node { rating } } }
+      }
+    }
+  }
+}`
+
+type nestedBooksData struct {
+	Books struct {
+		TotalCount int
+		Edges      []struct {
+			Node struct {
+				Title  string
+				Author struct {
+					Name  string
+					Books struct {
+						TotalCount int
+						Edges      []struct {
+							Node struct {
+								Title string
+								Tags  []string
+							}
+						}
+					}
+				}
+				Reviews struct {
+					TotalCount int
+					Edges      []struct{ Node struct{ Rating int } }
+				}
+			}
+		}
+	}
+}
+
+func TestGraphQL_NestedQueriesAreBatched(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := setupGraphQLRouter(t, db)
+
+	seedAuthorWithBooks := func(name string, books int) {
+		author := testutil.SeedAuthor(t, db, name)
+		for i := range books {
+			book := testutil.SeedBook(t, db, author, fmt.Sprintf("%s %d", name, i+1), "", nil)
+			review := model.Review{BookID: book.ID, UserID: "reader", Rating: 4}
+			if err := db.Create(&review).Error; err != nil {
+				t.Fatalf("failed to seed review: %v", err)
+			}
+		}
+	}
+	seedAuthorWithBooks("Asimov", 3)
+	seedAuthorWithBooks("Butler", 2)
+
+	queries := countQueries(t, db)
+
+	var data nestedBooksData
+	resp := doGraphQL(t, router, "", nestedBooksQuery, nil, &data)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	if data.Books.TotalCount != 5 || len(data.Books.Edges) != 5 {
+		t.Fatalf("expected 5 books, got %d of %d", len(data.Books.Edges), data.Books.TotalCount)
+	}
+	first := data.Books.Edges[0].Node
+	if first.Title != "Asimov 1" || first.Author.Name != "Asimov" {
+		t.Fatalf("expected Asimov 1 by Asimov first, got %q by %q", first.Title, first.Author.Name)
+	}
+	if first.Author.Books.TotalCount != 3 || len(first.Author.Books.Edges) != 2 {
+		t.Fatalf("expected 2 of the author's 3 books, got %d of %d",
+			len(first.Author.Books.Edges), first.Author.Books.TotalCount)
+	}
+	if first.Reviews.TotalCount != 1 || first.Reviews.Edges[0].Node.Rating != 4 {
+		t.Fatalf("expected the book's review, got %+v", first.Reviews)
+	}
+	small := queries.Swap(0)
+
+	// More authors and books mustn't mean more queries.
+	seedAuthorWithBooks("Clarke", 4)
+	seedAuthorWithBooks("Delany", 3)
+	queries.Store(0)
+
+	resp = doGraphQL(t, router, "", nestedBooksQuery, nil, &data)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	if data.Books.TotalCount != 12 {
+		t.Fatalf("expected 12 books, got %d", data.Books.TotalCount)
+	}
+	if large := queries.Load(); large != small {
+		t.Fatalf("expected the same number of queries for more books, got %d then %d", small, large)
+	}
+}
+
+func TestGraphQL_BooksPagination(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := setupGraphQLRouter(t, db)
+
+	author := testutil.SeedAuthor(t, db, "Le Guin")
+	for _, title := range []string{"A", "B", "C"} {
+		testutil.SeedBook(t, db, author, title, "", nil)
+	}
+
+	type page struct {
+		Books struct {
+			Edges    []struct{ Node struct{ Title string } }
+			PageInfo struct {
+				HasNextPage bool
+				EndCursor   *string
+			}
+		}
+	}
+	query := `query ($after: String) {
+	  books(first: 2, after: $after, sort: TITLE_ASC) {
+	    edges { node { title } }
+	    pageInfo { hasNextPage endCursor }
+	  }
+	}`
+
+	var p1 page
+	if resp := doGraphQL(t, router, "", query, nil, &p1); len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	if len(p1.Books.Edges) != 2 || p1.Books.Edges[1].Node.Title != "B" || !p1.Books.PageInfo.HasNextPage {
+		t.Fatalf("expected A and B with a next page, got %+v", p1.Books)
+	}
+
+	var p2 page
+	resp := doGraphQL(t, router, "", query, map[string]any{"after": *p1.Books.PageInfo.EndCursor}, &p2)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	if len(p2.Books.Edges) != 1 || p2.Books.Edges[0].Node.Title != "C" || p2.Books.PageInfo.HasNextPage {
+		t.Fatalf("expected only C, got %+v", p2.Books)
+	}
+
+	resp = doGraphQL(t, router, "", query, map[string]any{"after": "bogus"}, nil)
+	if code := errorCode(resp); code != "INVALID_CURSOR" {
+		t.Fatalf("expected INVALID_CURSOR, got %q", code)
+	}
+}
+
+func TestGraphQL_Mutations(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := setupGraphQLRouter(t, db)
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+
+	var created struct {
+		CreateAuthor struct{ ID, Name string }
+	}
+	resp := doGraphQL(t, router, alice, `mutation { createAuthor(input: {name: "Octavia Butler"}) { id name } }`, nil, &created)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	authorID := created.CreateAuthor.ID
+
+	createBook := `mutation ($input: CreateBookInput!) {
+	  createBook(input: $input) { id title isbn visibility tags author { name } }
+	}`
+	var book struct {
+		CreateBook struct {
+			ID, Title, Visibility string
+			ISBN                  *string
+			Tags                  []string
+			Author                struct{ Name string }
+		}
+	}
+	resp = doGraphQL(t, router, alice, createBook, map[string]any{"input": map[string]any{
+		"title": "Kindred", "authorId": authorID, "isbn": "978-0-441-17271-9",
+		"tags": []string{"classic"}, "visibility": "private",
+	}}, &book)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	if b := book.CreateBook; b.Author.Name != "Octavia Butler" || b.ISBN == nil || *b.ISBN != "9780441172719" ||
+		b.Visibility != model.VisibilityPrivate || len(b.Tags) != 1 {
+		t.Fatalf("unexpected created book: %+v", b)
+	}
+	bookID := book.CreateBook.ID
+
+	resp = doGraphQL(t, router, alice, createBook, map[string]any{"input": map[string]any{
+		"title": "Dawn", "authorId": authorID, "format": "scroll",
+	}}, nil)
+	if code := errorCode(resp); code != "VALIDATION_ERROR" {
+		t.Fatalf("expected VALIDATION_ERROR, got %q", code)
+	}
+	fields, _ := resp.Errors[0].Extensions["errors"].([]any)
+	if len(fields) != 1 || fields[0].(map[string]any)["field"] != "format" {
+		t.Fatalf("expected a format field error, got %v", resp.Errors[0].Extensions)
+	}
+
+	resp = doGraphQL(t, router, alice, createBook, map[string]any{"input": map[string]any{
+		"title": "Dawn", "authorId": "00000000-0000-4000-8000-000000000000",
+	}}, nil)
+	if code := errorCode(resp); code != "AUTHOR_NOT_FOUND" {
+		t.Fatalf("expected AUTHOR_NOT_FOUND, got %q", code)
+	}
+
+	resp = doGraphQL(t, router, "", createBook, map[string]any{"input": map[string]any{
+		"title": "Dawn", "authorId": authorID, "visibility": "private",
+	}}, nil)
+	if code := errorCode(resp); code != "UNAUTHORIZED" {
+		t.Fatalf("expected UNAUTHORIZED, got %q", code)
+	}
+
+	updateBook := `mutation ($id: ID!, $input: UpdateBookInput!) {
+	  updateBook(id: $id, input: $input) { title publishedAt }
+	}`
+	var updated struct {
+		UpdateBook struct {
+			Title       string
+			PublishedAt *string
+		}
+	}
+	resp = doGraphQL(t, router, alice, updateBook, map[string]any{
+		"id": bookID, "input": map[string]any{"title": "Kindred (25th Anniversary)", "publishedAt": "2004-02-01"},
+	}, &updated)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	if u := updated.UpdateBook; u.Title != "Kindred (25th Anniversary)" || u.PublishedAt == nil || *u.PublishedAt != "2004-02-01" {
+		t.Fatalf("unexpected updated book: %+v", u)
+	}
+
+	resp = doGraphQL(t, router, alice, updateBook, map[string]any{"id": bookID, "input": map[string]any{}}, nil)
+	if code := errorCode(resp); code != "NO_FIELDS_TO_UPDATE" {
+		t.Fatalf("expected NO_FIELDS_TO_UPDATE, got %q", code)
+	}
+
+	// The book is private, so to bob it doesn't exist.
+	resp = doGraphQL(t, router, bob, updateBook, map[string]any{"id": bookID, "input": map[string]any{"title": "Mine"}}, nil)
+	if code := errorCode(resp); code != "BOOK_NOT_FOUND" {
+		t.Fatalf("expected BOOK_NOT_FOUND, got %q", code)
+	}
+
+	// Once public, others can see alice's book but still not change it.
+	resp = doGraphQL(t, router, alice, updateBook, map[string]any{"id": bookID, "input": map[string]any{"visibility": "public"}}, nil)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	resp = doGraphQL(t, router, bob, updateBook, map[string]any{"id": bookID, "input": map[string]any{"title": "Mine"}}, nil)
+	if code := errorCode(resp); code != "FORBIDDEN" {
+		t.Fatalf("expected FORBIDDEN updating alice's book, got %q", code)
+	}
+	for _, token := range []string{bob, ""} {
+		resp = doGraphQL(t, router, token, `mutation ($id: ID!) { deleteBook(id: $id) }`, map[string]any{"id": bookID}, nil)
+		if code := errorCode(resp); code != "FORBIDDEN" {
+			t.Fatalf("expected FORBIDDEN deleting alice's book, got %q", code)
+		}
+	}
+
+	var deleted struct{ DeleteBook bool }
+	resp = doGraphQL(t, router, alice, `mutation ($id: ID!) { deleteBook(id: $id) }`, map[string]any{"id": bookID}, &deleted)
+	if len(resp.Errors) > 0 || !deleted.DeleteBook {
+		t.Fatalf("expected the book to be deleted, got %+v", resp.Errors)
+	}
+
+	var found struct{ Book *struct{ ID string } }
+	doGraphQL(t, router, alice, `query ($id: ID!) { book(id: $id) { id } }`, map[string]any{"id": bookID}, &found)
+	if found.Book != nil {
+		t.Fatalf("expected the deleted book to be gone")
+	}
+}
+
+func TestGraphQL_Limits(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := setupGraphQLRouter(t, db)
+
+	deep := "books(first: 1) { edges { node { author { books(first: 1) { edges { node { author { books(first: 1) { edges { node { title } } } } } } } } } } }"
+	resp := doGraphQL(t, router, "", "{ "+deep+" }", nil, nil)
+	if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "depth") {
+		t.Fatalf("expected a depth error, got %+v", resp.Errors)
+	}
+
+	costly := `query ($n: Int) {
+	  books(first: 100) { edges { node { reviews(first: $n) { edges { node { id rating } } } } } }
+	}`
+	resp = doGraphQL(t, router, "", costly, map[string]any{"n": 100}, nil)
+	if code := errorCode(resp); code != "QUERY_TOO_COMPLEX" {
+		t.Fatalf("expected QUERY_TOO_COMPLEX, got %q (%+v)", code, resp.Errors)
+	}
+
+	resp = doGraphQL(t, router, "", costly, map[string]any{"n": 5}, nil)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("expected a cheaper query to run, got %+v", resp.Errors)
+	}
+
+	resp = doGraphQL(t, router, "", "{ books(first: 500) { totalCount } }", nil, nil)
+	if code := errorCode(resp); code != "INVALID_FIRST" {
+		t.Fatalf("expected INVALID_FIRST, got %q", code)
+	}
+
+	if w := doAuthJSON(router, "", http.MethodPost, "/graphql", map[string]any{}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without a query, got %d", w.Code)
+	}
+}
+
+func TestGraphQL_DeleteBookRemovesCoverBlobs(t *testing.T) {
+	db := testutil.NewTestDB(t)
+
+	dir := t.TempDir()
+	store, err := storage.NewLocalStore(dir, "http://cdn.test")
+	if err != nil {
+		t.Fatalf("failed to create local store: %v", err)
+	}
+	covers := cover.NewService(store, cover.DefaultMaxBytes)
+	router := setupGraphQLRouter(t, db, graph.WithCovers(covers))
+
+	author := testutil.SeedAuthor(t, db, "Le Guin")
+	book := testutil.SeedBook(t, db, author, "The Dispossessed", "", nil)
+
+	key, err := covers.Upload(context.Background(), book.ID, bytes.NewReader(pngBytes(t, 50, 50)))
+	if err != nil {
+		t.Fatalf("failed to upload cover: %v", err)
+	}
+	if err := db.Model(&model.Book{}).Where("id = ?", book.ID).Update("cover_key", key).Error; err != nil {
+		t.Fatalf("failed to set cover key: %v", err)
+	}
+
+	var data struct{ DeleteBook bool }
+	resp := doGraphQL(t, router, "", `mutation ($id: ID!) { deleteBook(id: $id) }`,
+		map[string]any{"id": book.ID.String()}, &data)
+	if len(resp.Errors) > 0 || !data.DeleteBook {
+		t.Fatalf("expected the book to be deleted, got %+v", resp.Errors)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, key)); !os.IsNotExist(err) {
+		t.Errorf("expected original cover to be removed, stat err=%v", err)
+	}
+}
+
+func TestGraphQL_Shelves(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := setupGraphQLRouter(t, db)
+	shelves := repository.NewShelfRepository(db)
+	ctx := context.Background()
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+
+	wantToRead, err := shelves.Find(ctx, "6563a1f0c2a4b5d6e7f80911", model.ShelfWantToRead)
+	if err != nil {
+		t.Fatalf("failed to find shelf: %v", err)
+	}
+	author := testutil.SeedAuthor(t, db, "N. K. Jemisin")
+	added := time.Now().Add(-time.Hour)
+	for i, title := range []string{"The Fifth Season", "The Obelisk Gate", "The Stone Sky"} {
+		book := testutil.SeedBook(t, db, author, title, "", nil)
+		entry := model.ShelfEntry{BookID: book.ID, AddedAt: added.Add(time.Duration(i) * time.Minute)}
+		if err := shelves.PutEntry(ctx, wantToRead, &entry); err != nil {
+			t.Fatalf("failed to shelve book: %v", err)
+		}
+	}
+
+	const query = `query {
+  shelves {
+    slug
+    builtin
+    books(first: 2) {
+      totalCount
+      pageInfo { hasNextPage }
+      edges { node { addedAt book { title author { name } } } }
+    }
+  }
+}`
+	if resp := doGraphQL(t, router, "", query, nil, nil); errorCode(resp) != "UNAUTHORIZED" {
+		t.Fatalf("expected UNAUTHORIZED without a token, got %+v", resp.Errors)
+	}
+
+	var data struct {
+		Shelves []struct {
+			Slug    string
+			Builtin bool
+			Books   struct {
+				TotalCount int
+				PageInfo   struct{ HasNextPage bool }
+				Edges      []struct {
+					Node struct {
+						Book struct {
+							Title  string
+							Author struct{ Name string }
+						}
+					}
+				}
+			}
+		}
+	}
+	if resp := doGraphQL(t, router, alice, query, nil, &data); len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	if len(data.Shelves) != len(model.BuiltinShelves) {
+		t.Fatalf("expected the built-in shelves, got %+v", data.Shelves)
+	}
+	for _, s := range data.Shelves {
+		if s.Slug != model.ShelfWantToRead {
+			if s.Books.TotalCount != 0 {
+				t.Fatalf("expected %s to be empty, got %d books", s.Slug, s.Books.TotalCount)
+			}
+			continue
+		}
+		if s.Books.TotalCount != 3 || !s.Books.PageInfo.HasNextPage || len(s.Books.Edges) != 2 {
+			t.Fatalf("expected the first 2 of 3 books, got %+v", s.Books)
+		}
+		if got := s.Books.Edges[0].Node.Book; got.Title != "The Stone Sky" || got.Author.Name != "N. K. Jemisin" {
+			t.Fatalf("expected the latest book first with its author, got %+v", got)
+		}
+	}
+
+	var one struct {
+		Shelf *struct{ Name string }
+	}
+	doGraphQL(t, router, alice, `query { shelf(ref: "want-to-read") { name } }`, nil, &one)
+	if one.Shelf == nil || one.Shelf.Name != "Want to Read" {
+		t.Fatalf("expected alice's Want to Read shelf, got %+v", one.Shelf)
+	}
+	one.Shelf = nil
+	resp := doGraphQL(t, router, bob, `query($ref: String!) { shelf(ref: $ref) { name } }`,
+		map[string]any{"ref": wantToRead.ID.String()}, &one)
+	if len(resp.Errors) > 0 || one.Shelf != nil {
+		t.Fatalf("expected another member's shelf to be null, got %+v, %+v", one.Shelf, resp.Errors)
+	}
+}
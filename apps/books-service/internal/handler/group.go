@@ -0,0 +1,671 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"gorm.io/gorm"
+)
+
+const defaultGroupInviteTTL = 7 * 24 * time.Hour
+
+type GroupHandler struct {
+	repo repository.GroupRepository
+}
+
+func NewGroupHandler(repo repository.GroupRepository) *GroupHandler {
+	return &GroupHandler{repo: repo}
+}
+
+func (h *GroupHandler) RegisterRoutes(r *gin.RouterGroup) {
+	groups := r.Group("/groups", auth.Required())
+	{
+		groups.GET("", h.ListGroups)
+		groups.POST("", h.CreateGroup)
+		groups.POST("/join", h.JoinGroup)
+		groups.GET("/:group_id", h.GetGroup)
+		groups.PATCH("/:group_id", h.UpdateGroup)
+		groups.DELETE("/:group_id", h.DeleteGroup)
+		groups.GET("/:group_id/members", h.ListGroupMembers)
+		groups.PATCH("/:group_id/members/:user_id", h.UpdateGroupMember)
+		groups.DELETE("/:group_id/members/:user_id", h.RemoveGroupMember)
+		groups.GET("/:group_id/invites", h.ListGroupInvites)
+		groups.POST("/:group_id/invites", h.CreateGroupInvite)
+		groups.DELETE("/:group_id/invites/:invite_id", h.RevokeGroupInvite)
+	}
+}
+
+func toGroup(g model.Group) Group {
+	return Group{
+		ID:          g.ID,
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedAt:   model.Date{Time: g.CreatedAt},
+	}
+}
+
+func toGroupMember(m model.GroupMember) GroupMember {
+	return GroupMember{
+		UserID:   m.UserID,
+		Role:     m.Role,
+		JoinedAt: model.Date{Time: m.JoinedAt},
+	}
+}
+
+func toGroupInvite(i model.GroupInvite) GroupInvite {
+	return GroupInvite{
+		ID:        i.ID,
+		GroupID:   i.GroupID,
+		Token:     i.Token,
+		CreatedBy: i.CreatedBy,
+		ExpiresAt: i.ExpiresAt,
+		Uses:      i.Uses,
+	}
+}
+
+// ListGroups godoc
+// @Summary      List my groups
+// @Description  Get the groups the caller belongs to, with their role and the member count
+// @Tags         groups
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  ListGroupsResponse
+// @Failure      401  {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /groups [get]
+func (h *GroupHandler) ListGroups(c *gin.Context) {
+	user, _ := auth.UserFrom(c)
+
+	groups, err := h.repo.ListForUser(c.Request.Context(), user.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_LIST_FAILED",
+			"failed to fetch groups",
+		)
+		return
+	}
+
+	data := make([]Group, 0, len(groups))
+	for _, g := range groups {
+		res := toGroup(g.Group)
+		res.Role = g.Role
+		res.MemberCount = g.MemberCount
+		data = append(data, res)
+	}
+
+	c.JSON(http.StatusOK, ListGroupsResponse{Data: data})
+}
+
+// CreateGroup godoc
+// @Summary      Create a group
+// @Description  Create a group with the caller as its owner
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        payload  body      CreateGroupRequest        true  "Group to create"
+// @Success      201      {object}  GroupResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /groups [post]
+func (h *GroupHandler) CreateGroup(c *gin.Context) {
+	var req CreateGroupRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	group := model.Group{Name: req.Name, Description: req.Description}
+
+	if err := h.repo.Create(c.Request.Context(), &group, user.ID); err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_CREATE_FAILED",
+			"failed to create group",
+		)
+		return
+	}
+
+	res := toGroup(group)
+	res.Role = model.GroupRoleOwner
+	res.MemberCount = 1
+	c.JSON(http.StatusCreated, GroupResponse{Data: res})
+}
+
+// JoinGroup godoc
+// @Summary      Join a group
+// @Description  Join a group with an invite token
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        payload  body      JoinGroupRequest          true  "Invite token"
+// @Success      201      {object}  GroupMemberResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404      {object}  validation.ErrorResponse  "Invite not found"
+// @Failure      409      {object}  validation.ErrorResponse  "Already a member"
+// @Failure      410      {object}  validation.ErrorResponse  "Invite expired"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /groups/join [post]
+func (h *GroupHandler) JoinGroup(c *gin.Context) {
+	var req JoinGroupRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	member, err := h.repo.Join(c.Request.Context(), req.Token, user.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInviteNotFound):
+			writeError(c, http.StatusNotFound,
+				"INVITE_NOT_FOUND",
+				"invite not found",
+			)
+		case errors.Is(err, repository.ErrInviteExpired):
+			writeError(c, http.StatusGone,
+				"INVITE_EXPIRED",
+				"invite has expired",
+			)
+		case errors.Is(err, repository.ErrAlreadyGroupMember):
+			writeError(c, http.StatusConflict,
+				"ALREADY_GROUP_MEMBER",
+				"you are already a member of this group",
+			)
+		default:
+			writeError(c, http.StatusInternalServerError,
+				"GROUP_JOIN_FAILED",
+				"failed to join group",
+			)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, GroupMemberResponse{Data: toGroupMember(*member)})
+}
+
+// GetGroup godoc
+// @Summary      Get a group
+// @Description  Get a group the caller belongs to
+// @Tags         groups
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id  path      string                    true  "Group ID (UUID)"
+// @Success      200       {object}  GroupResponse
+// @Failure      400       {object}  validation.ErrorResponse  "Invalid group ID"
+// @Failure      401       {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404       {object}  validation.ErrorResponse  "Group not found"
+// @Failure      500       {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /groups/{group_id} [get]
+func (h *GroupHandler) GetGroup(c *gin.Context) {
+	member, ok := h.requireRole(c, model.GroupRoleMember)
+	if !ok {
+		return
+	}
+
+	h.writeGroup(c, member)
+}
+
+// UpdateGroup godoc
+// @Summary      Update a group
+// @Description  Rename a group or change its description. Admins and the owner only.
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id  path      string                    true  "Group ID (UUID)"
+// @Param        payload   body      UpdateGroupRequest        true  "Fields to update"
+// @Success      200       {object}  GroupResponse
+// @Failure      400       {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401       {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403       {object}  validation.ErrorResponse  "Not an admin of the group"
+// @Failure      404       {object}  validation.ErrorResponse  "Group not found"
+// @Failure      500       {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /groups/{group_id} [patch]
+func (h *GroupHandler) UpdateGroup(c *gin.Context) {
+	member, ok := h.requireRole(c, model.GroupRoleAdmin)
+	if !ok {
+		return
+	}
+
+	var req UpdateGroupRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	if req.Name == nil && req.Description == nil {
+		writeError(c, http.StatusBadRequest,
+			"NO_FIELDS_TO_UPDATE",
+			"at least one field must be provided",
+		)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	group, err := h.repo.FindByID(ctx, member.GroupID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_FETCH_FAILED",
+			"failed to fetch group",
+		)
+		return
+	}
+
+	if req.Name != nil {
+		group.Name = *req.Name
+	}
+	if req.Description != nil {
+		group.Description = *req.Description
+	}
+
+	if err := h.repo.Update(ctx, group); err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_UPDATE_FAILED",
+			"failed to update group",
+		)
+		return
+	}
+
+	h.writeGroup(c, member)
+}
+
+// DeleteGroup godoc
+// @Summary      Delete a group
+// @Description  Delete a group. Copies shared only with it become private. The owner only.
+// @Tags         groups
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id  path  string  true  "Group ID (UUID)"
+// @Success      204       "No Content"
+// @Failure      400       {object}  validation.ErrorResponse  "Invalid group ID"
+// @Failure      401       {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403       {object}  validation.ErrorResponse  "Not the owner of the group"
+// @Failure      404       {object}  validation.ErrorResponse  "Group not found"
+// @Failure      500       {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /groups/{group_id} [delete]
+func (h *GroupHandler) DeleteGroup(c *gin.Context) {
+	member, ok := h.requireRole(c, model.GroupRoleOwner)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), member.GroupID); err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_DELETE_FAILED",
+			"failed to delete group",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListGroupMembers godoc
+// @Summary      List group members
+// @Description  Get a group's members and their roles, longest-standing first
+// @Tags         groups
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id  path      string                    true  "Group ID (UUID)"
+// @Success      200       {object}  ListGroupMembersResponse
+// @Failure      400       {object}  validation.ErrorResponse  "Invalid group ID"
+// @Failure      401       {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404       {object}  validation.ErrorResponse  "Group not found"
+// @Failure      500       {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /groups/{group_id}/members [get]
+func (h *GroupHandler) ListGroupMembers(c *gin.Context) {
+	member, ok := h.requireRole(c, model.GroupRoleMember)
+	if !ok {
+		return
+	}
+
+	members, err := h.repo.Members(c.Request.Context(), member.GroupID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_MEMBER_LIST_FAILED",
+			"failed to fetch group members",
+		)
+		return
+	}
+
+	data := make([]GroupMember, 0, len(members))
+	for _, m := range members {
+		data = append(data, toGroupMember(m))
+	}
+
+	c.JSON(http.StatusOK, ListGroupMembersResponse{Data: data})
+}
+
+// UpdateGroupMember godoc
+// @Summary      Change a member's role
+// @Description  Make a member an admin or a plain member. Admins and the owner only; the owner's role can't change, and only the owner can demote an admin.
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id  path      string                    true  "Group ID (UUID)"
+// @Param        user_id   path      string                    true  "Member's user ID"
+// @Param        payload   body      UpdateGroupMemberRequest  true  "New role"
+// @Success      200       {object}  GroupMemberResponse
+// @Failure      400       {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401       {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403       {object}  validation.ErrorResponse  "Not allowed to change this member"
+// @Failure      404       {object}  validation.ErrorResponse  "Group or member not found"
+// @Failure      500       {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /groups/{group_id}/members/{user_id} [patch]
+func (h *GroupHandler) UpdateGroupMember(c *gin.Context) {
+	caller, ok := h.requireRole(c, model.GroupRoleAdmin)
+	if !ok {
+		return
+	}
+
+	var req UpdateGroupMemberRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	target, ok := h.findMember(c, caller.GroupID, c.Param("user_id"))
+	if !ok {
+		return
+	}
+	if !canManage(caller, target) {
+		writeError(c, http.StatusForbidden,
+			"FORBIDDEN",
+			"you can't change this member's role",
+		)
+		return
+	}
+
+	updated, err := h.repo.SetRole(c.Request.Context(), caller.GroupID, target.UserID, req.Role)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_MEMBER_UPDATE_FAILED",
+			"failed to update member",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, GroupMemberResponse{Data: toGroupMember(*updated)})
+}
+
+// RemoveGroupMember godoc
+// @Summary      Remove a member
+// @Description  Remove a member from a group, or leave it by passing your own user ID. Admins can remove members and the owner can remove admins; the owner can't leave. The member's copies stop being shared with the group; those shared with no other group become private.
+// @Tags         groups
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id  path  string  true  "Group ID (UUID)"
+// @Param        user_id   path  string  true  "Member's user ID"
+// @Success      204       "No Content"
+// @Failure      400       {object}  validation.ErrorResponse  "Invalid group ID"
+// @Failure      401       {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403       {object}  validation.ErrorResponse  "Not allowed to remove this member"
+// @Failure      404       {object}  validation.ErrorResponse  "Group or member not found"
+// @Failure      500       {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /groups/{group_id}/members/{user_id} [delete]
+func (h *GroupHandler) RemoveGroupMember(c *gin.Context) {
+	caller, ok := h.requireRole(c, model.GroupRoleMember)
+	if !ok {
+		return
+	}
+
+	target, ok := h.findMember(c, caller.GroupID, c.Param("user_id"))
+	if !ok {
+		return
+	}
+
+	leaving := target.UserID == caller.UserID
+	if target.Role == model.GroupRoleOwner || (!leaving && !canManage(caller, target)) {
+		writeError(c, http.StatusForbidden,
+			"FORBIDDEN",
+			"you can't remove this member",
+		)
+		return
+	}
+
+	if err := h.repo.RemoveMember(c.Request.Context(), caller.GroupID, target.UserID); err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_MEMBER_DELETE_FAILED",
+			"failed to remove member",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListGroupInvites godoc
+// @Summary      List group invites
+// @Description  Get a group's unexpired invites. Admins and the owner only.
+// @Tags         groups
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id  path      string                    true  "Group ID (UUID)"
+// @Success      200       {object}  ListGroupInvitesResponse
+// @Failure      400       {object}  validation.ErrorResponse  "Invalid group ID"
+// @Failure      401       {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403       {object}  validation.ErrorResponse  "Not an admin of the group"
+// @Failure      404       {object}  validation.ErrorResponse  "Group not found"
+// @Failure      500       {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /groups/{group_id}/invites [get]
+func (h *GroupHandler) ListGroupInvites(c *gin.Context) {
+	member, ok := h.requireRole(c, model.GroupRoleAdmin)
+	if !ok {
+		return
+	}
+
+	invites, err := h.repo.ListInvites(c.Request.Context(), member.GroupID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_INVITE_LIST_FAILED",
+			"failed to fetch invites",
+		)
+		return
+	}
+
+	data := make([]GroupInvite, 0, len(invites))
+	for _, i := range invites {
+		data = append(data, toGroupInvite(i))
+	}
+
+	c.JSON(http.StatusOK, ListGroupInvitesResponse{Data: data})
+}
+
+// CreateGroupInvite godoc
+// @Summary      Create a group invite
+// @Description  Create an invite link token anyone can use to join until it expires. Admins and the owner only.
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id  path      string                    true  "Group ID (UUID)"
+// @Param        payload   body      CreateGroupInviteRequest  false "Invite options"
+// @Success      201       {object}  GroupInviteResponse
+// @Failure      400       {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401       {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403       {object}  validation.ErrorResponse  "Not an admin of the group"
+// @Failure      404       {object}  validation.ErrorResponse  "Group not found"
+// @Failure      500       {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /groups/{group_id}/invites [post]
+func (h *GroupHandler) CreateGroupInvite(c *gin.Context) {
+	member, ok := h.requireRole(c, model.GroupRoleAdmin)
+	if !ok {
+		return
+	}
+
+	var req CreateGroupInviteRequest
+	if c.Request.ContentLength > 0 && !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	ttl := defaultGroupInviteTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	invite := model.GroupInvite{
+		GroupID:   member.GroupID,
+		CreatedBy: member.UserID,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := h.repo.CreateInvite(c.Request.Context(), &invite); err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_INVITE_CREATE_FAILED",
+			"failed to create invite",
+		)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GroupInviteResponse{Data: toGroupInvite(invite)})
+}
+
+// RevokeGroupInvite godoc
+// @Summary      Revoke a group invite
+// @Description  Stop an invite from being used. Admins and the owner only.
+// @Tags         groups
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id   path  string  true  "Group ID (UUID)"
+// @Param        invite_id  path  string  true  "Invite ID (UUID)"
+// @Success      204        "No Content"
+// @Failure      400        {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      401        {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403        {object}  validation.ErrorResponse  "Not an admin of the group"
+// @Failure      404        {object}  validation.ErrorResponse  "Group or invite not found"
+// @Failure      500        {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /groups/{group_id}/invites/{invite_id} [delete]
+func (h *GroupHandler) RevokeGroupInvite(c *gin.Context) {
+	member, ok := h.requireRole(c, model.GroupRoleAdmin)
+	if !ok {
+		return
+	}
+
+	inviteID, err := uuid.Parse(c.Param("invite_id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_INVITE_ID",
+			"invite_id must be a valid UUID",
+		)
+		return
+	}
+
+	if err := h.repo.RevokeInvite(c.Request.Context(), member.GroupID, inviteID); err != nil {
+		if errors.Is(err, repository.ErrInviteNotFound) {
+			writeError(c, http.StatusNotFound,
+				"INVITE_NOT_FOUND",
+				"invite not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_INVITE_DELETE_FAILED",
+			"failed to revoke invite",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *GroupHandler) writeGroup(c *gin.Context, member *model.GroupMember) {
+	group, err := h.repo.FindByID(c.Request.Context(), member.GroupID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeGroupNotFound(c)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_FETCH_FAILED",
+			"failed to fetch group",
+		)
+		return
+	}
+
+	res := toGroup(*group)
+	res.Role = member.Role
+	c.JSON(http.StatusOK, GroupResponse{Data: res})
+}
+
+// requireRole loads the caller's membership of the group in the path and
+// checks it carries at least minRole. Groups the caller isn't in are
+// reported as not found so their existence doesn't leak.
+func (h *GroupHandler) requireRole(c *gin.Context, minRole string) (*model.GroupMember, bool) {
+	groupID, err := uuid.Parse(c.Param("group_id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_GROUP_ID",
+			"group_id must be a valid UUID",
+		)
+		return nil, false
+	}
+
+	user, _ := auth.UserFrom(c)
+	member, err := h.repo.Membership(c.Request.Context(), groupID, user.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotGroupMember) {
+			writeGroupNotFound(c)
+			return nil, false
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_FETCH_FAILED",
+			"failed to fetch group",
+		)
+		return nil, false
+	}
+
+	if model.GroupRoleRank(member.Role) < model.GroupRoleRank(minRole) {
+		writeError(c, http.StatusForbidden,
+			"FORBIDDEN",
+			"you need to be a group "+minRole+" to do this",
+		)
+		return nil, false
+	}
+
+	return member, true
+}
+
+func (h *GroupHandler) findMember(c *gin.Context, groupID uuid.UUID, userID string) (*model.GroupMember, bool) {
+	member, err := h.repo.Membership(c.Request.Context(), groupID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotGroupMember) {
+			writeError(c, http.StatusNotFound,
+				"GROUP_MEMBER_NOT_FOUND",
+				"member not found",
+			)
+			return nil, false
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"GROUP_MEMBER_FETCH_FAILED",
+			"failed to fetch member",
+		)
+		return nil, false
+	}
+
+	return member, true
+}
+
+// canManage reports whether caller may change target's role or remove
+// them: only members ranked strictly below the caller.
+func canManage(caller, target *model.GroupMember) bool {
+	return model.GroupRoleRank(caller.Role) > model.GroupRoleRank(target.Role)
+}
+
+func writeGroupNotFound(c *gin.Context) {
+	writeError(c, http.StatusNotFound,
+		"GROUP_NOT_FOUND",
+		"group not found",
+	)
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func createGroup(t *testing.T, router *gin.Engine, token, name string) Group {
+	t.Helper()
+
+	w := doAuthJSON(router, token, http.MethodPost, "/groups", map[string]any{"name": name})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp GroupResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return resp.Data
+}
+
+// inviteAndJoin has the admin create an invite and each token join with it.
+func inviteAndJoin(t *testing.T, router *gin.Engine, admin, groupID string, tokens ...string) {
+	t.Helper()
+
+	w := doAuthJSON(router, admin, http.MethodPost, "/groups/"+groupID+"/invites", map[string]any{"expires_in_hours": 24})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var invite GroupInviteResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &invite); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	for _, token := range tokens {
+		if w := doAuthJSON(router, token, http.MethodPost, "/groups/join", map[string]any{"token": invite.Data.Token}); w.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 joining, got %d, body=%s", w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestGroups_InvitesAndRoles(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewCopyHandler(repository.NewCopyRepository(db)), NewGroupHandler(repository.NewGroupRepository(db)))
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+	carol := tokenFor(t, "6563a1f0c2a4b5d6e7f80933")
+
+	group := createGroup(t, router, alice, "Office book club")
+	if group.Role != "owner" || group.MemberCount != 1 {
+		t.Fatalf("expected alice to own the new group, got %+v", group)
+	}
+	groupPath := "/groups/" + group.ID.String()
+
+	if w := doAuthJSON(router, carol, http.MethodGet, groupPath, nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for a non-member, got %d", w.Code)
+	}
+
+	inviteAndJoin(t, router, alice, group.ID.String(), bob)
+
+	w := doAuthJSON(router, alice, http.MethodGet, groupPath+"/invites", nil)
+	var invites ListGroupInvitesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &invites); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(invites.Data) != 1 || invites.Data[0].Uses != 1 {
+		t.Fatalf("expected one invite used once, got %+v", invites.Data)
+	}
+	token := invites.Data[0].Token
+
+	if w := doAuthJSON(router, bob, http.MethodPost, "/groups/join", map[string]any{"token": token}); w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 joining twice, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, carol, http.MethodPost, "/groups/join", map[string]any{"token": "nope"}); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for an unknown token, got %d", w.Code)
+	}
+
+	expired := model.GroupInvite{GroupID: group.ID, CreatedBy: "6563a1f0c2a4b5d6e7f80911", ExpiresAt: time.Now().Add(-time.Hour)}
+	if err := repository.NewGroupRepository(db).CreateInvite(context.Background(), &expired); err != nil {
+		t.Fatalf("CreateInvite failed: %v", err)
+	}
+	if w := doAuthJSON(router, carol, http.MethodPost, "/groups/join", map[string]any{"token": expired.Token}); w.Code != http.StatusGone {
+		t.Fatalf("expected status 410 for an expired invite, got %d", w.Code)
+	}
+
+	// Plain members can't manage the group.
+	if w := doAuthJSON(router, bob, http.MethodPost, groupPath+"/invites", nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for a member creating an invite, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, bob, http.MethodPatch, groupPath, map[string]any{"name": "Mine now"}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for a member renaming, got %d", w.Code)
+	}
+
+	w = doAuthJSON(router, alice, http.MethodPatch, groupPath+"/members/6563a1f0c2a4b5d6e7f80922", map[string]any{"role": "admin"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	if w := doAuthJSON(router, bob, http.MethodPatch, groupPath, map[string]any{"name": "Renamed club"}); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for an admin renaming, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, bob, http.MethodPatch, groupPath+"/members/6563a1f0c2a4b5d6e7f80911", map[string]any{"role": "member"}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 demoting the owner, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, bob, http.MethodDelete, groupPath, nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for an admin deleting the group, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, alice, http.MethodDelete, groupPath+"/members/6563a1f0c2a4b5d6e7f80911", nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for the owner leaving, got %d", w.Code)
+	}
+
+	// Anyone can leave.
+	if w := doAuthJSON(router, bob, http.MethodDelete, groupPath+"/members/6563a1f0c2a4b5d6e7f80922", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204 leaving, got %d", w.Code)
+	}
+
+	w = doAuthJSON(router, alice, http.MethodGet, groupPath+"/members", nil)
+	var members ListGroupMembersResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &members); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(members.Data) != 1 || members.Data[0].Role != "owner" {
+		t.Fatalf("expected only the owner left, got %+v", members.Data)
+	}
+}
+
+func TestGroups_SharedLibraryAndCopyVisibility(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewCopyHandler(repository.NewCopyRepository(db)), NewGroupHandler(repository.NewGroupRepository(db)))
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+	carol := tokenFor(t, "6563a1f0c2a4b5d6e7f80933")
+
+	author := testutil.SeedAuthor(t, db, "Becky Chambers")
+	shared := testutil.SeedBook(t, db, author, "A Psalm for the Wild-Built", "", nil)
+	outside := testutil.SeedBook(t, db, author, "Record of a Spaceborn Few", "", nil)
+	public := testutil.SeedBook(t, db, author, "The Long Way to a Small, Angry Planet", "", nil)
+
+	group := createGroup(t, router, alice, "Friends")
+	other := createGroup(t, router, carol, "Neighbours")
+	inviteAndJoin(t, router, alice, group.ID.String(), bob)
+
+	bobsCopy := createCopy(t, router, bob, shared.ID.String(), map[string]any{})
+	createCopy(t, router, carol, outside.ID.String(), map[string]any{})
+	createCopy(t, router, alice, public.ID.String(), map[string]any{})
+
+	groupsPath := "/books/" + shared.ID.String() + "/copies/" + bobsCopy.ID.String() + "/groups"
+	if w := doAuthJSON(router, bob, http.MethodPut, groupsPath, map[string]any{"group_ids": []string{other.ID.String()}}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 sharing with a group bob isn't in, got %d", w.Code)
+	}
+	w := doAuthJSON(router, bob, http.MethodPut, groupsPath, map[string]any{"group_ids": []string{group.ID.String()}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var restricted CopyResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &restricted); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(restricted.Data.GroupIDs) != 1 || restricted.Data.GroupIDs[0] != group.ID {
+		t.Fatalf("expected the copy restricted to the group, got %+v", restricted.Data)
+	}
+
+	copiesPath := "/books/" + shared.ID.String() + "/copies"
+	for token, want := range map[string]int{alice: 1, bob: 1, carol: 0, "": 0} {
+		w := doAuthJSON(router, token, http.MethodGet, copiesPath, nil)
+		var resp ListCopiesResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if len(resp.Data) != want {
+			t.Fatalf("expected %d visible copies, got %d", want, len(resp.Data))
+		}
+	}
+	if w := doAuthJSON(router, carol, http.MethodGet, copiesPath+"/"+bobsCopy.ID.String(), nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for a copy carol can't see, got %d", w.Code)
+	}
+
+	w = doAuthJSON(router, alice, http.MethodGet, "/books?group_id="+group.ID.String()+"&sort=title_asc", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var library ListBooksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &library); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(library.Data) != 2 || library.Data[0].ID != shared.ID || library.Data[1].ID != public.ID {
+		t.Fatalf("expected the group library to hold bob's and alice's books, got %+v", library.Data)
+	}
+
+	if w := doAuthJSON(router, carol, http.MethodGet, "/books?group_id="+group.ID.String(), nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for a non-member, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, "", http.MethodGet, "/books?group_id="+group.ID.String(), nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401 without a token, got %d", w.Code)
+	}
+
+	// Leaving the group takes bob's copies out of its library.
+	doAuthJSON(router, bob, http.MethodDelete, "/groups/"+group.ID.String()+"/members/6563a1f0c2a4b5d6e7f80922", nil)
+	w = doAuthJSON(router, alice, http.MethodGet, "/books?group_id="+group.ID.String(), nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &library); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(library.Data) != 1 || library.Data[0].ID != public.ID {
+		t.Fatalf("expected only alice's book after bob left, got %+v", library.Data)
+	}
+}
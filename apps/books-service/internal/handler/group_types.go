@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+)
+
+type CreateGroupRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=100" example:"Office book club"`
+	Description string `json:"description" binding:"omitempty,max=2000"`
+}
+
+type UpdateGroupRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=2000"`
+}
+
+type UpdateGroupMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin member" example:"admin"`
+}
+
+type CreateGroupInviteRequest struct {
+	// ExpiresInHours defaults to a week.
+	ExpiresInHours int `json:"expires_in_hours" binding:"omitempty,min=1,max=720" example:"168"`
+}
+
+type JoinGroupRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type Group struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name" example:"Office book club"`
+	Description string     `json:"description"`
+	Role        string     `json:"role,omitempty" example:"owner"`
+	MemberCount int64      `json:"member_count,omitempty" example:"12"`
+	CreatedAt   model.Date `json:"created_at" swaggertype:"string" example:"2025-11-24"`
+}
+
+type GroupResponse struct {
+	Data Group `json:"data"`
+}
+
+type ListGroupsResponse struct {
+	Data []Group `json:"data"`
+}
+
+type GroupMember struct {
+	UserID   string     `json:"user_id"`
+	Role     string     `json:"role" example:"member"`
+	JoinedAt model.Date `json:"joined_at" swaggertype:"string" example:"2025-11-24"`
+}
+
+type GroupMemberResponse struct {
+	Data GroupMember `json:"data"`
+}
+
+type ListGroupMembersResponse struct {
+	Data []GroupMember `json:"data"`
+}
+
+type GroupInvite struct {
+	ID        uuid.UUID `json:"id"`
+	GroupID   uuid.UUID `json:"group_id"`
+	Token     string    `json:"token"`
+	CreatedBy string    `json:"created_by"`
+	ExpiresAt time.Time `json:"expires_at" example:"2025-12-01T09:00:00Z"`
+	Uses      int       `json:"uses" example:"2"`
+}
+
+type GroupInviteResponse struct {
+	Data GroupInvite `json:"data"`
+}
+
+type ListGroupInvitesResponse struct {
+	Data []GroupInvite `json:"data"`
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+)
+
+type registrar interface {
+	RegisterRoutes(r *gin.RouterGroup)
+}
+
+// newTestRouter serves just the given handlers behind the auth verifier,
+// as the API does.
+func newTestRouter(handlers ...registrar) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	api := r.Group("", auth.NewVerifier(testJWTSecret).Middleware())
+
+	for _, h := range handlers {
+		h.RegisterRoutes(api)
+	}
+	return r
+}
+
+// getJSON fetches path as the token's user, failing the test unless it
+// returns 200, and decodes the response body.
+func getJSON[T any](t *testing.T, router *gin.Engine, token, path string) T {
+	t.Helper()
+
+	w := doAuthJSON(router, token, http.MethodGet, path, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp T
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return resp
+}
+
+func bookTitles(books []Book) []string {
+	titles := make([]string, 0, len(books))
+	for _, b := range books {
+		titles = append(titles, b.Title)
+	}
+	return titles
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+)
+
+type HoldHandler struct {
+	repo repository.HoldRepository
+}
+
+func NewHoldHandler(repo repository.HoldRepository) *HoldHandler {
+	return &HoldHandler{repo: repo}
+}
+
+func (h *HoldHandler) RegisterRoutes(r *gin.RouterGroup) {
+	holds := r.Group("/books/:id/holds")
+	{
+		holds.GET("", h.GetHoldQueue)
+		holds.POST("", auth.Required(), h.JoinHoldQueue)
+		holds.GET("/me", auth.Required(), h.GetMyHold)
+		holds.DELETE("/me", auth.Required(), h.LeaveHoldQueue)
+		holds.POST("/me/accept", auth.Required(), h.AcceptHoldOffer)
+	}
+}
+
+func toHold(hold model.Hold, position int) Hold {
+	return Hold{
+		ID:             hold.ID,
+		BookID:         hold.BookID,
+		UserID:         hold.UserID,
+		Status:         hold.Status,
+		Position:       position,
+		CopyID:         hold.CopyID,
+		OfferedAt:      hold.OfferedAt,
+		OfferExpiresAt: hold.OfferExpiresAt,
+		CreatedAt:      hold.CreatedAt,
+	}
+}
+
+// GetHoldQueue godoc
+// @Summary      Get a book's hold queue
+// @Description  Get how many people are waiting for a copy of a book
+// @Tags         holds
+// @Produce      json
+// @Param        id   path      string                    true  "Book ID (UUID)"
+// @Success      200  {object}  HoldQueueResponse
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid book ID"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/holds [get]
+func (h *HoldHandler) GetHoldQueue(c *gin.Context) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	waiting, err := h.repo.QueueLength(c.Request.Context(), bookID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"HOLD_QUEUE_FETCH_FAILED",
+			"failed to fetch hold queue",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, HoldQueueResponse{Data: HoldQueue{BookID: bookID, Waiting: waiting}})
+}
+
+// JoinHoldQueue godoc
+// @Summary      Place a hold on a book
+// @Description  Join the back of a book's hold queue. When a copy is available and nobody is waiting ahead, it is offered to the caller straight away.
+// @Tags         holds
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string                    true  "Book ID (UUID)"
+// @Success      201  {object}  HoldResponse
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid book ID"
+// @Failure      401  {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404  {object}  validation.ErrorResponse  "Book not found"
+// @Failure      409  {object}  validation.ErrorResponse  "Already in the queue"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/holds [post]
+func (h *HoldHandler) JoinHoldQueue(c *gin.Context) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, _ := auth.UserFrom(c)
+	hold := model.Hold{BookID: bookID, UserID: user.ID}
+
+	if err := h.repo.Join(ctx, &hold); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrBookNotFound):
+			writeError(c, http.StatusNotFound,
+				"BOOK_NOT_FOUND",
+				"book not found",
+			)
+		case errors.Is(err, repository.ErrHoldAlreadyExists):
+			writeError(c, http.StatusConflict,
+				"HOLD_ALREADY_EXISTS",
+				"you are already in the hold queue for this book",
+			)
+		default:
+			writeError(c, http.StatusInternalServerError,
+				"HOLD_CREATE_FAILED",
+				"failed to place hold",
+			)
+		}
+		return
+	}
+
+	h.writeMyHold(c, http.StatusCreated)
+}
+
+// GetMyHold godoc
+// @Summary      Get my hold on a book
+// @Description  Get the caller's place in a book's hold queue, or the copy on offer to them with the time it must be collected by
+// @Tags         holds
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string                    true  "Book ID (UUID)"
+// @Success      200  {object}  HoldResponse
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid book ID"
+// @Failure      401  {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404  {object}  validation.ErrorResponse  "Not in the queue"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/holds/me [get]
+func (h *HoldHandler) GetMyHold(c *gin.Context) {
+	h.writeMyHold(c, http.StatusOK)
+}
+
+// LeaveHoldQueue godoc
+// @Summary      Leave a book's hold queue
+// @Description  Give up the caller's place in the queue. Declining a copy on offer passes it to the next person.
+// @Tags         holds
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Book ID (UUID)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid book ID"
+// @Failure      401  {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404  {object}  validation.ErrorResponse  "Not in the queue"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/holds/me [delete]
+func (h *HoldHandler) LeaveHoldQueue(c *gin.Context) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	if err := h.repo.Leave(c.Request.Context(), bookID, user.ID); err != nil {
+		if writeHoldLookupError(c, err) {
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"HOLD_DELETE_FAILED",
+			"failed to leave hold queue",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AcceptHoldOffer godoc
+// @Summary      Take up a hold offer
+// @Description  Borrow the copy on offer to the caller, which marks it on loan and fulfils the hold
+// @Tags         holds
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string                    true  "Book ID (UUID)"
+// @Success      200  {object}  HoldResponse
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid book ID"
+// @Failure      401  {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404  {object}  validation.ErrorResponse  "Not in the queue"
+// @Failure      409  {object}  validation.ErrorResponse  "No copy on offer, or the offer expired"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/holds/me/accept [post]
+func (h *HoldHandler) AcceptHoldOffer(c *gin.Context) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	hold, err := h.repo.Accept(c.Request.Context(), bookID, user.ID)
+	if err != nil {
+		switch {
+		case writeHoldLookupError(c, err):
+		case errors.Is(err, repository.ErrNoHoldOffer):
+			writeError(c, http.StatusConflict,
+				"NO_HOLD_OFFER",
+				"no copy is on offer to you yet",
+			)
+		case errors.Is(err, repository.ErrHoldOfferExpired):
+			writeError(c, http.StatusConflict,
+				"HOLD_OFFER_EXPIRED",
+				"the offer expired and has passed to the next person",
+			)
+		default:
+			writeError(c, http.StatusInternalServerError,
+				"HOLD_ACCEPT_FAILED",
+				"failed to accept hold offer",
+			)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, HoldResponse{Data: toHold(*hold, 0)})
+}
+
+func (h *HoldHandler) writeMyHold(c *gin.Context, status int) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	hold, position, err := h.repo.Find(c.Request.Context(), bookID, user.ID)
+	if err != nil {
+		if writeHoldLookupError(c, err) {
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"HOLD_FETCH_FAILED",
+			"failed to fetch hold",
+		)
+		return
+	}
+
+	c.JSON(status, HoldResponse{Data: toHold(*hold, position)})
+}
+
+// writeHoldLookupError writes the response for errors meaning the book or
+// the caller's hold doesn't exist, reporting whether it did.
+func writeHoldLookupError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, repository.ErrBookNotFound):
+		writeError(c, http.StatusNotFound,
+			"BOOK_NOT_FOUND",
+			"book not found",
+		)
+	case errors.Is(err, repository.ErrHoldNotFound):
+		writeError(c, http.StatusNotFound,
+			"HOLD_NOT_FOUND",
+			"you are not in the hold queue for this book",
+		)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func TestHolds_QueueOffersCopiesInOrder(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	holds := repository.NewHoldRepository(db, time.Hour)
+	router := newTestRouter(NewCopyHandler(repository.NewCopyRepository(db, repository.WithHoldOffers(time.Hour))), NewHoldHandler(holds))
+	owner := tokenFor(t, "6563a1f0c2a4b5d6e7f80900")
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+	carol := tokenFor(t, "6563a1f0c2a4b5d6e7f80933")
+
+	author := testutil.SeedAuthor(t, db, "Ursula K. Le Guin")
+	book := testutil.SeedBook(t, db, author, "The Dispossessed", "", nil)
+	bookID := book.ID.String()
+
+	first := createCopy(t, router, owner, bookID, map[string]any{"status": "on-loan"})
+
+	for _, token := range []string{alice, bob, carol} {
+		if w := doAuthJSON(router, token, http.MethodPost, "/books/"+bookID+"/holds", nil); w.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+		}
+	}
+	if w := doAuthJSON(router, bob, http.MethodPost, "/books/"+bookID+"/holds", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 for a second hold, got %d", w.Code)
+	}
+
+	if got := getJSON[HoldResponse](t, router, carol, "/books/"+bookID+"/holds/me").Data; got.Position != 3 || got.Status != "waiting" {
+		t.Fatalf("expected carol third in line, got %+v", got)
+	}
+
+	w := doAuthJSON(router, "", http.MethodGet, "/books/"+bookID+"/holds", nil)
+	var queue HoldQueueResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &queue); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if queue.Data.Waiting != 3 {
+		t.Fatalf("expected 3 waiting, got %+v", queue.Data)
+	}
+
+	// The copy coming back goes to the head of the queue and is set aside.
+	firstPath := "/books/" + bookID + "/copies/" + first.ID.String()
+	if w := doAuthJSON(router, owner, http.MethodPatch, firstPath, map[string]any{"status": "available"}); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	offer := getJSON[HoldResponse](t, router, alice, "/books/"+bookID+"/holds/me").Data
+	if offer.Status != "offered" || offer.Position != 0 || offer.CopyID == nil || *offer.CopyID != first.ID || offer.OfferExpiresAt == nil {
+		t.Fatalf("expected the copy to be offered to alice, got %+v", offer)
+	}
+	if got := getJSON[HoldResponse](t, router, bob, "/books/"+bookID+"/holds/me").Data; got.Position != 1 {
+		t.Fatalf("expected bob to move to the head, got %+v", got)
+	}
+	if w := doAuthJSON(router, owner, http.MethodPatch, firstPath, map[string]any{"status": "lost"}); w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 changing a reserved copy, got %d", w.Code)
+	}
+
+	// A second copy goes to the next person, not to alice again.
+	second := createCopy(t, router, owner, bookID, map[string]any{})
+	if second.Status != "reserved" {
+		t.Fatalf("expected the new copy to be reserved, got %+v", second)
+	}
+	if got := getJSON[HoldResponse](t, router, bob, "/books/"+bookID+"/holds/me").Data; got.Status != "offered" || *got.CopyID != second.ID {
+		t.Fatalf("expected the second copy to be offered to bob, got %+v", got)
+	}
+
+	// Declining passes the copy on.
+	if w := doAuthJSON(router, alice, http.MethodDelete, "/books/"+bookID+"/holds/me", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	if got := getJSON[HoldResponse](t, router, carol, "/books/"+bookID+"/holds/me").Data; got.Status != "offered" || *got.CopyID != first.ID {
+		t.Fatalf("expected alice's copy to be offered to carol, got %+v", got)
+	}
+
+	w = doAuthJSON(router, bob, http.MethodPost, "/books/"+bookID+"/holds/me/accept", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var accepted HoldResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &accepted); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if accepted.Data.Status != "fulfilled" {
+		t.Fatalf("expected a fulfilled hold, got %+v", accepted.Data)
+	}
+
+	w = doAuthJSON(router, "", http.MethodGet, "/books/"+bookID+"/copies/"+second.ID.String(), nil)
+	var cp CopyResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &cp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if cp.Data.Status != "on-loan" || cp.Data.BorrowerID == nil || *cp.Data.BorrowerID != "6563a1f0c2a4b5d6e7f80922" {
+		t.Fatalf("expected the accepted copy to be on loan to bob, got %+v", cp.Data)
+	}
+
+	if w := doAuthJSON(router, bob, http.MethodGet, "/books/"+bookID+"/holds/me", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 once the hold is fulfilled, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, alice, http.MethodPost, "/books/"+bookID+"/holds/me/accept", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 after leaving, got %d", w.Code)
+	}
+}
+
+func TestHolds_ExpiredOffersMoveOn(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	holds := repository.NewHoldRepository(db, time.Hour)
+	router := newTestRouter(NewCopyHandler(repository.NewCopyRepository(db, repository.WithHoldOffers(time.Hour))), NewHoldHandler(holds))
+	owner := tokenFor(t, "6563a1f0c2a4b5d6e7f80900")
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+
+	author := testutil.SeedAuthor(t, db, "N. K. Jemisin")
+	book := testutil.SeedBook(t, db, author, "The Fifth Season", "", nil)
+	bookID := book.ID.String()
+
+	cp := createCopy(t, router, owner, bookID, map[string]any{"status": "on-loan"})
+	for _, token := range []string{alice, bob} {
+		if w := doAuthJSON(router, token, http.MethodPost, "/books/"+bookID+"/holds", nil); w.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+		}
+	}
+	doAuthJSON(router, owner, http.MethodPatch, "/books/"+bookID+"/copies/"+cp.ID.String(), map[string]any{"status": "available"})
+
+	if w := doAuthJSON(router, bob, http.MethodPost, "/books/"+bookID+"/holds/me/accept", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 accepting without an offer, got %d", w.Code)
+	}
+
+	n, err := holds.ExpireOffers(context.Background(), time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("ExpireOffers failed: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 offer to lapse, got %d", n)
+	}
+	if w := doAuthJSON(router, alice, http.MethodGet, "/books/"+bookID+"/holds/me", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected alice's hold to be gone, got %d", w.Code)
+	}
+	if got := getJSON[HoldResponse](t, router, bob, "/books/"+bookID+"/holds/me").Data; got.Status != "offered" || *got.CopyID != cp.ID {
+		t.Fatalf("expected the copy to pass to bob, got %+v", got)
+	}
+
+	// Accepting after the window closes lapses the offer instead, and with
+	// nobody left in line the copy is available again.
+	lapsing := repository.NewHoldRepository(db, time.Nanosecond)
+	lapsed := newTestRouter(NewCopyHandler(repository.NewCopyRepository(db, repository.WithHoldOffers(time.Nanosecond))), NewHoldHandler(lapsing))
+	doAuthJSON(lapsed, bob, http.MethodDelete, "/books/"+bookID+"/holds/me", nil)
+	if w := doAuthJSON(lapsed, bob, http.MethodPost, "/books/"+bookID+"/holds", nil); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+	time.Sleep(time.Millisecond)
+
+	w := doAuthJSON(lapsed, bob, http.MethodPost, "/books/"+bookID+"/holds/me/accept", nil)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 for a lapsed offer, got %d, body=%s", w.Code, w.Body.String())
+	}
+	w = doAuthJSON(router, "", http.MethodGet, "/books/"+bookID+"/copies/"+cp.ID.String(), nil)
+	var got CopyResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if got.Data.Status != "available" {
+		t.Fatalf("expected the copy to be available again, got %q", got.Data.Status)
+	}
+}
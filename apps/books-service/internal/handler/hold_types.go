@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Hold struct {
+	ID     uuid.UUID `json:"id"`
+	BookID uuid.UUID `json:"book_id"`
+	UserID string    `json:"user_id"`
+	Status string    `json:"status" example:"waiting"`
+	// Position is 1 for the head of the queue and 0 while a copy is on
+	// offer or once the hold has been fulfilled.
+	Position       int        `json:"position" example:"3"`
+	CopyID         *uuid.UUID `json:"copy_id,omitempty"`
+	OfferedAt      *time.Time `json:"offered_at,omitempty" example:"2025-11-24T09:00:00Z"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty" example:"2025-11-26T09:00:00Z"`
+	CreatedAt      time.Time  `json:"created_at" example:"2025-11-20T18:30:00Z"`
+}
+
+type HoldResponse struct {
+	Data Hold `json:"data"`
+}
+
+type HoldQueue struct {
+	BookID  uuid.UUID `json:"book_id"`
+	Waiting int64     `json:"waiting" example:"4"`
+}
+
+type HoldQueueResponse struct {
+	Data HoldQueue `json:"data"`
+}
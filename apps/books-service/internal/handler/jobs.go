@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/scheduler"
+)
+
+type JobStatsSource interface {
+	Stats() []scheduler.JobStats
+}
+
+type JobsHandler struct {
+	jobs JobStatsSource
+}
+
+func NewJobsHandler(jobs JobStatsSource) *JobsHandler {
+	return &JobsHandler{jobs: jobs}
+}
+
+// RegisterRoutes serves the job stats to authenticated callers only; r
+// must run the auth verifier.
+func (h *JobsHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/jobs", auth.Required(), h.Jobs)
+}
+
+type JobStatus struct {
+	Name           string     `json:"name"`
+	Schedule       string     `json:"schedule"`
+	Runs           int64      `json:"runs"`
+	Failures       int64      `json:"failures"`
+	Skipped        int64      `json:"skipped"`
+	LastStartedAt  *time.Time `json:"last_started_at"`
+	LastDurationMS int64      `json:"last_duration_ms"`
+	LastFailed     bool       `json:"last_failed"`
+	NextRunAt      *time.Time `json:"next_run_at"`
+}
+
+// Jobs reports per-job run counts and timings for this replica. Skipped
+// counts scheduled runs that another replica held the lock for. Errors
+// are only logged, as their text can name internal details.
+func (h *JobsHandler) Jobs(c *gin.Context) {
+	stats := h.jobs.Stats()
+
+	jobs := make([]JobStatus, 0, len(stats))
+	for _, st := range stats {
+		jobs = append(jobs, JobStatus{
+			Name:           st.Name,
+			Schedule:       st.Schedule,
+			Runs:           st.Runs,
+			Failures:       st.Failures,
+			Skipped:        st.Skipped,
+			LastStartedAt:  st.LastStartedAt,
+			LastDurationMS: st.LastDuration.Milliseconds(),
+			LastFailed:     st.LastError != "",
+			NextRunAt:      st.NextRunAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"jobs": jobs})
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/scheduler"
+)
+
+type fakeJobStats []scheduler.JobStats
+
+func (f fakeJobStats) Stats() []scheduler.JobStats { return f }
+
+func TestJobs_RequiresAuthAndHidesErrors(t *testing.T) {
+	router := newTestRouter(NewJobsHandler(fakeJobStats{{
+		Name:      "expire-hold-offers",
+		Runs:      3,
+		Failures:  1,
+		LastError: "dial tcp 10.0.0.5:5432: connection refused",
+	}}))
+
+	if w := doAuthJSON(router, "", http.MethodGet, "/jobs", nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401 without a token, got %d", w.Code)
+	}
+
+	w := doAuthJSON(router, tokenFor(t, "6563a1f0c2a4b5d6e7f80911"), http.MethodGet, "/jobs", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `"last_failed":true`) || strings.Contains(body, "connection refused") {
+		t.Fatalf("expected the failure flagged without its error text, got %s", body)
+	}
+}
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"gorm.io/gorm"
+)
+
+type NotificationHandler struct {
+	repo repository.NotificationRepository
+}
+
+func NewNotificationHandler(repo repository.NotificationRepository) *NotificationHandler {
+	return &NotificationHandler{repo: repo}
+}
+
+func (h *NotificationHandler) RegisterRoutes(r *gin.RouterGroup) {
+	notifications := r.Group("/notifications", auth.Required())
+	{
+		notifications.GET("", h.ListNotifications)
+		notifications.POST("/read-all", h.MarkAllNotificationsRead)
+		notifications.POST("/:notification_id/read", h.MarkNotificationRead)
+		notifications.GET("/preferences", h.GetNotificationPreferences)
+		notifications.PUT("/preferences", h.UpdateNotificationPreferences)
+	}
+}
+
+func toNotification(n model.Notification) Notification {
+	return Notification{
+		ID:        n.ID,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		BookID:    n.BookID,
+		Read:      n.ReadAt != nil,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+// ListNotifications godoc
+// @Summary      List my notifications
+// @Description  Get the caller's inbox, newest first. Pass next_cursor from a response as cursor to get the following page.
+// @Tags         notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        unread  query     bool    false  "Only unread notifications"
+// @Param        limit   query     int     false  "Items per page"  default(20) minimum(1) maximum(100)
+// @Param        cursor  query     string  false  "Cursor from the previous page"
+// @Success      200     {object}  ListNotificationsResponse
+// @Failure      400     {object}  validation.ErrorResponse  "Invalid cursor"
+// @Failure      401     {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      500     {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /notifications [get]
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	limit := parseIntQuery(c, "limit", 20)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	params := repository.NotificationListParams{
+		UnreadOnly: c.Query("unread") == "true",
+		// One extra tells whether there is another page.
+		Limit: limit + 1,
+	}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_CURSOR",
+				"cursor is not valid",
+			)
+			return
+		}
+		params.After = after
+	}
+
+	ctx := c.Request.Context()
+	user, _ := auth.UserFrom(c)
+
+	notifications, err := h.repo.List(ctx, user.ID, params)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"NOTIFICATION_LIST_FAILED",
+			"failed to fetch notifications",
+		)
+		return
+	}
+
+	unread, err := h.repo.UnreadCount(ctx, user.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"NOTIFICATION_LIST_FAILED",
+			"failed to fetch notifications",
+		)
+		return
+	}
+
+	var next *string
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		last := notifications[limit-1]
+		cursor := encodeCursor(last.CreatedAt, last.ID)
+		next = &cursor
+	}
+
+	data := make([]Notification, 0, len(notifications))
+	for _, n := range notifications {
+		data = append(data, toNotification(n))
+	}
+
+	c.JSON(http.StatusOK, ListNotificationsResponse{
+		Data:        data,
+		NextCursor:  next,
+		UnreadCount: unread,
+	})
+}
+
+// MarkNotificationRead godoc
+// @Summary      Mark a notification read
+// @Description  Mark one of the caller's notifications read
+// @Tags         notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        notification_id  path      string  true  "Notification ID (UUID)"
+// @Success      200              {object}  NotificationResponse
+// @Failure      400              {object}  validation.ErrorResponse  "Invalid notification ID"
+// @Failure      401              {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404              {object}  validation.ErrorResponse  "Notification not found"
+// @Failure      500              {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /notifications/{notification_id}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("notification_id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_NOTIFICATION_ID",
+			"notification_id must be a valid UUID",
+		)
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	n, err := h.repo.MarkRead(c.Request.Context(), user.ID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"NOTIFICATION_NOT_FOUND",
+				"notification not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"NOTIFICATION_UPDATE_FAILED",
+			"failed to mark notification read",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, NotificationResponse{Data: toNotification(*n)})
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary      Mark all notifications read
+// @Description  Mark every unread notification of the caller read
+// @Tags         notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  MarkAllReadResponse
+// @Failure      401  {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	user, _ := auth.UserFrom(c)
+
+	updated, err := h.repo.MarkAllRead(c.Request.Context(), user.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"NOTIFICATION_UPDATE_FAILED",
+			"failed to mark notifications read",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, MarkAllReadResponse{Updated: updated})
+}
+
+// GetNotificationPreferences godoc
+// @Summary      Get my notification preferences
+// @Description  Get whether the caller receives each type of notification
+// @Tags         notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  NotificationPreferencesResponse
+// @Failure      401  {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /notifications/preferences [get]
+func (h *NotificationHandler) GetNotificationPreferences(c *gin.Context) {
+	h.writePreferences(c)
+}
+
+// UpdateNotificationPreferences godoc
+// @Summary      Update my notification preferences
+// @Description  Turn types of notification on or off. Types left out keep their current setting.
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        payload  body      UpdateNotificationPreferencesRequest  true  "Preferences to change"
+// @Success      200      {object}  NotificationPreferencesResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /notifications/preferences [put]
+func (h *NotificationHandler) UpdateNotificationPreferences(c *gin.Context) {
+	var req UpdateNotificationPreferencesRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	prefs := make([]model.NotificationPreference, 0, len(req.Preferences))
+	for _, p := range req.Preferences {
+		prefs = append(prefs, model.NotificationPreference{Type: p.Type, Enabled: *p.Enabled})
+	}
+
+	user, _ := auth.UserFrom(c)
+	if err := h.repo.SetPreferences(c.Request.Context(), user.ID, prefs); err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"NOTIFICATION_PREFERENCES_UPDATE_FAILED",
+			"failed to update notification preferences",
+		)
+		return
+	}
+
+	h.writePreferences(c)
+}
+
+func (h *NotificationHandler) writePreferences(c *gin.Context) {
+	user, _ := auth.UserFrom(c)
+
+	prefs, err := h.repo.Preferences(c.Request.Context(), user.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"NOTIFICATION_PREFERENCES_FETCH_FAILED",
+			"failed to fetch notification preferences",
+		)
+		return
+	}
+
+	data := make([]NotificationPreference, 0, len(prefs))
+	for _, p := range prefs {
+		enabled := p.Enabled
+		data = append(data, NotificationPreference{Type: p.Type, Enabled: &enabled})
+	}
+
+	c.JSON(http.StatusOK, NotificationPreferencesResponse{Data: data})
+}
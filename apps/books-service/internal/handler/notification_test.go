@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func TestNotifications_InboxPaginationAndRead(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := repository.NewNotificationRepository(db)
+	router := newTestRouter(NewNotificationHandler(repo))
+	const aliceID = "6563a1f0c2a4b5d6e7f80911"
+	alice := tokenFor(t, aliceID)
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+
+	for i := 1; i <= 5; i++ {
+		if err := repo.Enqueue(context.Background(), &model.Notification{
+			UserID: aliceID,
+			Type:   model.NotificationHoldAvailable,
+			Title:  fmt.Sprintf("Notification %d", i),
+			Body:   "body",
+		}); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+
+	if w := doAuthJSON(router, "", http.MethodGet, "/notifications", nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", w.Code)
+	}
+
+	first := getJSON[ListNotificationsResponse](t, router, alice, "/notifications?limit=3")
+	if len(first.Data) != 3 || first.NextCursor == nil || first.UnreadCount != 5 {
+		t.Fatalf("expected a first page of 3 with a cursor, got %+v", first)
+	}
+	if first.Data[0].Title != "Notification 5" {
+		t.Fatalf("expected newest first, got %q", first.Data[0].Title)
+	}
+
+	second := getJSON[ListNotificationsResponse](t, router, alice, "/notifications?limit=3&cursor="+url.QueryEscape(*first.NextCursor))
+	if len(second.Data) != 2 || second.NextCursor != nil {
+		t.Fatalf("expected a last page of 2, got %+v", second)
+	}
+	if second.Data[0].Title != "Notification 2" {
+		t.Fatalf("expected the second page to continue after the first, got %q", second.Data[0].Title)
+	}
+
+	if w := doAuthJSON(router, alice, http.MethodGet, "/notifications?cursor=bogus", nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a bad cursor, got %d", w.Code)
+	}
+
+	readPath := "/notifications/" + first.Data[0].ID.String() + "/read"
+	if w := doAuthJSON(router, bob, http.MethodPost, readPath, nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for another user's notification, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, alice, http.MethodPost, readPath, nil); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	unread := getJSON[ListNotificationsResponse](t, router, alice, "/notifications?unread=true")
+	if len(unread.Data) != 4 || unread.UnreadCount != 4 {
+		t.Fatalf("expected 4 unread, got %+v", unread)
+	}
+
+	w := doAuthJSON(router, alice, http.MethodPost, "/notifications/read-all", nil)
+	var marked MarkAllReadResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &marked); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if marked.Updated != 4 {
+		t.Fatalf("expected 4 marked read, got %d", marked.Updated)
+	}
+	if got := getJSON[ListNotificationsResponse](t, router, alice, "/notifications?unread=true"); len(got.Data) != 0 || got.UnreadCount != 0 {
+		t.Fatalf("expected an empty unread inbox, got %+v", got)
+	}
+}
+
+func TestNotifications_PreferencesAndHoldOffers(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	holds := repository.NewHoldRepository(db, time.Hour)
+	router := newTestRouter(
+		NewCopyHandler(repository.NewCopyRepository(db, repository.WithHoldOffers(time.Hour))),
+		NewHoldHandler(holds),
+		NewNotificationHandler(repository.NewNotificationRepository(db)),
+	)
+	owner := tokenFor(t, "6563a1f0c2a4b5d6e7f80900")
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+
+	author := testutil.SeedAuthor(t, db, "Iain M. Banks")
+	book := testutil.SeedBook(t, db, author, "Excession", "", nil)
+	bookID := book.ID.String()
+
+	w := doAuthJSON(router, bob, http.MethodPut, "/notifications/preferences", map[string]any{
+		"preferences": []map[string]any{{"type": "hold-available", "enabled": false}},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var prefs NotificationPreferencesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &prefs); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(prefs.Data) != len(model.NotificationTypes) || prefs.Data[0].Type != "hold-available" || *prefs.Data[0].Enabled || !*prefs.Data[1].Enabled {
+		t.Fatalf("expected hold-available off and the rest on, got %+v", prefs.Data)
+	}
+
+	if w := doAuthJSON(router, bob, http.MethodPut, "/notifications/preferences", map[string]any{
+		"preferences": []map[string]any{{"type": "carrier-pigeon", "enabled": true}},
+	}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for an unknown type, got %d", w.Code)
+	}
+
+	// Both copies go to the queue; only alice wants to hear about it.
+	createCopy(t, router, owner, bookID, map[string]any{"status": "on-loan"})
+	createCopy(t, router, owner, bookID, map[string]any{"status": "on-loan"})
+	for _, token := range []string{alice, bob} {
+		doAuthJSON(router, token, http.MethodPost, "/books/"+bookID+"/holds", nil)
+	}
+	w = doAuthJSON(router, "", http.MethodGet, "/books/"+bookID+"/copies", nil)
+	var copies ListCopiesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &copies); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	for _, cp := range copies.Data {
+		doAuthJSON(router, owner, http.MethodPatch, "/books/"+bookID+"/copies/"+cp.ID.String(), map[string]any{"status": "available"})
+	}
+
+	inbox := getJSON[ListNotificationsResponse](t, router, alice, "/notifications")
+	if len(inbox.Data) != 1 || inbox.Data[0].Type != "hold-available" || inbox.Data[0].BookID == nil || *inbox.Data[0].BookID != book.ID {
+		t.Fatalf("expected a hold-available notification for alice, got %+v", inbox.Data)
+	}
+	if got := getJSON[ListNotificationsResponse](t, router, bob, "/notifications"); len(got.Data) != 0 {
+		t.Fatalf("expected bob's muted notification to be dropped, got %+v", got.Data)
+	}
+}
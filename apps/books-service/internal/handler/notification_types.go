@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Notification struct {
+	ID        uuid.UUID  `json:"id"`
+	Type      string     `json:"type" example:"hold-available"`
+	Title     string     `json:"title" example:"Your hold is ready"`
+	Body      string     `json:"body"`
+	BookID    *uuid.UUID `json:"book_id,omitempty"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at,omitempty" example:"2025-11-24T09:00:00Z"`
+	CreatedAt time.Time  `json:"created_at" example:"2025-11-24T08:00:00Z"`
+}
+
+type NotificationResponse struct {
+	Data Notification `json:"data"`
+}
+
+type ListNotificationsResponse struct {
+	Data []Notification `json:"data"`
+	// NextCursor is passed as cursor to fetch the next page; it is null on
+	// the last page.
+	NextCursor  *string `json:"next_cursor"`
+	UnreadCount int64   `json:"unread_count" example:"3"`
+}
+
+type MarkAllReadResponse struct {
+	Updated int64 `json:"updated" example:"3"`
+}
+
+type NotificationPreference struct {
+	Type    string `json:"type" binding:"required,oneof=hold-available hold-expired new-follower follow-request loan-due-soon loan-overdue" example:"hold-available"`
+	Enabled *bool  `json:"enabled" binding:"required" example:"true"`
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreference `json:"preferences" binding:"required,min=1,dive"`
+}
+
+type NotificationPreferencesResponse struct {
+	Data []NotificationPreference `json:"data"`
+}
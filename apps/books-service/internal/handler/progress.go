@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"gorm.io/gorm"
+)
+
+type ProgressHandler struct {
+	repo  repository.ProgressRepository
+	books repository.BookRepository
+}
+
+func NewProgressHandler(repo repository.ProgressRepository, books repository.BookRepository) *ProgressHandler {
+	return &ProgressHandler{repo: repo, books: books}
+}
+
+func (h *ProgressHandler) RegisterRoutes(r *gin.RouterGroup) {
+	progress := r.Group("/books/:id/progress", auth.Required())
+	{
+		progress.POST("", h.LogProgress)
+		progress.GET("", h.GetProgress)
+	}
+}
+
+// LogProgress godoc
+// @Summary      Log reading progress
+// @Description  Record the caller's current page or percent for a book on their currently-reading shelf, optionally with the reading session's start and end and a note. Responds with the updated progress summary.
+// @Tags         progress
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                    true  "Book ID (UUID)"
+// @Param        payload  body      LogProgressRequest        true  "Progress update"
+// @Success      201      {object}  ReadingProgressResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404      {object}  validation.ErrorResponse  "Book not found"
+// @Failure      409      {object}  validation.ErrorResponse  "Book is not currently being read"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/progress [post]
+func (h *ProgressHandler) LogProgress(c *gin.Context) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req LogProgressRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	if req.Page == nil && req.Percent == nil {
+		writeError(c, http.StatusBadRequest,
+			"PROGRESS_POSITION_REQUIRED",
+			"either page or percent is required",
+		)
+		return
+	}
+	if req.SessionStart != nil && req.SessionEnd != nil && req.SessionEnd.Before(*req.SessionStart) {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_SESSION",
+			"session_end must not be before session_start",
+		)
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	entry := model.ProgressEntry{
+		UserID:       user.ID,
+		BookID:       bookID,
+		Page:         req.Page,
+		Percent:      req.Percent,
+		SessionStart: req.SessionStart,
+		SessionEnd:   req.SessionEnd,
+		Note:         req.Note,
+	}
+
+	if err := h.repo.Log(c.Request.Context(), &entry); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrBookNotFound):
+			writeError(c, http.StatusNotFound,
+				"BOOK_NOT_FOUND",
+				"book not found",
+			)
+		case errors.Is(err, repository.ErrNotCurrentlyReading):
+			writeError(c, http.StatusConflict,
+				"BOOK_NOT_CURRENTLY_READING",
+				"put the book on your currently-reading shelf before logging progress",
+			)
+		case errors.Is(err, repository.ErrPageOutOfRange):
+			writeError(c, http.StatusBadRequest,
+				"PAGE_OUT_OF_RANGE",
+				"page exceeds the book's page count",
+			)
+		default:
+			writeError(c, http.StatusInternalServerError,
+				"PROGRESS_LOG_FAILED",
+				"failed to log progress",
+			)
+		}
+		return
+	}
+
+	h.writeProgress(c, http.StatusCreated)
+}
+
+// GetProgress godoc
+// @Summary      Get reading progress
+// @Description  Get the caller's progress history for a book, oldest first, with pages-per-day pace and an estimated finish date when the book's page count is known
+// @Tags         progress
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string                    true  "Book ID (UUID)"
+// @Success      200  {object}  ReadingProgressResponse
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid book ID"
+// @Failure      401  {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404  {object}  validation.ErrorResponse  "Book not found"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/progress [get]
+func (h *ProgressHandler) GetProgress(c *gin.Context) {
+	h.writeProgress(c, http.StatusOK)
+}
+
+func (h *ProgressHandler) writeProgress(c *gin.Context, status int) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, _ := auth.UserFrom(c)
+
+	book, err := h.books.FindByID(ctx, bookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"BOOK_NOT_FOUND",
+				"book not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"BOOK_FETCH_FAILED",
+			"failed to fetch book",
+		)
+		return
+	}
+
+	entries, err := h.repo.History(ctx, user.ID, bookID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"PROGRESS_FETCH_FAILED",
+			"failed to fetch progress",
+		)
+		return
+	}
+
+	startedAt, err := h.repo.StartedAt(ctx, user.ID, bookID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"PROGRESS_FETCH_FAILED",
+			"failed to fetch progress",
+		)
+		return
+	}
+	if startedAt == nil && len(entries) > 0 {
+		first := entries[0]
+		startedAt = &first.RecordedAt
+		if first.SessionStart != nil {
+			startedAt = first.SessionStart
+		}
+	}
+
+	data := ReadingProgress{
+		BookID:    book.ID,
+		PageCount: book.PageCount,
+		StartedAt: toOptionalDate(startedAt),
+		Entries:   make([]ProgressEntry, 0, len(entries)),
+	}
+
+	if startedAt != nil {
+		pace := model.CalculatePace(entries, *startedAt, book.PageCount)
+		data.CurrentPage = pace.CurrentPage
+		data.Percent = pace.Percent
+		data.PagesPerDay = pace.PagesPerDay
+		data.EstimatedFinish = toOptionalDate(pace.EstimatedFinish)
+	}
+
+	for _, e := range entries {
+		data.Entries = append(data.Entries, ProgressEntry{
+			ID:           e.ID,
+			Page:         e.Page,
+			Percent:      e.Percent,
+			SessionStart: e.SessionStart,
+			SessionEnd:   e.SessionEnd,
+			Note:         e.Note,
+			RecordedAt:   e.RecordedAt,
+		})
+	}
+
+	c.JSON(status, ReadingProgressResponse{Data: data})
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func TestProgress_RequiresCurrentlyReading(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(
+		NewShelfHandler(repository.NewShelfRepository(db)),
+		NewProgressHandler(repository.NewProgressRepository(db), repository.NewGormBookRepository(db)),
+	)
+	token := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+
+	author := testutil.SeedAuthor(t, db, "Iain M. Banks")
+	book := testutil.SeedBook(t, db, author, "Excession", "", nil)
+
+	w := doAuthJSON(router, token, http.MethodPost, "/books/"+book.ID.String()+"/progress", map[string]any{"page": 10})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	w = doAuthJSON(router, token, http.MethodPost, "/books/"+book.ID.String()+"/progress", map[string]any{"note": "no position"})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without page or percent, got %d", w.Code)
+	}
+}
+
+func TestProgress_PaceAndEstimatedFinish(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(
+		NewShelfHandler(repository.NewShelfRepository(db)),
+		NewProgressHandler(repository.NewProgressRepository(db), repository.NewGormBookRepository(db)),
+	)
+	token := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+
+	author := testutil.SeedAuthor(t, db, "Iain M. Banks")
+	book := testutil.SeedBook(t, db, author, "Use of Weapons", "", nil)
+	if err := db.Model(&model.Book{}).Where("id = ?", book.ID).Update("page_count", 300).Error; err != nil {
+		t.Fatalf("failed to set page count: %v", err)
+	}
+
+	started := time.Now().AddDate(0, 0, -10)
+	w := doAuthJSON(router, token, http.MethodPut, "/shelves/currently-reading/books/"+book.ID.String(), map[string]any{
+		"started_at": started.Format("2006-01-02"),
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	path := "/books/" + book.ID.String() + "/progress"
+	if w := doAuthJSON(router, token, http.MethodPost, path, map[string]any{
+		"page":        60,
+		"session_end": time.Now().Add(-2 * time.Hour).Format(time.RFC3339),
+	}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	w = doAuthJSON(router, token, http.MethodPost, path, map[string]any{
+		"percent":       33.4,
+		"session_start": time.Now().Add(-time.Hour).Format(time.RFC3339),
+		"session_end":   time.Now().Format(time.RFC3339),
+		"note":          "Zakalwe!",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp ReadingProgressResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	got := resp.Data
+
+	if len(got.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(got.Entries))
+	}
+	if got.Entries[0].Percent == nil || *got.Entries[0].Percent != 20 {
+		t.Fatalf("expected page 60 to be stored as 20%%, got %+v", got.Entries[0])
+	}
+	if got.CurrentPage == nil || *got.CurrentPage != 100 {
+		t.Fatalf("expected 33.4%% of 300 pages to be page 100, got %v", got.CurrentPage)
+	}
+	if got.PagesPerDay == nil || *got.PagesPerDay < 9 || *got.PagesPerDay > 10 {
+		t.Fatalf("expected about 10 pages per day, got %v", got.PagesPerDay)
+	}
+	if got.EstimatedFinish == nil {
+		t.Fatalf("expected an estimated finish date")
+	}
+	wantFinish := time.Now().AddDate(0, 0, 20)
+	if diff := got.EstimatedFinish.Sub(wantFinish); diff < -72*time.Hour || diff > 72*time.Hour {
+		t.Fatalf("expected finish around %s, got %s", wantFinish.Format("2006-01-02"), got.EstimatedFinish.Format("2006-01-02"))
+	}
+
+	if w := doAuthJSON(router, token, http.MethodPost, path, map[string]any{"page": 301}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 past the last page, got %d", w.Code)
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+)
+
+type LogProgressRequest struct {
+	Page         *int       `json:"page" binding:"omitempty,min=0" example:"120"`
+	Percent      *float64   `json:"percent" binding:"omitempty,min=0,max=100" example:"42.5"`
+	SessionStart *time.Time `json:"session_start" example:"2025-11-24T19:30:00Z"`
+	SessionEnd   *time.Time `json:"session_end" example:"2025-11-24T20:15:00Z"`
+	Note         string     `json:"note" binding:"omitempty,max=2000"`
+}
+
+type ProgressEntry struct {
+	ID           uuid.UUID  `json:"id"`
+	Page         *int       `json:"page,omitempty" example:"120"`
+	Percent      *float64   `json:"percent,omitempty" example:"42.5"`
+	SessionStart *time.Time `json:"session_start,omitempty" example:"2025-11-24T19:30:00Z"`
+	SessionEnd   *time.Time `json:"session_end,omitempty" example:"2025-11-24T20:15:00Z"`
+	Note         string     `json:"note,omitempty"`
+	RecordedAt   time.Time  `json:"recorded_at" example:"2025-11-24T20:15:00Z"`
+}
+
+type ReadingProgress struct {
+	BookID          uuid.UUID       `json:"book_id"`
+	PageCount       *int            `json:"page_count,omitempty" example:"282"`
+	StartedAt       *model.Date     `json:"started_at,omitempty" swaggertype:"string" example:"2025-11-20"`
+	CurrentPage     *int            `json:"current_page,omitempty" example:"120"`
+	Percent         *float64        `json:"percent,omitempty" example:"42.5"`
+	PagesPerDay     *float64        `json:"pages_per_day,omitempty" example:"30"`
+	EstimatedFinish *model.Date     `json:"estimated_finish,omitempty" swaggertype:"string" example:"2025-11-30"`
+	Entries         []ProgressEntry `json:"entries"`
+}
+
+type ReadingProgressResponse struct {
+	Data ReadingProgress `json:"data"`
+}
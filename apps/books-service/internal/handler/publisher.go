@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"gorm.io/gorm"
+)
+
+type PublisherHandler struct {
+	repo repository.PublisherRepository
+}
+
+func NewPublisherHandler(repo repository.PublisherRepository) *PublisherHandler {
+	return &PublisherHandler{repo: repo}
+}
+
+func (h *PublisherHandler) RegisterRoutes(r *gin.RouterGroup) {
+	publishers := r.Group("/publishers")
+	{
+		publishers.POST("", h.CreatePublisher)
+		publishers.GET("", h.ListPublishers)
+		publishers.GET("/:id", h.GetPublisherByID)
+		publishers.PATCH("/:id", h.UpdatePublisher)
+		publishers.DELETE("/:id", h.DeletePublisher)
+	}
+}
+
+func toPublisherResponse(p model.Publisher) PublisherResponse {
+	books := make([]BookSummary, 0, len(p.Books))
+	for _, b := range p.Books {
+		books = append(books, toBookSummaryResponse(b).Data)
+	}
+
+	data := Publisher{
+		ID:          p.ID,
+		Name:        p.Name,
+		Website:     p.Website,
+		Description: p.Description,
+		Books:       books,
+		CreatedAt:   model.Date{Time: p.CreatedAt},
+		UpdatedAt:   model.Date{Time: p.UpdatedAt},
+	}
+
+	return PublisherResponse{Data: data}
+}
+
+// CreatePublisher godoc
+// @Summary      Create a publisher
+// @Description  Create a new publisher with name, optional website and description
+// @Tags         publishers
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      CreatePublisherRequest        true  "Publisher to create"
+// @Success      201      {object}  PublisherResponse
+// @Failure      400      {object}  validation.ErrorResponse   "Validation error"
+// @Failure      409      {object}  validation.ErrorResponse   "Publisher already exists"
+// @Failure      500      {object}  validation.ErrorResponse   "Internal server error"
+// @Router       /publishers [post]
+func (h *PublisherHandler) CreatePublisher(c *gin.Context) {
+	var req CreatePublisherRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	publisher := model.Publisher{
+		Name:        req.Name,
+		Website:     req.Website,
+		Description: req.Description,
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &publisher); err != nil {
+		if errors.Is(err, repository.ErrPublisherAlreadyExists) {
+			writeError(c, http.StatusConflict,
+				"PUBLISHER_ALREADY_EXISTS",
+				"a publisher with this name already exists",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"PUBLISHER_CREATE_FAILED",
+			"failed to create publisher",
+		)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toPublisherResponse(publisher))
+}
+
+// ListPublishers godoc
+// @Summary      List publishers
+// @Description  Get a list of all publishers ordered by name
+// @Tags         publishers
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   PublisherResponse
+// @Failure      500  {object}  validation.ErrorResponse   "Internal server error"
+// @Router       /publishers [get]
+func (h *PublisherHandler) ListPublishers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	publishers, err := h.repo.List(ctx)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"PUBLISHER_LIST_FAILED",
+			"failed to list publishers",
+		)
+		return
+	}
+
+	res := make([]PublisherResponse, 0, len(publishers))
+	for _, a := range publishers {
+		res = append(res, toPublisherResponse(a))
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetPublisherByID godoc
+// @Summary      Get publisher by ID
+// @Description  Get a single publisher by its ID
+// @Tags         publishers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string                    true  "Publisher ID (UUID)"
+// @Success      200  {object}  PublisherResponse
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      404  {object}  validation.ErrorResponse  "Publisher not found"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /publishers/{id} [get]
+func (h *PublisherHandler) GetPublisherByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"PUBLISHER_INVALID_ID",
+			"invalid publisher id",
+		)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	publisher, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"PUBLISHER_NOT_FOUND",
+				"publisher not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"PUBLISHER_FETCH_FAILED",
+			"failed to fetch publisher",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPublisherResponse(*publisher))
+}
+
+// UpdatePublisher godoc
+// @Summary      Update a publisher
+// @Description  Partially update an existing publisher
+// @Tags         publishers
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string               true  "Publisher ID (UUID)"
+// @Param        payload  body      UpdatePublisherRequest  true  "Publisher fields to update"
+// @Success      200      {object}  PublisherResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid ID or validation error"
+// @Failure      404      {object}  validation.ErrorResponse  "Publisher not found"
+// @Failure      409      {object}  validation.ErrorResponse  "Publisher already exists"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /publishers/{id} [patch]
+func (h *PublisherHandler) UpdatePublisher(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"PUBLISHER_INVALID_ID",
+			"invalid publisher id",
+		)
+		return
+	}
+
+	var req UpdatePublisherRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	publisher, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"PUBLISHER_NOT_FOUND",
+				"publisher not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"PUBLISHER_FETCH_FAILED",
+			"failed to fetch publisher",
+		)
+		return
+	}
+
+	if req.Name != nil {
+		publisher.Name = *req.Name
+	}
+	if req.Website != nil {
+		publisher.Website = *req.Website
+	}
+	if req.Description != nil {
+		publisher.Description = *req.Description
+	}
+
+	publisher.Books = nil
+
+	if err := h.repo.Update(ctx, publisher); err != nil {
+		if errors.Is(err, repository.ErrPublisherAlreadyExists) {
+			writeError(c, http.StatusConflict,
+				"PUBLISHER_ALREADY_EXISTS",
+				"a publisher with this name already exists",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"PUBLISHER_UPDATE_FAILED",
+			"failed to update publisher",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPublisherResponse(*publisher))
+}
+
+// DeletePublisher godoc
+// @Summary      Delete a publisher
+// @Description  Delete a publisher by ID. Publishers that still have books cannot be deleted.
+// @Tags         publishers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string                    true  "Publisher ID (UUID)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      404  {object}  validation.ErrorResponse  "Publisher not found"
+// @Failure      409  {object}  validation.ErrorResponse  "Publisher still has books"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /publishers/{id} [delete]
+func (h *PublisherHandler) DeletePublisher(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"PUBLISHER_INVALID_ID",
+			"invalid publisher id",
+		)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrPublisherHasBooks) {
+			writeError(c, http.StatusConflict,
+				"PUBLISHER_HAS_BOOKS",
+				"publisher still has books; reassign or delete them first",
+			)
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"PUBLISHER_NOT_FOUND",
+				"publisher not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"PUBLISHER_DELETE_FAILED",
+			"failed to delete publisher",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func createPublisher(t *testing.T, router *gin.Engine, name string) uuid.UUID {
+	t.Helper()
+
+	w := doJSON(router, http.MethodPost, "/publishers", map[string]any{
+		"name":    name,
+		"website": "https://example.com",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp PublisherResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return resp.Data.ID
+}
+
+func TestPublisher_DuplicateNameConflict(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewPublisherHandler(repository.NewPublisherRepository(db)))
+
+	createPublisher(t, router, "Tor Books")
+
+	w := doJSON(router, http.MethodPost, "/publishers", map[string]any{"name": "tor books"})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d, body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestPublisher_BooksFilterSortAndDeleteGuard(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewPublisherHandler(repository.NewPublisherRepository(db)))
+
+	author := testutil.SeedAuthor(t, db, "Ann Leckie")
+	orbit := createPublisher(t, router, "Orbit")
+	tor := createPublisher(t, router, "Tor")
+
+	for title, publisherID := range map[string]uuid.UUID{
+		"Ancillary Justice": orbit,
+		"Provenance":        orbit,
+		"The Raven Tower":   tor,
+	} {
+		w := doJSON(router, http.MethodPost, "/books", map[string]any{
+			"title":        title,
+			"author_id":    author.ID.String(),
+			"publisher_id": publisherID.String(),
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+		}
+	}
+
+	w := doJSON(router, http.MethodGet, "/books?publisher_id="+orbit.String(), nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var list ListBooksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(list.Data) != 2 {
+		t.Fatalf("expected 2 Orbit books, got %d", len(list.Data))
+	}
+	for _, b := range list.Data {
+		if b.Publisher == nil || b.Publisher.ID != orbit {
+			t.Fatalf("expected publisher Orbit on %q, got %+v", b.Title, b.Publisher)
+		}
+	}
+
+	w = doJSON(router, http.MethodGet, "/books?sort=publisher_desc", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(list.Data) != 3 || list.Data[0].Title != "The Raven Tower" {
+		t.Fatalf("expected Tor book first when sorting by publisher desc, got %+v", list.Data)
+	}
+
+	w = doJSON(router, http.MethodDelete, "/publishers/"+tor.String(), nil)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	w = doJSON(router, http.MethodGet, "/publishers/"+orbit.String(), nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var got PublisherResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(got.Data.Books) != 2 {
+		t.Fatalf("expected 2 books on publisher, got %d", len(got.Data.Books))
+	}
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+)
+
+type CreatePublisherRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=200"`
+	Website     string `json:"website" binding:"omitempty,url,max=500"`
+	Description string `json:"description" binding:"omitempty,max=2000"`
+}
+
+type UpdatePublisherRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=200"`
+	Website     *string `json:"website" binding:"omitempty,max=500"`
+	Description *string `json:"description" binding:"omitempty,max=2000"`
+}
+
+type Publisher struct {
+	ID          uuid.UUID     `json:"id"`
+	Name        string        `json:"name"`
+	Website     string        `json:"website"`
+	Description string        `json:"description"`
+	Books       []BookSummary `json:"books,omitempty"`
+	CreatedAt   model.Date    `json:"created_at" swaggertype:"string" example:"2025-11-24"`
+	UpdatedAt   model.Date    `json:"updated_at" swaggertype:"string" example:"2025-11-24"`
+}
+
+type PublisherSummary struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type PublisherResponse struct {
+	Data Publisher `json:"data"`
+}
@@ -0,0 +1,336 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"gorm.io/gorm"
+)
+
+type ReviewHandler struct {
+	repo  repository.ReviewRepository
+	books repository.BookRepository
+}
+
+func NewReviewHandler(repo repository.ReviewRepository, books repository.BookRepository) *ReviewHandler {
+	return &ReviewHandler{repo: repo, books: books}
+}
+
+func (h *ReviewHandler) RegisterRoutes(r *gin.RouterGroup) {
+	reviews := r.Group("/books/:id/reviews")
+	{
+		reviews.GET("", h.ListReviews)
+		reviews.POST("", auth.Required(), h.CreateReview)
+		reviews.PATCH("/:review_id", auth.Required(), h.UpdateReview)
+		reviews.DELETE("/:review_id", auth.Required(), h.DeleteReview)
+	}
+}
+
+func toReview(r model.Review) Review {
+	return Review{
+		ID:        r.ID,
+		BookID:    r.BookID,
+		UserID:    r.UserID,
+		Rating:    r.Rating,
+		Body:      r.Body,
+		CreatedAt: model.Date{Time: r.CreatedAt},
+		UpdatedAt: model.Date{Time: r.UpdatedAt},
+	}
+}
+
+// ListReviews godoc
+// @Summary      List reviews of a book
+// @Description  Get a paginated list of a book's reviews
+// @Tags         reviews
+// @Produce      json
+// @Param        id         path      string  true   "Book ID (UUID)"
+// @Param        page       query     int     false  "Page number"      default(1) minimum(1)
+// @Param        page_size  query     int     false  "Items per page"   default(20) minimum(1) maximum(100)
+// @Param        sort       query     string  false  "Sort field and direction" Enums(created_at_desc,created_at_asc,rating_desc,rating_asc)
+// @Success      200        {object}  ListReviewsResponse
+// @Failure      400        {object}  validation.ErrorResponse  "Invalid book ID"
+// @Failure      404        {object}  validation.ErrorResponse  "Book not found"
+// @Failure      500        {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/reviews [get]
+func (h *ReviewHandler) ListReviews(c *gin.Context) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if _, err := h.books.FindByID(ctx, bookID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"BOOK_NOT_FOUND",
+				"book not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"BOOK_FETCH_FAILED",
+			"failed to fetch book",
+		)
+		return
+	}
+
+	page := parseIntQuery(c, "page", 1)
+	pageSize := parseIntQuery(c, "page_size", 20)
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	params := repository.ReviewListParams{
+		Page:     page,
+		PageSize: pageSize,
+		Sort:     c.DefaultQuery("sort", "created_at_desc"),
+	}
+
+	result, err := h.repo.List(ctx, bookID, params)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"REVIEW_LIST_FAILED",
+			"failed to fetch reviews",
+		)
+		return
+	}
+
+	reviews := make([]Review, 0, len(result.Reviews))
+	for _, r := range result.Reviews {
+		reviews = append(reviews, toReview(r))
+	}
+
+	totalPages := 0
+	if params.PageSize > 0 {
+		totalPages = int((result.Total + int64(params.PageSize) - 1) / int64(params.PageSize))
+	}
+
+	c.JSON(http.StatusOK, ListReviewsResponse{
+		Data: reviews,
+		Pagination: Pagination{
+			Page:       params.Page,
+			PageSize:   params.PageSize,
+			Total:      result.Total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// CreateReview godoc
+// @Summary      Review a book
+// @Description  Rate a book from 1 to 5 with an optional text. Each user can review a book once; edit the existing review instead.
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                    true  "Book ID (UUID)"
+// @Param        payload  body      CreateReviewRequest       true  "Review to create"
+// @Success      201      {object}  ReviewResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404      {object}  validation.ErrorResponse  "Book not found"
+// @Failure      409      {object}  validation.ErrorResponse  "Already reviewed"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/reviews [post]
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req CreateReviewRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	review := model.Review{
+		BookID: bookID,
+		UserID: user.ID,
+		Rating: req.Rating,
+		Body:   req.Body,
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &review); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrReviewAlreadyExists):
+			writeError(c, http.StatusConflict,
+				"REVIEW_ALREADY_EXISTS",
+				"you have already reviewed this book",
+			)
+		case errors.Is(err, repository.ErrBookNotFound):
+			writeError(c, http.StatusNotFound,
+				"BOOK_NOT_FOUND",
+				"book not found",
+			)
+		default:
+			writeError(c, http.StatusInternalServerError,
+				"REVIEW_CREATE_FAILED",
+				"failed to create review",
+			)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, ReviewResponse{Data: toReview(review)})
+}
+
+// UpdateReview godoc
+// @Summary      Edit a review
+// @Description  Change the rating or text of the caller's own review
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string                    true  "Book ID (UUID)"
+// @Param        review_id  path      string                    true  "Review ID (UUID)"
+// @Param        payload    body      UpdateReviewRequest       true  "Fields to update"
+// @Success      200        {object}  ReviewResponse
+// @Failure      400        {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401        {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403        {object}  validation.ErrorResponse  "Not the author of the review"
+// @Failure      404        {object}  validation.ErrorResponse  "Review not found"
+// @Failure      500        {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/reviews/{review_id} [patch]
+func (h *ReviewHandler) UpdateReview(c *gin.Context) {
+	review, ok := h.findOwnReview(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateReviewRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	if req.Rating == nil && req.Body == nil {
+		writeError(c, http.StatusBadRequest,
+			"NO_FIELDS_TO_UPDATE",
+			"at least one field must be provided",
+		)
+		return
+	}
+
+	if req.Rating != nil {
+		review.Rating = *req.Rating
+	}
+	if req.Body != nil {
+		review.Body = *req.Body
+	}
+	review.UpdatedAt = time.Now()
+
+	if err := h.repo.Update(c.Request.Context(), review); err != nil {
+		// Deleted by another request since it was loaded.
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"REVIEW_NOT_FOUND",
+				"review not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"REVIEW_UPDATE_FAILED",
+			"failed to update review",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, ReviewResponse{Data: toReview(*review)})
+}
+
+// DeleteReview godoc
+// @Summary      Delete a review
+// @Description  Delete the caller's own review
+// @Tags         reviews
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path  string  true  "Book ID (UUID)"
+// @Param        review_id  path  string  true  "Review ID (UUID)"
+// @Success      204        "No Content"
+// @Failure      400        {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      401        {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      403        {object}  validation.ErrorResponse  "Not the author of the review"
+// @Failure      404        {object}  validation.ErrorResponse  "Review not found"
+// @Failure      500        {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /books/{id}/reviews/{review_id} [delete]
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	review, ok := h.findOwnReview(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), review); err != nil {
+		// Deleted by another request since it was loaded.
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"REVIEW_NOT_FOUND",
+				"review not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"REVIEW_DELETE_FAILED",
+			"failed to delete review",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// findOwnReview loads the review named in the path and checks that the
+// caller wrote it, writing the error response itself when not.
+func (h *ReviewHandler) findOwnReview(c *gin.Context) (*model.Review, bool) {
+	bookID, ok := parseBookIDParam(c)
+	if !ok {
+		return nil, false
+	}
+
+	reviewID, err := uuid.Parse(c.Param("review_id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_REVIEW_ID",
+			"review_id must be a valid UUID",
+		)
+		return nil, false
+	}
+
+	review, err := h.repo.FindByID(c.Request.Context(), bookID, reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"REVIEW_NOT_FOUND",
+				"review not found",
+			)
+			return nil, false
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"REVIEW_FETCH_FAILED",
+			"failed to fetch review",
+		)
+		return nil, false
+	}
+
+	user, _ := auth.UserFrom(c)
+	if review.UserID != user.ID {
+		writeError(c, http.StatusForbidden,
+			"FORBIDDEN",
+			"you can only change your own reviews",
+		)
+		return nil, false
+	}
+
+	return review, true
+}
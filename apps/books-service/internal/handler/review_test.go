@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func createReview(t *testing.T, router *gin.Engine, token, bookID string, rating int) Review {
+	t.Helper()
+
+	w := doAuthJSON(router, token, http.MethodPost, "/books/"+bookID+"/reviews", map[string]any{
+		"rating": rating,
+		"body":   "thoughts",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp ReviewResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return resp.Data
+}
+
+func TestReviews_AggregatesFollowCreateUpdateDelete(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	bookRepo := repository.NewGormBookRepository(db)
+	router := newTestRouter(NewBookHandler(bookRepo), NewReviewHandler(repository.NewReviewRepository(db), bookRepo))
+
+	author := testutil.SeedAuthor(t, db, "Becky Chambers")
+	book := testutil.SeedBook(t, db, author, "A Psalm for the Wild-Built", "", nil)
+	bookID := book.ID.String()
+
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+
+	aliceReview := createReview(t, router, alice, bookID, 5)
+	createReview(t, router, bob, bookID, 2)
+
+	if got := getJSON[BookResponse](t, router, "", "/books/"+bookID).Data; got.RatingCount != 2 || got.RatingAverage != 3.5 {
+		t.Fatalf("expected 2 ratings averaging 3.5, got %d / %v", got.RatingCount, got.RatingAverage)
+	}
+
+	w := doAuthJSON(router, alice, http.MethodPost, "/books/"+bookID+"/reviews", map[string]any{"rating": 1})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 for a second review, got %d", w.Code)
+	}
+
+	path := "/books/" + bookID + "/reviews/" + aliceReview.ID.String()
+	if w := doAuthJSON(router, bob, http.MethodPatch, path, map[string]any{"rating": 1}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 editing someone else's review, got %d", w.Code)
+	}
+
+	w = doAuthJSON(router, alice, http.MethodPatch, path, map[string]any{"rating": 4})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if got := getJSON[BookResponse](t, router, "", "/books/"+bookID).Data; got.RatingCount != 2 || got.RatingAverage != 3 {
+		t.Fatalf("expected 2 ratings averaging 3 after edit, got %d / %v", got.RatingCount, got.RatingAverage)
+	}
+
+	if w := doAuthJSON(router, alice, http.MethodDelete, path, nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if got := getJSON[BookResponse](t, router, "", "/books/"+bookID).Data; got.RatingCount != 1 || got.RatingAverage != 2 {
+		t.Fatalf("expected 1 rating averaging 2 after delete, got %d / %v", got.RatingCount, got.RatingAverage)
+	}
+}
+
+func TestReviews_ListSortedAndBookFilters(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	bookRepo := repository.NewGormBookRepository(db)
+	router := newTestRouter(NewBookHandler(bookRepo), NewReviewHandler(repository.NewReviewRepository(db), bookRepo))
+
+	author := testutil.SeedAuthor(t, db, "Ursula K. Le Guin")
+	dispossessed := testutil.SeedBook(t, db, author, "The Dispossessed", "", nil)
+	lathe := testutil.SeedBook(t, db, author, "The Lathe of Heaven", "", nil)
+	testutil.SeedBook(t, db, author, "Unrated", "", nil)
+
+	createReview(t, router, tokenFor(t, "user-a"), dispossessed.ID.String(), 3)
+	createReview(t, router, tokenFor(t, "user-b"), dispossessed.ID.String(), 5)
+	createReview(t, router, tokenFor(t, "user-a"), lathe.ID.String(), 5)
+
+	w := doJSON(router, http.MethodGet, "/books/"+dispossessed.ID.String()+"/reviews?sort=rating_desc&page_size=1", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var reviews ListReviewsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &reviews); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if reviews.Pagination.Total != 2 || len(reviews.Data) != 1 || reviews.Data[0].Rating != 5 {
+		t.Fatalf("expected the 5-star review first of 2, got %+v", reviews)
+	}
+
+	w = doJSON(router, http.MethodGet, "/books?sort=rating_desc&min_rating=3.5", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var books ListBooksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &books); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(books.Data) != 2 || books.Data[0].Title != "The Lathe of Heaven" || books.Data[1].Title != "The Dispossessed" {
+		t.Fatalf("expected rated books by average desc, got %+v", books.Data)
+	}
+
+	if w := doJSON(router, http.MethodGet, "/books?min_rating=6", nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for min_rating out of range, got %d", w.Code)
+	}
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+)
+
+type CreateReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5" example:"4"`
+	Body   string `json:"body" binding:"omitempty,max=10000"`
+}
+
+type UpdateReviewRequest struct {
+	Rating *int    `json:"rating" binding:"omitempty,min=1,max=5" example:"5"`
+	Body   *string `json:"body" binding:"omitempty,max=10000"`
+}
+
+type Review struct {
+	ID        uuid.UUID  `json:"id"`
+	BookID    uuid.UUID  `json:"book_id"`
+	UserID    string     `json:"user_id"`
+	Rating    int        `json:"rating" example:"4"`
+	Body      string     `json:"body"`
+	CreatedAt model.Date `json:"created_at" swaggertype:"string" example:"2025-11-24"`
+	UpdatedAt model.Date `json:"updated_at" swaggertype:"string" example:"2025-11-24"`
+}
+
+type ReviewResponse struct {
+	Data Review `json:"data"`
+}
+
+type ListReviewsResponse struct {
+	Data       []Review   `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/search"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func setupEmbeddedSearchRouter(t *testing.T, db *gorm.DB) (*gin.Engine, repository.SearchIndex) {
+	t.Helper()
+
+	ix, err := search.Open("")
+	if err != nil {
+		t.Fatalf("failed to open search index: %v", err)
+	}
+	index := repository.NewEmbeddedSearchIndex(ix)
+	withSearch := repository.WithSearchIndex(index)
+
+	r := newTestRouter(
+		NewBookHandler(repository.NewGormBookRepository(db, withSearch)),
+		NewAuthorHandler(repository.NewAuthorRepository(db, withSearch)),
+	)
+	return r, index
+}
+
+func TestListBooks_EmbeddedSearch(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router, index := setupEmbeddedSearchRouter(t, db)
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+
+	leguin := testutil.SeedAuthor(t, db, "Ursula K. Le Guin")
+	testutil.SeedBook(t, db, leguin, "The Dispossessed", "An ambiguous utopia", nil)
+	testutil.SeedBook(t, db, leguin, "A Wizard of Earthsea", "A young wizard's shadow", nil)
+
+	// Seeded rows bypass the repositories, so only a reindex finds them.
+	if got := visibleBookTitles(t, router, "", "/books?q=wizard"); len(got) != 0 {
+		t.Fatalf("expected no matches before reindexing, got %v", got)
+	}
+	if n, err := index.Reindex(context.Background(), db); err != nil || n != 2 {
+		t.Fatalf("expected 2 books reindexed, got %d, err=%v", n, err)
+	}
+	if got := visibleBookTitles(t, router, "", "/books?q=wizard"); !reflect.DeepEqual(got, []string{"A Wizard of Earthsea"}) {
+		t.Fatalf("expected the wizard book, got %v", got)
+	}
+
+	w := doAuthJSON(router, alice, http.MethodPost, "/books", map[string]any{
+		"title": "Tehanu", "description": "The last book of Earthsea", "author_id": leguin.ID.String(),
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var created BookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	tehanu := created.Data.ID.String()
+
+	// Titles outrank descriptions.
+	got := visibleBookTitles(t, router, "", "/books?q=earthsea&sort=relevance")
+	if !reflect.DeepEqual(got, []string{"A Wizard of Earthsea", "Tehanu"}) {
+		t.Fatalf("expected the title match first, got %v", got)
+	}
+
+	if got := visibleBookTitles(t, router, "", "/books?q=earthsae&fuzzy=true"); len(got) != 2 {
+		t.Fatalf("expected typos to match both Earthsea books, got %v", got)
+	}
+
+	if w := doAuthJSON(router, alice, http.MethodPatch, "/authors/"+leguin.ID.String(), map[string]any{
+		"name": "Ursula Kroeber Le Guin",
+	}); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if got := visibleBookTitles(t, router, "", "/books?q=kroeber"); len(got) != 3 {
+		t.Fatalf("expected the renamed author's books to match, got %v", got)
+	}
+
+	if w := doAuthJSON(router, alice, http.MethodDelete, "/books/"+tehanu, nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if got := visibleBookTitles(t, router, "", "/books?q=earthsea"); !reflect.DeepEqual(got, []string{"A Wizard of Earthsea"}) {
+		t.Fatalf("expected the deleted book to be gone, got %v", got)
+	}
+}
+
+func TestListBooks_EmbeddedSearchPagesPastManyHits(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router, index := setupEmbeddedSearchRouter(t, db)
+
+	author := testutil.SeedAuthor(t, db, "Ursula K. Le Guin")
+	owner := "6563a1f0c2a4b5d6e7f80922"
+	books := make([]model.Book, 0, 1500)
+	for i := range 1500 {
+		b := model.Book{Title: fmt.Sprintf("Wizard %04d", i), AuthorID: author.ID}
+		// Hidden matches mustn't crowd out visible ones.
+		if i%5 == 0 {
+			b.OwnerID = &owner
+			b.Visibility = model.VisibilityPrivate
+		}
+		books = append(books, b)
+	}
+	if err := db.CreateInBatches(&books, 250).Error; err != nil {
+		t.Fatalf("failed to seed books: %v", err)
+	}
+	if _, err := index.Reindex(context.Background(), db); err != nil {
+		t.Fatalf("failed to reindex: %v", err)
+	}
+
+	w := doAuthJSON(router, "", http.MethodGet, "/books?q=wizard&page=12&page_size=100", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var resp ListBooksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Pagination.Total != 1200 || len(resp.Data) != 100 {
+		t.Fatalf("expected the last 100 of 1200 visible matches, got %d of %d", len(resp.Data), resp.Pagination.Total)
+	}
+}
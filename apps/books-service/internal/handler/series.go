@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"gorm.io/gorm"
+)
+
+type SeriesHandler struct {
+	repo repository.SeriesRepository
+}
+
+func NewSeriesHandler(repo repository.SeriesRepository) *SeriesHandler {
+	return &SeriesHandler{repo: repo}
+}
+
+func (h *SeriesHandler) RegisterRoutes(r *gin.RouterGroup) {
+	series := r.Group("/series")
+	{
+		series.POST("", h.CreateSeries)
+		series.GET("", h.ListSeries)
+		series.GET("/:id", h.GetSeriesByID)
+		series.PATCH("/:id", h.UpdateSeries)
+		series.DELETE("/:id", h.DeleteSeries)
+	}
+}
+
+// toSeriesResponse lists the volumes in order and derives the series'
+// authors from its books, in order of first appearance.
+func toSeriesResponse(s model.Series) SeriesResponse {
+	books := make([]SeriesVolume, 0, len(s.Books))
+	authors := make([]AuthorSummary, 0)
+	seen := make(map[uuid.UUID]bool)
+
+	for _, b := range s.Books {
+		var pub *model.Date
+		if b.PublishedAt != nil && !b.PublishedAt.IsZero() {
+			pub = &model.Date{Time: *b.PublishedAt}
+		}
+
+		author := AuthorSummary{
+			ID:   b.Author.ID,
+			Name: b.Author.Name,
+			Bio:  b.Author.Bio,
+		}
+
+		books = append(books, SeriesVolume{
+			ID:          b.ID,
+			Title:       b.Title,
+			Volume:      b.SeriesVolume,
+			Author:      author,
+			PublishedAt: pub,
+		})
+
+		if !seen[b.AuthorID] {
+			seen[b.AuthorID] = true
+			authors = append(authors, author)
+		}
+	}
+
+	return SeriesResponse{
+		Data: Series{
+			ID:          s.ID,
+			Name:        s.Name,
+			Description: s.Description,
+			Authors:     authors,
+			Books:       books,
+			CreatedAt:   model.Date{Time: s.CreatedAt},
+			UpdatedAt:   model.Date{Time: s.UpdatedAt},
+		},
+	}
+}
+
+// CreateSeries godoc
+// @Summary      Create a series
+// @Description  Create a new book series with name and optional description
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      CreateSeriesRequest       true  "Series to create"
+// @Success      201      {object}  SeriesResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /series [post]
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	var req CreateSeriesRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	series := model.Series{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &series); err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"SERIES_CREATE_FAILED",
+			"failed to create series",
+		)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toSeriesResponse(series))
+}
+
+// ListSeries godoc
+// @Summary      List series
+// @Description  Get all series with their volumes in order
+// @Tags         series
+// @Produce      json
+// @Success      200  {object}  ListSeriesResponse
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /series [get]
+func (h *SeriesHandler) ListSeries(c *gin.Context) {
+	series, err := h.repo.List(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"SERIES_LIST_FAILED",
+			"failed to list series",
+		)
+		return
+	}
+
+	res := make([]Series, 0, len(series))
+	for _, s := range series {
+		res = append(res, toSeriesResponse(s).Data)
+	}
+
+	c.JSON(http.StatusOK, ListSeriesResponse{Data: res})
+}
+
+// GetSeriesByID godoc
+// @Summary      Get series by ID
+// @Description  Get a single series with its volumes ordered by volume number
+// @Tags         series
+// @Produce      json
+// @Param        id   path      string                    true  "Series ID (UUID)"
+// @Success      200  {object}  SeriesResponse
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      404  {object}  validation.ErrorResponse  "Series not found"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /series/{id} [get]
+func (h *SeriesHandler) GetSeriesByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"SERIES_INVALID_ID",
+			"invalid series id",
+		)
+		return
+	}
+
+	series, err := h.repo.FindByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"SERIES_NOT_FOUND",
+				"series not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"SERIES_FETCH_FAILED",
+			"failed to fetch series",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSeriesResponse(*series))
+}
+
+// UpdateSeries godoc
+// @Summary      Update a series
+// @Description  Partially update an existing series
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                    true  "Series ID (UUID)"
+// @Param        payload  body      UpdateSeriesRequest       true  "Series fields to update"
+// @Success      200      {object}  SeriesResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid ID or validation error"
+// @Failure      404      {object}  validation.ErrorResponse  "Series not found"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /series/{id} [patch]
+func (h *SeriesHandler) UpdateSeries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"SERIES_INVALID_ID",
+			"invalid series id",
+		)
+		return
+	}
+
+	var req UpdateSeriesRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	series, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"SERIES_NOT_FOUND",
+				"series not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"SERIES_FETCH_FAILED",
+			"failed to fetch series",
+		)
+		return
+	}
+
+	if req.Name != nil {
+		series.Name = *req.Name
+	}
+	if req.Description != nil {
+		series.Description = *req.Description
+	}
+
+	if err := h.repo.Update(ctx, series); err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"SERIES_UPDATE_FAILED",
+			"failed to update series",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSeriesResponse(*series))
+}
+
+// DeleteSeries godoc
+// @Summary      Delete a series
+// @Description  Delete a series by ID. Its books are kept and detached from the series.
+// @Tags         series
+// @Produce      json
+// @Param        id   path      string                    true  "Series ID (UUID)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      404  {object}  validation.ErrorResponse  "Series not found"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /series/{id} [delete]
+func (h *SeriesHandler) DeleteSeries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"SERIES_INVALID_ID",
+			"invalid series id",
+		)
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"SERIES_NOT_FOUND",
+				"series not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"SERIES_DELETE_FAILED",
+			"failed to delete series",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
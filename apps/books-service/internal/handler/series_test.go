@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func createSeries(t *testing.T, router *gin.Engine, name string) uuid.UUID {
+	t.Helper()
+
+	w := doJSON(router, http.MethodPost, "/series", map[string]any{"name": name})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp SeriesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return resp.Data.ID
+}
+
+func createSeriesBook(t *testing.T, router *gin.Engine, authorID, seriesID uuid.UUID, title string, volume float64) uuid.UUID {
+	t.Helper()
+
+	w := doJSON(router, http.MethodPost, "/books", map[string]any{
+		"title":         title,
+		"author_id":     authorID.String(),
+		"series_id":     seriesID.String(),
+		"series_volume": volume,
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp BookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return resp.Data.ID
+}
+
+func TestSeries_VolumesOrderedWithNeighbourLinks(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewSeriesHandler(repository.NewSeriesRepository(db)))
+
+	author := testutil.SeedAuthor(t, db, "Martha Wells")
+	seriesID := createSeries(t, router, "The Murderbot Diaries")
+
+	createSeriesBook(t, router, author.ID, seriesID, "Rogue Protocol", 3)
+	first := createSeriesBook(t, router, author.ID, seriesID, "All Systems Red", 1)
+	novella := createSeriesBook(t, router, author.ID, seriesID, "Home: Habitat, Range, Niche, Territory", 2.5)
+	createSeriesBook(t, router, author.ID, seriesID, "Artificial Condition", 2)
+
+	w := doJSON(router, http.MethodGet, "/books?series_id="+seriesID.String(), nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var list ListBooksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	wantOrder := []string{"All Systems Red", "Artificial Condition", "Home: Habitat, Range, Niche, Territory", "Rogue Protocol"}
+	if len(list.Data) != len(wantOrder) {
+		t.Fatalf("expected %d books, got %d", len(wantOrder), len(list.Data))
+	}
+	for i, b := range list.Data {
+		if b.Title != wantOrder[i] {
+			t.Fatalf("expected %q at position %d, got %q", wantOrder[i], i, b.Title)
+		}
+		if b.Series == nil || b.Series.ID != seriesID {
+			t.Fatalf("expected series summary on %q", b.Title)
+		}
+	}
+
+	if list.Data[0].ID != first || list.Data[0].PreviousVolume != nil {
+		t.Errorf("expected first volume without previous link, got %+v", list.Data[0].PreviousVolume)
+	}
+	if list.Data[1].NextVolume == nil || list.Data[1].NextVolume.ID != novella {
+		t.Errorf("expected volume 2 to link to the 2.5 novella, got %+v", list.Data[1].NextVolume)
+	}
+	if list.Data[3].NextVolume != nil {
+		t.Errorf("expected last volume without next link, got %+v", list.Data[3].NextVolume)
+	}
+
+	w = doJSON(router, http.MethodGet, "/series/"+seriesID.String(), nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var series SeriesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &series); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(series.Data.Authors) != 1 || series.Data.Authors[0].ID != author.ID {
+		t.Errorf("expected authors derived from books, got %+v", series.Data.Authors)
+	}
+	if len(series.Data.Books) != 4 || series.Data.Books[0].Title != "All Systems Red" {
+		t.Errorf("expected volumes in order, got %+v", series.Data.Books)
+	}
+}
+
+func TestUpdateBook_RemoveFromSeries(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewSeriesHandler(repository.NewSeriesRepository(db)))
+
+	author := testutil.SeedAuthor(t, db, "Martha Wells")
+	seriesID := createSeries(t, router, "The Murderbot Diaries")
+	bookID := createSeriesBook(t, router, author.ID, seriesID, "All Systems Red", 1)
+
+	w := doJSON(router, http.MethodPatch, "/books/"+bookID.String(), map[string]any{"series_id": ""})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp BookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Data.Series != nil {
+		t.Errorf("expected book to be removed from series, got %+v", resp.Data.Series)
+	}
+}
+
+func TestCreateBook_SeriesVolumeWithoutSeries(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewSeriesHandler(repository.NewSeriesRepository(db)))
+
+	author := testutil.SeedAuthor(t, db, "Martha Wells")
+
+	w := doJSON(router, http.MethodPost, "/books", map[string]any{
+		"title":         "All Systems Red",
+		"author_id":     author.ID.String(),
+		"series_volume": 1,
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d, body=%s", w.Code, w.Body.String())
+	}
+}
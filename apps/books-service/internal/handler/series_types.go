@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+)
+
+type CreateSeriesRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=200"`
+	Description string `json:"description" binding:"omitempty,max=2000"`
+}
+
+type UpdateSeriesRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=2000"`
+}
+
+type SeriesVolume struct {
+	ID          uuid.UUID     `json:"id"`
+	Title       string        `json:"title"`
+	Volume      *float64      `json:"volume,omitempty" example:"2.5"`
+	Author      AuthorSummary `json:"author"`
+	PublishedAt *model.Date   `json:"published_at,omitempty" swaggertype:"string" example:"2025-11-24"`
+}
+
+type Series struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Authors     []AuthorSummary `json:"authors"`
+	Books       []SeriesVolume  `json:"books"`
+	CreatedAt   model.Date      `json:"created_at" swaggertype:"string" example:"2025-11-24"`
+	UpdatedAt   model.Date      `json:"updated_at" swaggertype:"string" example:"2025-11-24"`
+}
+
+type SeriesSummary struct {
+	ID     uuid.UUID `json:"id"`
+	Name   string    `json:"name"`
+	Volume *float64  `json:"volume,omitempty" example:"2.5"`
+}
+
+type SeriesVolumeLink struct {
+	ID     uuid.UUID `json:"id"`
+	Title  string    `json:"title"`
+	Volume float64   `json:"volume" example:"3"`
+}
+
+type SeriesResponse struct {
+	Data Series `json:"data"`
+}
+
+type ListSeriesResponse struct {
+	Data []Series `json:"data"`
+}
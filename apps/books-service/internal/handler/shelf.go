@@ -0,0 +1,490 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"gorm.io/gorm"
+)
+
+type ShelfHandler struct {
+	repo repository.ShelfRepository
+}
+
+func NewShelfHandler(repo repository.ShelfRepository) *ShelfHandler {
+	return &ShelfHandler{repo: repo}
+}
+
+// RegisterRoutes mounts the shelf routes. Every route acts on the caller's
+// own shelves, so the auth middleware must already be installed on r.
+func (h *ShelfHandler) RegisterRoutes(r *gin.RouterGroup) {
+	shelves := r.Group("/shelves", auth.Required())
+	{
+		shelves.GET("", h.ListShelves)
+		shelves.POST("", h.CreateShelf)
+		shelves.GET("/:shelf", h.GetShelf)
+		shelves.PATCH("/:shelf", h.RenameShelf)
+		shelves.DELETE("/:shelf", h.DeleteShelf)
+		shelves.PUT("/:shelf/books/:book_id", h.ShelveBook)
+		shelves.POST("/:shelf/books/:book_id/move", h.MoveShelfEntry)
+		shelves.DELETE("/:shelf/books/:book_id", h.UnshelveBook)
+	}
+}
+
+func toShelf(s model.Shelf, bookCount int64) Shelf {
+	return Shelf{
+		ID:        s.ID,
+		Slug:      s.Slug,
+		Name:      s.Name,
+		Builtin:   s.Builtin,
+		BookCount: bookCount,
+	}
+}
+
+func toShelfEntry(e model.ShelfEntry, shelfSlug string) ShelfEntry {
+	return ShelfEntry{
+		Book:       toBookSummaryResponse(e.Book).Data,
+		Shelf:      shelfSlug,
+		AddedAt:    model.Date{Time: e.AddedAt},
+		StartedAt:  toOptionalDate(e.StartedAt),
+		FinishedAt: toOptionalDate(e.FinishedAt),
+	}
+}
+
+func fromOptionalDate(d *model.Date) *time.Time {
+	if d == nil || d.IsZero() {
+		return nil
+	}
+	t := d.Time
+	return &t
+}
+
+// ListShelves godoc
+// @Summary      List my shelves
+// @Description  List the caller's shelves with book counts. The built-in want-to-read, currently-reading and read shelves come first.
+// @Tags         shelves
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  ListShelvesResponse
+// @Failure      401  {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /shelves [get]
+func (h *ShelfHandler) ListShelves(c *gin.Context) {
+	user, _ := auth.UserFrom(c)
+
+	shelves, err := h.repo.List(c.Request.Context(), user.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"SHELF_LIST_FAILED",
+			"failed to list shelves",
+		)
+		return
+	}
+
+	res := make([]Shelf, 0, len(shelves))
+	for _, s := range shelves {
+		res = append(res, Shelf{
+			ID:        s.ID,
+			Slug:      s.Slug,
+			Name:      s.Name,
+			Builtin:   s.Builtin,
+			BookCount: s.BookCount,
+		})
+	}
+
+	c.JSON(http.StatusOK, ListShelvesResponse{Data: res})
+}
+
+// CreateShelf godoc
+// @Summary      Create a custom shelf
+// @Description  Create a named shelf for the caller. The shelf can then be addressed by its UUID or by the slug derived from its name.
+// @Tags         shelves
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        payload  body      CreateShelfRequest        true  "Shelf to create"
+// @Success      201      {object}  ShelfResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      409      {object}  validation.ErrorResponse  "Shelf already exists"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /shelves [post]
+func (h *ShelfHandler) CreateShelf(c *gin.Context) {
+	var req CreateShelfRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	user, _ := auth.UserFrom(c)
+	shelf := model.Shelf{UserID: user.ID, Name: req.Name}
+
+	if err := h.repo.Create(c.Request.Context(), &shelf); err != nil {
+		if writeShelfError(c, err) {
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"SHELF_CREATE_FAILED",
+			"failed to create shelf",
+		)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ShelfResponse{Data: toShelf(shelf, 0)})
+}
+
+// GetShelf godoc
+// @Summary      Get a shelf
+// @Description  Get one of the caller's shelves with its books, most recently added first
+// @Tags         shelves
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shelf  path      string                    true  "Shelf UUID or slug"
+// @Success      200    {object}  ShelfResponse
+// @Failure      401    {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404    {object}  validation.ErrorResponse  "Shelf not found"
+// @Failure      500    {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /shelves/{shelf} [get]
+func (h *ShelfHandler) GetShelf(c *gin.Context) {
+	shelf, ok := h.findShelf(c, c.Param("shelf"))
+	if !ok {
+		return
+	}
+
+	entries, err := h.repo.Entries(c.Request.Context(), shelf.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"SHELF_FETCH_FAILED",
+			"failed to fetch shelf",
+		)
+		return
+	}
+
+	data := toShelf(*shelf, int64(len(entries)))
+	data.Entries = make([]ShelfEntry, 0, len(entries))
+	for _, e := range entries {
+		data.Entries = append(data.Entries, toShelfEntry(e, shelf.Slug))
+	}
+
+	c.JSON(http.StatusOK, ShelfResponse{Data: data})
+}
+
+// RenameShelf godoc
+// @Summary      Rename a custom shelf
+// @Description  Rename one of the caller's custom shelves. Its slug changes with the name. Built-in shelves cannot be renamed.
+// @Tags         shelves
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shelf    path      string                    true  "Shelf UUID or slug"
+// @Param        payload  body      UpdateShelfRequest        true  "New name"
+// @Success      200      {object}  ShelfResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404      {object}  validation.ErrorResponse  "Shelf not found"
+// @Failure      409      {object}  validation.ErrorResponse  "Shelf already exists or is built in"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /shelves/{shelf} [patch]
+func (h *ShelfHandler) RenameShelf(c *gin.Context) {
+	var req UpdateShelfRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	shelf, ok := h.findShelf(c, c.Param("shelf"))
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	shelf.Name = req.Name
+	if err := h.repo.Rename(ctx, shelf); err != nil {
+		if writeShelfError(c, err) {
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"SHELF_UPDATE_FAILED",
+			"failed to rename shelf",
+		)
+		return
+	}
+
+	entries, err := h.repo.Entries(ctx, shelf.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"SHELF_FETCH_FAILED",
+			"failed to fetch renamed shelf",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, ShelfResponse{Data: toShelf(*shelf, int64(len(entries)))})
+}
+
+// DeleteShelf godoc
+// @Summary      Delete a custom shelf
+// @Description  Delete one of the caller's custom shelves. The books stay in the catalog. Built-in shelves cannot be deleted.
+// @Tags         shelves
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shelf  path  string  true  "Shelf UUID or slug"
+// @Success      204    "No Content"
+// @Failure      401    {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404    {object}  validation.ErrorResponse  "Shelf not found"
+// @Failure      409    {object}  validation.ErrorResponse  "Shelf is built in"
+// @Failure      500    {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /shelves/{shelf} [delete]
+func (h *ShelfHandler) DeleteShelf(c *gin.Context) {
+	shelf, ok := h.findShelf(c, c.Param("shelf"))
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), shelf); err != nil {
+		if writeShelfError(c, err) {
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"SHELF_DELETE_FAILED",
+			"failed to delete shelf",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ShelveBook godoc
+// @Summary      Put a book on a shelf
+// @Description  Add a book to one of the caller's shelves, or update its dates if it is already there. added_at defaults to today, started_at defaults to today on currently-reading and finished_at defaults to today on read. A book is on at most one built-in shelf, so adding it to one takes it off the others.
+// @Tags         shelves
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shelf    path      string                    true   "Shelf UUID or slug"
+// @Param        book_id  path      string                    true   "Book ID (UUID)"
+// @Param        payload  body      ShelveBookRequest         false  "Reading dates"
+// @Success      200      {object}  ShelfEntryResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid book ID or dates"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404      {object}  validation.ErrorResponse  "Shelf or book not found"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /shelves/{shelf}/books/{book_id} [put]
+func (h *ShelfHandler) ShelveBook(c *gin.Context) {
+	bookID, ok := parseShelfBookID(c)
+	if !ok {
+		return
+	}
+
+	var req ShelveBookRequest
+	if c.Request.ContentLength != 0 && !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	shelf, ok := h.findShelf(c, c.Param("shelf"))
+	if !ok {
+		return
+	}
+
+	entry := model.ShelfEntry{
+		BookID:     bookID,
+		StartedAt:  fromOptionalDate(req.StartedAt),
+		FinishedAt: fromOptionalDate(req.FinishedAt),
+	}
+	if t := fromOptionalDate(req.AddedAt); t != nil {
+		entry.AddedAt = *t
+	}
+
+	if err := h.repo.PutEntry(c.Request.Context(), shelf, &entry); err != nil {
+		if writeShelfError(c, err) {
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"SHELF_ENTRY_SAVE_FAILED",
+			"failed to put book on shelf",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, ShelfEntryResponse{Data: toShelfEntry(entry, shelf.Slug)})
+}
+
+// MoveShelfEntry godoc
+// @Summary      Move a book to another shelf
+// @Description  Take a book off one of the caller's shelves and put it on another, keeping its dates unless new ones are given
+// @Tags         shelves
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shelf    path      string                    true  "Current shelf UUID or slug"
+// @Param        book_id  path      string                    true  "Book ID (UUID)"
+// @Param        payload  body      MoveShelfEntryRequest     true  "Destination shelf and dates"
+// @Success      200      {object}  ShelfEntryResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404      {object}  validation.ErrorResponse  "Shelf not found or book not on shelf"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /shelves/{shelf}/books/{book_id}/move [post]
+func (h *ShelfHandler) MoveShelfEntry(c *gin.Context) {
+	bookID, ok := parseShelfBookID(c)
+	if !ok {
+		return
+	}
+
+	var req MoveShelfEntryRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	from, ok := h.findShelf(c, c.Param("shelf"))
+	if !ok {
+		return
+	}
+	to, ok := h.findShelf(c, req.To)
+	if !ok {
+		return
+	}
+
+	entry := model.ShelfEntry{
+		BookID:     bookID,
+		StartedAt:  fromOptionalDate(req.StartedAt),
+		FinishedAt: fromOptionalDate(req.FinishedAt),
+	}
+
+	if err := h.repo.MoveEntry(c.Request.Context(), from, to, &entry); err != nil {
+		if writeShelfError(c, err) {
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"SHELF_ENTRY_MOVE_FAILED",
+			"failed to move book",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, ShelfEntryResponse{Data: toShelfEntry(entry, to.Slug)})
+}
+
+// UnshelveBook godoc
+// @Summary      Take a book off a shelf
+// @Description  Remove a book from one of the caller's shelves
+// @Tags         shelves
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shelf    path  string  true  "Shelf UUID or slug"
+// @Param        book_id  path  string  true  "Book ID (UUID)"
+// @Success      204      "No Content"
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid book ID"
+// @Failure      401      {object}  validation.ErrorResponse  "Authentication required"
+// @Failure      404      {object}  validation.ErrorResponse  "Shelf not found or book not on shelf"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /shelves/{shelf}/books/{book_id} [delete]
+func (h *ShelfHandler) UnshelveBook(c *gin.Context) {
+	bookID, ok := parseShelfBookID(c)
+	if !ok {
+		return
+	}
+
+	shelf, ok := h.findShelf(c, c.Param("shelf"))
+	if !ok {
+		return
+	}
+
+	if err := h.repo.RemoveEntry(c.Request.Context(), shelf.ID, bookID); err != nil {
+		if writeShelfError(c, err) {
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"SHELF_ENTRY_DELETE_FAILED",
+			"failed to take book off shelf",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// findShelf resolves ref among the caller's shelves, writing the error
+// response itself when it can't.
+func (h *ShelfHandler) findShelf(c *gin.Context, ref string) (*model.Shelf, bool) {
+	user, _ := auth.UserFrom(c)
+
+	shelf, err := h.repo.Find(c.Request.Context(), user.ID, ref)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"SHELF_NOT_FOUND",
+				"shelf not found",
+			)
+			return nil, false
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"SHELF_FETCH_FAILED",
+			"failed to fetch shelf",
+		)
+		return nil, false
+	}
+
+	return shelf, true
+}
+
+func parseShelfBookID(c *gin.Context) (uuid.UUID, bool) {
+	bookID, err := uuid.Parse(c.Param("book_id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_BOOK_ID",
+			"book_id must be a valid UUID",
+		)
+		return uuid.Nil, false
+	}
+	return bookID, true
+}
+
+// writeShelfError maps the shelf repository's sentinel errors to responses
+// and reports whether it wrote one.
+func writeShelfError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, repository.ErrShelfAlreadyExists):
+		writeError(c, http.StatusConflict,
+			"SHELF_ALREADY_EXISTS",
+			"you already have a shelf with this name",
+		)
+	case errors.Is(err, repository.ErrShelfBuiltin):
+		writeError(c, http.StatusConflict,
+			"SHELF_BUILTIN",
+			"built-in shelves cannot be renamed or deleted",
+		)
+	case errors.Is(err, repository.ErrInvalidShelfName):
+		writeError(c, http.StatusBadRequest,
+			"SHELF_INVALID_NAME",
+			"shelf name must contain letters or digits",
+		)
+	case errors.Is(err, repository.ErrShelfEntryNotFound):
+		writeError(c, http.StatusNotFound,
+			"SHELF_ENTRY_NOT_FOUND",
+			"book is not on this shelf",
+		)
+	case errors.Is(err, repository.ErrBookNotFound):
+		writeError(c, http.StatusNotFound,
+			"BOOK_NOT_FOUND",
+			"book not found",
+		)
+	default:
+		return false
+	}
+	return true
+}
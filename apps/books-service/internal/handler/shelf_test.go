@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+const testJWTSecret = "test_secret"
+
+func tokenFor(t *testing.T, userID string) string {
+	t.Helper()
+
+	token, err := auth.SignToken(testJWTSecret, auth.User{ID: userID}, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func doAuthJSON(router *gin.Engine, token, method, path string, body any) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+
+	req := httptest.NewRequest(method, path, &buf)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestShelves_RequireAuthentication(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewShelfHandler(repository.NewShelfRepository(db)))
+
+	if w := doAuthJSON(router, "", http.MethodGet, "/shelves", nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, "", http.MethodGet, "/books?shelf=read", nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401 for shelf filter, got %d", w.Code)
+	}
+}
+
+func TestShelves_BuiltinsAndCustomShelves(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewShelfHandler(repository.NewShelfRepository(db)))
+	token := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+
+	w := doAuthJSON(router, token, http.MethodPost, "/shelves", map[string]any{"name": "Sci-Fi Favourites"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	w = doAuthJSON(router, token, http.MethodPost, "/shelves", map[string]any{"name": "Read"})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 for built-in name, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	w = doAuthJSON(router, token, http.MethodGet, "/shelves", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var list ListShelvesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(list.Data) != 4 {
+		t.Fatalf("expected 3 built-in shelves and 1 custom, got %+v", list.Data)
+	}
+	if !list.Data[0].Builtin || list.Data[3].Slug != "sci-fi-favourites" {
+		t.Fatalf("expected built-ins first and the custom shelf last, got %+v", list.Data)
+	}
+
+	if w := doAuthJSON(router, token, http.MethodDelete, "/shelves/read", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 deleting a built-in shelf, got %d", w.Code)
+	}
+
+	other := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+	if w := doAuthJSON(router, other, http.MethodGet, "/shelves/sci-fi-favourites", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected another user's shelf to be hidden, got %d", w.Code)
+	}
+
+	if w := doAuthJSON(router, token, http.MethodDelete, "/shelves/sci-fi-favourites", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d, body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestShelves_AddMoveRemoveBooks(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewShelfHandler(repository.NewShelfRepository(db)))
+	token := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	other := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+
+	author := testutil.SeedAuthor(t, db, "N. K. Jemisin")
+	fifth := testutil.SeedBook(t, db, author, "The Fifth Season", "", nil)
+	obelisk := testutil.SeedBook(t, db, author, "The Obelisk Gate", "", nil)
+
+	w := doAuthJSON(router, token, http.MethodPut, "/shelves/want-to-read/books/"+fifth.ID.String(), map[string]any{
+		"added_at": "2025-01-02",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if w := doAuthJSON(router, token, http.MethodPut, "/shelves/want-to-read/books/"+obelisk.ID.String(), nil); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	w = doAuthJSON(router, token, http.MethodPost, "/shelves/want-to-read/books/"+fifth.ID.String()+"/move", map[string]any{
+		"to": "currently-reading",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var moved ShelfEntryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &moved); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if moved.Data.Shelf != "currently-reading" || moved.Data.StartedAt == nil {
+		t.Fatalf("expected book on currently-reading with a start date, got %+v", moved.Data)
+	}
+	if got := moved.Data.AddedAt.Format("2006-01-02"); got != "2025-01-02" {
+		t.Fatalf("expected added_at to carry over, got %s", got)
+	}
+
+	// Putting the book straight on read takes it off currently-reading.
+	w = doAuthJSON(router, token, http.MethodPut, "/shelves/read/books/"+fifth.ID.String(), map[string]any{
+		"finished_at": "2025-03-04",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var finished ShelfEntryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &finished); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if finished.Data.StartedAt == nil || finished.Data.FinishedAt == nil ||
+		finished.Data.FinishedAt.Format("2006-01-02") != "2025-03-04" {
+		t.Fatalf("expected started_at carried over and finished_at set, got %+v", finished.Data)
+	}
+
+	if titles := visibleBookTitles(t, router, token, "/books?shelf=currently-reading"); len(titles) != 0 {
+		t.Fatalf("expected currently-reading to be empty, got %v", titles)
+	}
+	if titles := visibleBookTitles(t, router, token, "/books?shelf=read"); len(titles) != 1 || titles[0] != "The Fifth Season" {
+		t.Fatalf("expected only The Fifth Season on read, got %v", titles)
+	}
+	if titles := visibleBookTitles(t, router, other, "/books?shelf=want-to-read"); len(titles) != 0 {
+		t.Fatalf("expected another user's shelf filter to be empty, got %v", titles)
+	}
+
+	w = doAuthJSON(router, token, http.MethodDelete, "/shelves/want-to-read/books/"+obelisk.ID.String(), nil)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d, body=%s", w.Code, w.Body.String())
+	}
+	w = doAuthJSON(router, token, http.MethodDelete, "/shelves/want-to-read/books/"+obelisk.ID.String(), nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 removing twice, got %d", w.Code)
+	}
+
+	w = doAuthJSON(router, token, http.MethodPut, "/shelves/read/books/"+uuid.New().String(), nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for unknown book, got %d", w.Code)
+	}
+}
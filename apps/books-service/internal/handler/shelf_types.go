@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+)
+
+type CreateShelfRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+type UpdateShelfRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+type ShelveBookRequest struct {
+	AddedAt    *model.Date `json:"added_at" swaggertype:"string" example:"2025-11-24"`
+	StartedAt  *model.Date `json:"started_at" swaggertype:"string" example:"2025-11-24"`
+	FinishedAt *model.Date `json:"finished_at" swaggertype:"string" example:"2025-11-24"`
+}
+
+type MoveShelfEntryRequest struct {
+	// To is the destination shelf's UUID or slug.
+	To         string      `json:"to" binding:"required" example:"currently-reading"`
+	StartedAt  *model.Date `json:"started_at" swaggertype:"string" example:"2025-11-24"`
+	FinishedAt *model.Date `json:"finished_at" swaggertype:"string" example:"2025-11-24"`
+}
+
+type Shelf struct {
+	ID        uuid.UUID    `json:"id"`
+	Slug      string       `json:"slug" example:"want-to-read"`
+	Name      string       `json:"name" example:"Want to Read"`
+	Builtin   bool         `json:"builtin"`
+	BookCount int64        `json:"book_count"`
+	Entries   []ShelfEntry `json:"entries,omitempty"`
+}
+
+type ShelfEntry struct {
+	Book       BookSummary `json:"book"`
+	Shelf      string      `json:"shelf" example:"read"`
+	AddedAt    model.Date  `json:"added_at" swaggertype:"string" example:"2025-11-24"`
+	StartedAt  *model.Date `json:"started_at,omitempty" swaggertype:"string" example:"2025-11-24"`
+	FinishedAt *model.Date `json:"finished_at,omitempty" swaggertype:"string" example:"2025-11-24"`
+}
+
+type ShelfResponse struct {
+	Data Shelf `json:"data"`
+}
+
+type ListShelvesResponse struct {
+	Data []Shelf `json:"data"`
+}
+
+type ShelfEntryResponse struct {
+	Data ShelfEntry `json:"data"`
+}
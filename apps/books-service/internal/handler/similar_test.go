@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func TestSimilarBooks(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(
+		NewBookHandler(repository.NewGormBookRepository(db), WithSimilarBooks(repository.NewSimilarityRepository(db))),
+		NewShelfHandler(repository.NewShelfRepository(db)),
+	)
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+
+	leGuin := testutil.SeedAuthor(t, db, "Ursula K. Le Guin")
+	banks := testutil.SeedAuthor(t, db, "Iain M. Banks")
+	chiang := testutil.SeedAuthor(t, db, "Ted Chiang")
+	dispossessed := testutil.SeedBook(t, db, leGuin, "The Dispossessed", "", nil)
+	lathe := testutil.SeedBook(t, db, leGuin, "The Lathe of Heaven", "", nil)
+	player := testutil.SeedBook(t, db, banks, "The Player of Games", "", nil)
+	stories := testutil.SeedBook(t, db, chiang, "Stories of Your Life", "", nil)
+	testutil.SeedBook(t, db, chiang, "Exhalation", "", nil)
+
+	anarchy := model.Tag{Name: "anarchism"}
+	if err := db.Create(&anarchy).Error; err != nil {
+		t.Fatalf("failed to seed tag: %v", err)
+	}
+	for _, b := range []model.Book{dispossessed, player} {
+		if err := db.Model(&b).Association("Tags").Append(&anarchy); err != nil {
+			t.Fatalf("failed to tag book: %v", err)
+		}
+	}
+
+	// Bob has read both The Dispossessed and Stories of Your Life.
+	for _, b := range []model.Book{dispossessed, stories} {
+		if w := doAuthJSON(router, bob, http.MethodPut, "/shelves/read/books/"+b.ID.String(), nil); w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+		}
+	}
+
+	if got := bookTitles(getJSON[SimilarBooksResponse](t, router, "", "/books/"+dispossessed.ID.String()+"/similar").Data); len(got) != 0 {
+		t.Fatalf("expected no recommendations before the first refresh, got %v", got)
+	}
+
+	n, err := repository.NewSimilarityRepository(db).Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if n == 0 {
+		t.Fatalf("expected scored pairs")
+	}
+
+	got := bookTitles(getJSON[SimilarBooksResponse](t, router, alice, "/books/"+dispossessed.ID.String()+"/similar").Data)
+	want := []string{lathe.Title, player.Title, stories.Title}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	if got := bookTitles(getJSON[SimilarBooksResponse](t, router, bob, "/books/"+lathe.ID.String()+"/similar").Data); len(got) != 0 {
+		t.Fatalf("expected books bob has read to be left out, got %v", got)
+	}
+
+	if w := doAuthJSON(router, "", http.MethodGet, "/books/"+leGuin.ID.String()+"/similar", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for an unknown book, got %d", w.Code)
+	}
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+)
+
+type StatsHandler struct {
+	repo repository.StatsRepository
+}
+
+func NewStatsHandler(repo repository.StatsRepository) *StatsHandler {
+	return &StatsHandler{repo: repo}
+}
+
+func (h *StatsHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/stats", h.GetStats)
+}
+
+func toMissingCount(count, total int64) MissingCount {
+	m := MissingCount{Count: count}
+	if total > 0 {
+		m.Share = float64(count) / float64(total)
+	}
+	return m
+}
+
+func toBookStatsResponse(s *repository.BookStats, publishedBy string) BookStatsResponse {
+	data := BookStats{
+		Total:              s.Total,
+		MissingDescription: toMissingCount(s.MissingDescription, s.Total),
+		MissingPublishedAt: toMissingCount(s.MissingPublishedAt, s.Total),
+		PublishedBy:        publishedBy,
+		Published:          make([]PeriodCount, 0, len(s.Published)),
+		AddedByMonth:       make([]MonthCount, 0, len(s.Added)),
+		TopAuthors:         make([]AuthorCount, 0, len(s.TopAuthors)),
+	}
+	for _, p := range s.Published {
+		data.Published = append(data.Published, PeriodCount{Year: p.Year, Count: p.Count})
+	}
+	for _, m := range s.Added {
+		data.AddedByMonth = append(data.AddedByMonth, MonthCount{Month: m.Month, Count: m.Count})
+	}
+	for _, a := range s.TopAuthors {
+		data.TopAuthors = append(data.TopAuthors, AuthorCount{AuthorID: a.AuthorID, Name: a.Name, BookCount: a.Count})
+	}
+	return BookStatsResponse{Data: data}
+}
+
+// GetStats godoc
+// @Summary      Get catalog statistics
+// @Description  Totals and breakdowns over the books the caller can see: books per publication year or decade, books added per month, top authors by book count, and how many books lack a description or publication date. Accepts the same filters as listing books. Results are cached briefly.
+// @Tags         stats
+// @Produce      json
+// @Param        published_by    query     string  false  "Bucket publication dates by year or decade" Enums(year,decade) default(year)
+// @Param        top_authors     query     int     false  "How many top authors to return" default(10) minimum(1) maximum(50)
+// @Param        q               query     string  false  "Full-text search on title and description"
+// @Param        fuzzy           query     bool    false  "Match q against titles and author names by trigram similarity, tolerating typos"
+// @Param        similarity      query     number  false  "Similarity a fuzzy match needs, from 0 to 1" default(0.5)
+// @Param        author_id       query     string  false  "Filter by author ID (UUID)"
+// @Param        series_id       query     string  false  "Filter by series ID (UUID)"
+// @Param        work_id         query     string  false  "Filter to the editions of a work (UUID)"
+// @Param        publisher_id    query     string  false  "Filter by publisher ID (UUID)"
+// @Param        published_after query     string  false  "Filter: published_at >= YYYY-MM-DD" example(2015-01-01)
+// @Param        published_before query    string  false  "Filter: published_at <= YYYY-MM-DD" example(2020-12-31)
+// @Param        decade          query     int     false  "Filter to books published in the decade starting this year" example(1990)
+// @Param        language        query     string  false  "Filter by language code" example(en)
+// @Param        tags            query     string  false  "Comma-separated tag names to filter by" example(scifi,classic)
+// @Param        tags_mode       query     string  false  "Whether books need any or all of the tags" Enums(any,all) default(any)
+// @Param        exclude_tags    query     string  false  "Comma-separated tag names to exclude" example(horror)
+// @Param        min_rating      query     number  false  "Only books whose average rating is at least this" minimum(1) maximum(5)
+// @Param        available       query     bool    false  "Only books with at least one copy available to borrow"
+// @Param        shelf           query     string  false  "Only books on one of the caller's shelves (UUID or slug); requires a bearer token" example(currently-reading)
+// @Param        group_id        query     string  false  "Only books in a group's combined library (UUID); requires a bearer token and membership"
+// @Success      200  {object}  BookStatsResponse
+// @Failure      400  {object}  validation.ErrorResponse   "Invalid query parameters"
+// @Failure      401  {object}  validation.ErrorResponse   "Shelf or group filter without authentication"
+// @Failure      403  {object}  validation.ErrorResponse   "Not a member of the group"
+// @Failure      500  {object}  validation.ErrorResponse   "Internal server error"
+// @Router       /stats [get]
+func (h *StatsHandler) GetStats(c *gin.Context) {
+	books, ok := parseBookListParams(c)
+	if !ok {
+		return
+	}
+
+	publishedBy := c.DefaultQuery("published_by", repository.StatsByYear)
+	if publishedBy != repository.StatsByYear && publishedBy != repository.StatsByDecade {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_PUBLISHED_BY",
+			"published_by must be one of: year, decade",
+		)
+		return
+	}
+
+	topAuthors := parseIntQuery(c, "top_authors", 10)
+	if topAuthors < 1 || topAuthors > 50 {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_TOP_AUTHORS",
+			"top_authors must be between 1 and 50",
+		)
+		return
+	}
+
+	stats, err := h.repo.Stats(c.Request.Context(), repository.StatsParams{
+		Books:       books,
+		PublishedBy: publishedBy,
+		TopAuthors:  topAuthors,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotGroupMember) {
+			writeError(c, http.StatusForbidden,
+				"NOT_GROUP_MEMBER",
+				"you are not a member of this group",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"STATS_FAILED",
+			"failed to compute statistics",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, toBookStatsResponse(stats, publishedBy))
+}
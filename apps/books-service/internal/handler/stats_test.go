@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func TestStats(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewStatsHandler(repository.NewStatsRepository(db, time.Minute)))
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+
+	year := func(y int) *time.Time {
+		d := time.Date(y, time.June, 1, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	leGuin := testutil.SeedAuthor(t, db, "Ursula K. Le Guin")
+	butler := testutil.SeedAuthor(t, db, "Octavia E. Butler")
+	testutil.SeedBook(t, db, leGuin, "The Left Hand of Darkness", "Genly Ai on Gethen.", year(1969))
+	testutil.SeedBook(t, db, leGuin, "The Lathe of Heaven", "Dreams change the world.", year(1971))
+	testutil.SeedBook(t, db, leGuin, "The Dispossessed", "Shevek between worlds.", year(1974))
+	kindred := testutil.SeedBook(t, db, butler, "Kindred", "Dana is pulled back in time.", year(1979))
+	testutil.SeedBook(t, db, butler, "Parable of the Sower", "", year(1993))
+	testutil.SeedBook(t, db, butler, "Unexpected Stories", "", nil)
+
+	added := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	if err := db.Model(&model.Book{}).Where("id = ?", kindred.ID).Update("created_at", added).Error; err != nil {
+		t.Fatalf("failed to backdate book: %v", err)
+	}
+
+	if w := doAuthJSON(router, alice, http.MethodPost, "/books", map[string]any{
+		"title": "Notebook", "author_id": butler.ID.String(), "visibility": "private", "published_at": "2020-01-01",
+	}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	stats := getJSON[BookStatsResponse](t, router, "", "/stats?published_by=decade").Data
+	if stats.Total != 6 {
+		t.Fatalf("expected 6 visible books, got %d", stats.Total)
+	}
+	if stats.MissingDescription.Count != 2 || stats.MissingPublishedAt.Count != 1 {
+		t.Fatalf("expected 2 without a description and 1 without a date, got %+v", stats)
+	}
+	if share := stats.MissingDescription.Share; share < 0.33 || share > 0.34 {
+		t.Fatalf("expected a third missing descriptions, got %v", share)
+	}
+
+	wantDecades := []PeriodCount{{1960, 1}, {1970, 3}, {1990, 1}}
+	if len(stats.Published) != len(wantDecades) {
+		t.Fatalf("expected %v, got %v", wantDecades, stats.Published)
+	}
+	for i, want := range wantDecades {
+		if stats.Published[i] != want {
+			t.Fatalf("expected %v, got %v", wantDecades, stats.Published)
+		}
+	}
+
+	if len(stats.AddedByMonth) != 2 || stats.AddedByMonth[0] != (MonthCount{Month: "2024-03", Count: 1}) || stats.AddedByMonth[1].Count != 5 {
+		t.Fatalf("expected one book backdated to March 2024 and the rest this month, got %v", stats.AddedByMonth)
+	}
+
+	if len(stats.TopAuthors) != 2 || stats.TopAuthors[0].Name != "Octavia E. Butler" || stats.TopAuthors[0].BookCount != 3 {
+		t.Fatalf("expected Butler first with 3 books, got %+v", stats.TopAuthors)
+	}
+
+	// The owner counts their private book too, and filters apply.
+	if got := getJSON[BookStatsResponse](t, router, alice, "/stats").Data; got.Total != 7 {
+		t.Fatalf("expected alice to see 7 books, got %d", got.Total)
+	}
+	filtered := getJSON[BookStatsResponse](t, router, "", "/stats?author_id="+leGuin.ID.String()+"&top_authors=1").Data
+	if filtered.Total != 3 || len(filtered.Published) != 3 || len(filtered.TopAuthors) != 1 {
+		t.Fatalf("expected Le Guin's 3 books by year, got %+v", filtered)
+	}
+
+	// Repeating a query within the cache TTL serves the cached result.
+	testutil.SeedBook(t, db, leGuin, "Always Coming Home", "", year(1985))
+	if got := getJSON[BookStatsResponse](t, router, "", "/stats?published_by=decade").Data; got.Total != 6 {
+		t.Fatalf("expected the cached total, got %d", got.Total)
+	}
+
+	for _, query := range []string{"?published_by=century", "?top_authors=0", "?author_id=nope"} {
+		if w := doAuthJSON(router, "", http.MethodGet, "/stats"+query, nil); w.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for %s, got %d", query, w.Code)
+		}
+	}
+}
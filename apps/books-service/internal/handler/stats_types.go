@@ -0,0 +1,40 @@
+package handler
+
+import "github.com/google/uuid"
+
+type MissingCount struct {
+	Count int64 `json:"count" example:"12"`
+	// Share is Count as a fraction of the total, from 0 to 1.
+	Share float64 `json:"share" example:"0.08"`
+}
+
+type PeriodCount struct {
+	// Year is the year, or the first year of the decade.
+	Year  int   `json:"year" example:"1990"`
+	Count int64 `json:"count" example:"42"`
+}
+
+type MonthCount struct {
+	Month string `json:"month" example:"2025-11"`
+	Count int64  `json:"count" example:"7"`
+}
+
+type AuthorCount struct {
+	AuthorID  uuid.UUID `json:"author_id"`
+	Name      string    `json:"name" example:"Ursula K. Le Guin"`
+	BookCount int64     `json:"book_count" example:"9"`
+}
+
+type BookStats struct {
+	Total              int64         `json:"total" example:"150"`
+	MissingDescription MissingCount  `json:"missing_description"`
+	MissingPublishedAt MissingCount  `json:"missing_published_at"`
+	PublishedBy        string        `json:"published_by" example:"decade"`
+	Published          []PeriodCount `json:"published"`
+	AddedByMonth       []MonthCount  `json:"added_by_month"`
+	TopAuthors         []AuthorCount `json:"top_authors"`
+}
+
+type BookStatsResponse struct {
+	Data BookStats `json:"data"`
+}
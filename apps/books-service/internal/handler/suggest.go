@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+)
+
+type SuggestHandler struct {
+	repo repository.SuggestRepository
+}
+
+func NewSuggestHandler(repo repository.SuggestRepository) *SuggestHandler {
+	return &SuggestHandler{repo: repo}
+}
+
+func (h *SuggestHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/suggest", h.Suggest)
+}
+
+// Suggest godoc
+// @Summary      Autocomplete titles and author names
+// @Description  Suggest book titles and author names starting with the prefix, ignoring case, for a search box. Exact matches come first, then shorter ones. Books the caller can't see are left out.
+// @Tags         search
+// @Produce      json
+// @Param        prefix  query     string  true   "What the user has typed so far"  example(left ha)
+// @Param        types   query     string  false  "Comma-separated kinds of suggestion" Enums(book,author) default(book,author)
+// @Param        limit   query     int     false  "Maximum suggestions" default(8) minimum(1) maximum(20)
+// @Success      200     {object}  SuggestResponse
+// @Failure      400     {object}  validation.ErrorResponse  "Invalid query parameters"
+// @Failure      500     {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /suggest [get]
+func (h *SuggestHandler) Suggest(c *gin.Context) {
+	prefix := strings.TrimSpace(c.Query("prefix"))
+	if prefix == "" || len(prefix) > 100 {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_PREFIX",
+			"prefix is required and must be at most 100 characters",
+		)
+		return
+	}
+
+	types := parseListQuery(c, "types")
+	for _, t := range types {
+		if t != repository.SuggestionBook && t != repository.SuggestionAuthor {
+			writeError(c, http.StatusBadRequest,
+				"INVALID_TYPES",
+				"types must be a comma-separated list of: book, author",
+			)
+			return
+		}
+	}
+
+	limit := parseIntQuery(c, "limit", 8)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 20 {
+		limit = 20
+	}
+
+	suggestions, err := h.repo.Suggest(c.Request.Context(), repository.SuggestParams{
+		Prefix: prefix,
+		Types:  types,
+		Limit:  limit,
+	})
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"SUGGEST_FAILED",
+			"failed to fetch suggestions",
+		)
+		return
+	}
+
+	data := make([]Suggestion, 0, len(suggestions))
+	for _, s := range suggestions {
+		data = append(data, Suggestion{Type: s.Type, ID: s.ID, Text: s.Text, Detail: s.Detail})
+	}
+
+	c.JSON(http.StatusOK, SuggestResponse{Data: data})
+}
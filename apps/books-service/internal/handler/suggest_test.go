@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+func TestSuggest(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewSuggestHandler(repository.NewSuggestRepository(db)))
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+
+	herbert := testutil.SeedAuthor(t, db, "Frank Herbert")
+	testutil.SeedAuthor(t, db, "Lord Dunsany")
+	testutil.SeedAuthor(t, db, "Dune Collective")
+	testutil.SeedBook(t, db, herbert, "Dune Messiah", "", nil)
+	dune := testutil.SeedBook(t, db, herbert, "Dune", "", nil)
+	testutil.SeedBook(t, db, herbert, "Children of Dune", "", nil)
+
+	if w := doAuthJSON(router, alice, http.MethodPost, "/books", map[string]any{
+		"title": "Dune notes", "author_id": herbert.ID.String(), "visibility": "private",
+	}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	got := getJSON[SuggestResponse](t, router, "", "/suggest?prefix=DUNE").Data
+	want := []string{"Dune", "Dune Messiah", "Dune Collective"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %+v", want, got)
+	}
+	for i := range want {
+		if got[i].Text != want[i] {
+			t.Fatalf("expected %v, got %+v", want, got)
+		}
+	}
+	if got[0].Type != "book" || got[0].ID != dune.ID || got[0].Detail != "Frank Herbert" {
+		t.Fatalf("expected the exact title first with its author, got %+v", got[0])
+	}
+
+	if got := getJSON[SuggestResponse](t, router, alice, "/suggest?prefix=dune+n&types=book").Data; len(got) != 1 || got[0].Text != "Dune notes" {
+		t.Fatalf("expected alice's private book, got %+v", got)
+	}
+	if got := getJSON[SuggestResponse](t, router, "", "/suggest?prefix=dun&types=author&limit=1").Data; len(got) != 1 || got[0].Type != "author" {
+		t.Fatalf("expected a single author, got %+v", got)
+	}
+	if got := getJSON[SuggestResponse](t, router, "", "/suggest?prefix=%25").Data; len(got) != 0 {
+		t.Fatalf("expected wildcards to match literally, got %+v", got)
+	}
+
+	for _, query := range []string{"", "prefix=+", "prefix=dune&types=series"} {
+		if w := doAuthJSON(router, "", http.MethodGet, "/suggest?"+query, nil); w.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for %q, got %d", query, w.Code)
+		}
+	}
+}
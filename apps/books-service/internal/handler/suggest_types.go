@@ -0,0 +1,15 @@
+package handler
+
+import "github.com/google/uuid"
+
+type Suggestion struct {
+	Type string    `json:"type" example:"book"`
+	ID   uuid.UUID `json:"id"`
+	Text string    `json:"text" example:"The Left Hand of Darkness"`
+	// Detail is the author's name for book suggestions.
+	Detail string `json:"detail,omitempty" example:"Ursula K. Le Guin"`
+}
+
+type SuggestResponse struct {
+	Data []Suggestion `json:"data"`
+}
@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"gorm.io/gorm"
+)
+
+type TagHandler struct {
+	repo repository.TagRepository
+}
+
+func NewTagHandler(repo repository.TagRepository) *TagHandler {
+	return &TagHandler{repo: repo}
+}
+
+func (h *TagHandler) RegisterRoutes(r *gin.RouterGroup) {
+	tags := r.Group("/tags")
+	{
+		tags.POST("", h.CreateTag)
+		tags.GET("", h.ListTags)
+		tags.GET("/:id", h.GetTagByID)
+		tags.PATCH("/:id", h.UpdateTag)
+		tags.DELETE("/:id", h.DeleteTag)
+	}
+}
+
+func toTagResponse(t model.Tag) TagResponse {
+	return TagResponse{
+		Data: Tag{
+			ID:        t.ID,
+			Name:      t.Name,
+			CreatedAt: model.Date{Time: t.CreatedAt},
+			UpdatedAt: model.Date{Time: t.UpdatedAt},
+		},
+	}
+}
+
+// CreateTag godoc
+// @Summary      Create a tag
+// @Description  Create a new tag. Names are stored lowercased and must be unique.
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      CreateTagRequest          true  "Tag to create"
+// @Success      201      {object}  TagResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error"
+// @Failure      409      {object}  validation.ErrorResponse  "Tag already exists"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /tags [post]
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req CreateTagRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	tag := model.Tag{Name: model.NormalizeTagName(req.Name)}
+	if tag.Name == "" {
+		writeError(c, http.StatusBadRequest,
+			"TAG_INVALID_NAME",
+			"tag name must not be blank",
+		)
+		return
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &tag); err != nil {
+		if errors.Is(err, repository.ErrTagAlreadyExists) {
+			writeError(c, http.StatusConflict,
+				"TAG_ALREADY_EXISTS",
+				"a tag with this name already exists",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"TAG_CREATE_FAILED",
+			"failed to create tag",
+		)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTagResponse(tag))
+}
+
+// ListTags godoc
+// @Summary      List tags
+// @Description  Get all tags with the number of books carrying each, most used first
+// @Tags         tags
+// @Produce      json
+// @Success      200  {object}  ListTagsResponse
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /tags [get]
+func (h *TagHandler) ListTags(c *gin.Context) {
+	tags, err := h.repo.List(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"TAG_LIST_FAILED",
+			"failed to list tags",
+		)
+		return
+	}
+
+	res := make([]TagWithCount, 0, len(tags))
+	for _, t := range tags {
+		res = append(res, TagWithCount{
+			ID:        t.ID,
+			Name:      t.Name,
+			BookCount: t.BookCount,
+		})
+	}
+
+	c.JSON(http.StatusOK, ListTagsResponse{Data: res})
+}
+
+// GetTagByID godoc
+// @Summary      Get tag by ID
+// @Description  Get a single tag by its ID
+// @Tags         tags
+// @Produce      json
+// @Param        id   path      string                    true  "Tag ID (UUID)"
+// @Success      200  {object}  TagResponse
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      404  {object}  validation.ErrorResponse  "Tag not found"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /tags/{id} [get]
+func (h *TagHandler) GetTagByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"TAG_INVALID_ID",
+			"invalid tag id",
+		)
+		return
+	}
+
+	tag, err := h.repo.FindByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"TAG_NOT_FOUND",
+				"tag not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"TAG_FETCH_FAILED",
+			"failed to fetch tag",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTagResponse(*tag))
+}
+
+// UpdateTag godoc
+// @Summary      Update a tag
+// @Description  Rename an existing tag
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                    true  "Tag ID (UUID)"
+// @Param        payload  body      UpdateTagRequest          true  "Tag fields to update"
+// @Success      200      {object}  TagResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid ID or validation error"
+// @Failure      404      {object}  validation.ErrorResponse  "Tag not found"
+// @Failure      409      {object}  validation.ErrorResponse  "Tag already exists"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /tags/{id} [patch]
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"TAG_INVALID_ID",
+			"invalid tag id",
+		)
+		return
+	}
+
+	var req UpdateTagRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tag, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"TAG_NOT_FOUND",
+				"tag not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"TAG_FETCH_FAILED",
+			"failed to fetch tag",
+		)
+		return
+	}
+
+	if req.Name != nil {
+		tag.Name = model.NormalizeTagName(*req.Name)
+		if tag.Name == "" {
+			writeError(c, http.StatusBadRequest,
+				"TAG_INVALID_NAME",
+				"tag name must not be blank",
+			)
+			return
+		}
+	}
+
+	if err := h.repo.Update(ctx, tag); err != nil {
+		if errors.Is(err, repository.ErrTagAlreadyExists) {
+			writeError(c, http.StatusConflict,
+				"TAG_ALREADY_EXISTS",
+				"a tag with this name already exists",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"TAG_UPDATE_FAILED",
+			"failed to update tag",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTagResponse(*tag))
+}
+
+// DeleteTag godoc
+// @Summary      Delete a tag
+// @Description  Delete a tag by ID and detach it from all books
+// @Tags         tags
+// @Produce      json
+// @Param        id   path      string                    true  "Tag ID (UUID)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      404  {object}  validation.ErrorResponse  "Tag not found"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /tags/{id} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"TAG_INVALID_ID",
+			"invalid tag id",
+		)
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(c, http.StatusNotFound,
+				"TAG_NOT_FOUND",
+				"tag not found",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"TAG_DELETE_FAILED",
+			"failed to delete tag",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+)
+
+func doJSON(router *gin.Engine, method, path string, body any) *httptest.ResponseRecorder {
+	var reader *bytes.Reader
+	if body != nil {
+		b, _ := json.Marshal(body)
+		reader = bytes.NewReader(b)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, _ := http.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateTag_NormalizesAndRejectsDuplicates(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewTagHandler(repository.NewTagRepository(db)))
+
+	w := doJSON(router, http.MethodPost, "/tags", map[string]any{"name": "  SciFi "})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp TagResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Data.Name != "scifi" {
+		t.Errorf("expected normalized name %q, got %q", "scifi", resp.Data.Name)
+	}
+
+	w = doJSON(router, http.MethodPost, "/tags", map[string]any{"name": "SCIFI"})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var errResp validation.ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &errResp)
+	if errResp.Code != "TAG_ALREADY_EXISTS" {
+		t.Errorf("expected error code TAG_ALREADY_EXISTS, got %q", errResp.Code)
+	}
+}
+
+func TestCreateBook_WithTags_ListedWithCounts(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewTagHandler(repository.NewTagRepository(db)))
+
+	author := testutil.SeedAuthor(t, db, "Herbert")
+
+	w := doJSON(router, http.MethodPost, "/books", map[string]any{
+		"title":     "Dune",
+		"author_id": author.ID.String(),
+		"tags":      []string{"SciFi", "classic", "scifi"},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var book BookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &book); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(book.Data.Tags) != 2 || book.Data.Tags[0].Name != "classic" || book.Data.Tags[1].Name != "scifi" {
+		t.Fatalf("expected tags [classic scifi], got %+v", book.Data.Tags)
+	}
+
+	w = doJSON(router, http.MethodGet, "/tags", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var tags ListTagsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &tags); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(tags.Data) != 2 {
+		t.Fatalf("expected 2 tags, got %+v", tags.Data)
+	}
+	for _, tag := range tags.Data {
+		if tag.BookCount != 1 {
+			t.Errorf("expected book_count=1 for %q, got %d", tag.Name, tag.BookCount)
+		}
+	}
+
+	w = doJSON(router, http.MethodPatch, "/books/"+book.Data.ID.String(), map[string]any{"tags": []string{}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &book); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(book.Data.Tags) != 0 {
+		t.Fatalf("expected tags to be cleared, got %+v", book.Data.Tags)
+	}
+}
+
+func TestListBooks_InvalidTagsMode(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewTagHandler(repository.NewTagRepository(db)))
+
+	w := doJSON(router, http.MethodGet, "/books?tags=scifi&tags_mode=some", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d, body=%s", w.Code, w.Body.String())
+	}
+}
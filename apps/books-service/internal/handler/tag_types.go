@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+)
+
+type CreateTagRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=50"`
+}
+
+type UpdateTagRequest struct {
+	Name *string `json:"name" binding:"omitempty,min=1,max=50"`
+}
+
+type Tag struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	CreatedAt model.Date `json:"created_at" swaggertype:"string" example:"2025-11-24"`
+	UpdatedAt model.Date `json:"updated_at" swaggertype:"string" example:"2025-11-24"`
+}
+
+type TagWithCount struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	BookCount int64     `json:"book_count"`
+}
+
+type TagSummary struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type TagResponse struct {
+	Data Tag `json:"data"`
+}
+
+type ListTagsResponse struct {
+	Data []TagWithCount `json:"data"`
+}
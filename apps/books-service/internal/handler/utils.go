@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"math"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/cover"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
@@ -33,6 +38,19 @@ func setupTestRouter(db *gorm.DB) *gin.Engine {
 	return setupTestRouterWithRepos(bookRepo, authorRepo)
 }
 
+// parseBookIDParam reads the :id path parameter of nested book routes.
+func parseBookIDParam(c *gin.Context) (uuid.UUID, bool) {
+	bookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_BOOK_ID",
+			"invalid book id",
+		)
+		return uuid.Nil, false
+	}
+	return bookID, true
+}
+
 func parseIntQuery(c *gin.Context, key string, def int) int {
 	if s := c.Query(key); s != "" {
 		if v, err := strconv.Atoi(s); err == nil {
@@ -42,6 +60,31 @@ func parseIntQuery(c *gin.Context, key string, def int) int {
 	return def
 }
 
+// parseListQuery splits a comma-separated query value, dropping empty items.
+func parseListQuery(c *gin.Context, key string) []string {
+	s := c.Query(key)
+	if s == "" {
+		return nil
+	}
+
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func tagsFromNames(names []string) []model.Tag {
+	tags := make([]model.Tag, 0, len(names))
+	for _, n := range names {
+		tags = append(tags, model.Tag{Name: n})
+	}
+	return tags
+}
+
 func parseDateQuery(c *gin.Context, key string) (*time.Time, error) {
 	s := c.Query(key)
 	if s == "" {
@@ -63,12 +106,17 @@ func writeError(c *gin.Context, status int, code, message string) {
 	})
 }
 
-func toBookResponse(b model.Book) BookResponse {
+func toBookResponse(b model.Book, covers *cover.Service) BookResponse {
 	var pub *model.Date
 	if b.PublishedAt != nil && !b.PublishedAt.IsZero() {
 		pub = &model.Date{Time: *b.PublishedAt}
 	}
 
+	tags := make([]TagSummary, 0, len(b.Tags))
+	for _, t := range b.Tags {
+		tags = append(tags, TagSummary{ID: t.ID, Name: t.Name})
+	}
+
 	data := Book{
 		ID:    b.ID,
 		Title: b.Title,
@@ -77,10 +125,43 @@ func toBookResponse(b model.Book) BookResponse {
 			Name: b.Author.Name,
 			Bio:  b.Author.Bio,
 		},
-		Description: b.Description,
-		PublishedAt: pub,
-		CreatedAt:   model.Date{Time: b.CreatedAt},
-		UpdatedAt:   model.Date{Time: b.UpdatedAt},
+		Description:     b.Description,
+		PublishedAt:     pub,
+		Tags:            tags,
+		Format:          b.Format,
+		Language:        b.Language,
+		Publisher:       toPublisherSummary(b.Publisher),
+		PageCount:       b.PageCount,
+		ISBN:            b.ISBN,
+		RatingAverage:   math.Round(b.RatingAverage*100) / 100,
+		RatingCount:     b.RatingCount,
+		CopyCount:       b.CopyCount,
+		AvailableCopies: b.AvailableCopies,
+		OwnerID:         b.OwnerID,
+		Visibility:      b.Visibility,
+		CreatedAt:       model.Date{Time: b.CreatedAt},
+		UpdatedAt:       model.Date{Time: b.UpdatedAt},
+	}
+
+	if b.Series != nil {
+		data.Series = &SeriesSummary{
+			ID:     b.Series.ID,
+			Name:   b.Series.Name,
+			Volume: b.SeriesVolume,
+		}
+	}
+	if b.Work != nil {
+		data.Work = &WorkSummary{
+			ID:            b.Work.ID,
+			OriginalTitle: b.Work.OriginalTitle,
+		}
+	}
+	data.PreviousVolume = toSeriesVolumeLink(b.PreviousVolume)
+	data.NextVolume = toSeriesVolumeLink(b.NextVolume)
+
+	if urls := covers.URLs(b.CoverKey); urls.Original != "" {
+		data.CoverURL = urls.Original
+		data.CoverThumbnailURLs = urls.Thumbnails
 	}
 
 	return BookResponse{
@@ -88,6 +169,24 @@ func toBookResponse(b model.Book) BookResponse {
 	}
 }
 
+func toSeriesVolumeLink(ref *model.SeriesVolumeRef) *SeriesVolumeLink {
+	if ref == nil {
+		return nil
+	}
+	return &SeriesVolumeLink{
+		ID:     ref.ID,
+		Title:  ref.Title,
+		Volume: ref.Volume,
+	}
+}
+
+func toPublisherSummary(p *model.Publisher) *PublisherSummary {
+	if p == nil {
+		return nil
+	}
+	return &PublisherSummary{ID: p.ID, Name: p.Name}
+}
+
 func toBookSummaryResponse(b model.Book) BookSummaryResponse {
 	var pub *model.Date
 	if b.PublishedAt != nil && !b.PublishedAt.IsZero() {
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+)
+
+// visibleBookTitles lists the titles the token's user finds at path, which
+// must return a list of books.
+func visibleBookTitles(t *testing.T, router *gin.Engine, token, path string) []string {
+	t.Helper()
+	return bookTitles(getJSON[ListBooksResponse](t, router, token, path).Data)
+}
+
+func TestVisibility_Books(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(
+		NewBookHandler(repository.NewGormBookRepository(db)),
+		NewAuthorHandler(repository.NewAuthorRepository(db)),
+		NewCopyHandler(repository.NewCopyRepository(db)),
+		NewGroupHandler(repository.NewGroupRepository(db)),
+	)
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+
+	author := testutil.SeedAuthor(t, db, "Ursula K. Le Guin")
+	testutil.SeedBook(t, db, author, "The Dispossessed", "", nil)
+
+	if w := doAuthJSON(router, "", http.MethodPost, "/books", map[string]any{
+		"title": "Diary", "author_id": author.ID.String(), "visibility": "private",
+	}); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401 for an anonymous private book, got %d", w.Code)
+	}
+
+	w := doAuthJSON(router, alice, http.MethodPost, "/books", map[string]any{
+		"title": "The Lathe of Heaven", "author_id": author.ID.String(), "visibility": "private",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var created BookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if created.Data.Visibility != "private" || created.Data.OwnerID == nil || *created.Data.OwnerID != "6563a1f0c2a4b5d6e7f80911" {
+		t.Fatalf("expected a private book owned by alice, got %+v", created.Data)
+	}
+	bookPath := "/books/" + created.Data.ID.String()
+
+	if got := visibleBookTitles(t, router, alice, "/books?q=lathe"); len(got) != 1 {
+		t.Fatalf("expected alice to find her own private book, got %v", got)
+	}
+	for _, token := range []string{bob, ""} {
+		if got := visibleBookTitles(t, router, token, "/books?q=lathe"); len(got) != 0 {
+			t.Fatalf("expected a private book to stay out of search, got %v", got)
+		}
+		if w := doAuthJSON(router, token, http.MethodGet, bookPath, nil); w.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404 for a private book, got %d", w.Code)
+		}
+
+		w := doAuthJSON(router, token, http.MethodGet, "/authors/"+author.ID.String(), nil)
+		var page AuthorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if len(page.Data.Books) != 1 || page.Data.Books[0].Title != "The Dispossessed" {
+			t.Fatalf("expected the author page to leave out the private book, got %+v", page.Data.Books)
+		}
+	}
+
+	if w := doAuthJSON(router, bob, http.MethodPatch, "/books/"+created.Data.ID.String(), map[string]any{"title": "Mine now"}); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 updating a book bob can't see, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, bob, http.MethodDelete, bookPath, nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 deleting a book bob can't see, got %d", w.Code)
+	}
+
+	// Groups-only books are visible to members of the owner's groups.
+	if w := doAuthJSON(router, alice, http.MethodPatch, bookPath, map[string]any{"visibility": "groups"}); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if w := doAuthJSON(router, bob, http.MethodGet, bookPath, nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 before bob shares a group with alice, got %d", w.Code)
+	}
+	group := createGroup(t, router, alice, "Readers")
+	inviteAndJoin(t, router, alice, group.ID.String(), bob)
+	if w := doAuthJSON(router, bob, http.MethodGet, bookPath, nil); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for a group member, got %d", w.Code)
+	}
+	if w := doAuthJSON(router, bob, http.MethodPatch, bookPath, map[string]any{"visibility": "public"}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 changing the visibility of alice's book, got %d", w.Code)
+	}
+	if got := visibleBookTitles(t, router, "", "/books"); len(got) != 1 {
+		t.Fatalf("expected anonymous readers to see only the catalog book, got %v", got)
+	}
+}
+
+func TestVisibility_OnlyOwnerChangesOwnedBooks(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)))
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+
+	author := testutil.SeedAuthor(t, db, "Ursula K. Le Guin")
+	catalogBook := testutil.SeedBook(t, db, author, "The Dispossessed", "", nil)
+
+	w := doAuthJSON(router, alice, http.MethodPost, "/books", map[string]any{
+		"title": "The Left Hand of Darkness", "author_id": author.ID.String(),
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+	var created BookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	bookPath := "/books/" + created.Data.ID.String()
+
+	for _, token := range []string{bob, ""} {
+		if w := doAuthJSON(router, token, http.MethodPatch, bookPath, map[string]any{"title": "Mine now"}); w.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403 updating alice's public book, got %d", w.Code)
+		}
+		if w := doAuthJSON(router, token, http.MethodDelete, bookPath, nil); w.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403 deleting alice's public book, got %d", w.Code)
+		}
+	}
+	if w := doAuthJSON(router, alice, http.MethodPatch, bookPath, map[string]any{"title": "Left Hand"}); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for the owner, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	// Books nobody owns stay open to everyone.
+	if w := doAuthJSON(router, bob, http.MethodPatch, "/books/"+catalogBook.ID.String(), map[string]any{"title": "Dispossessed"}); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for an unowned book, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if w := doAuthJSON(router, alice, http.MethodDelete, bookPath, nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204 for the owner, got %d, body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestVisibility_Copies(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(
+		NewBookHandler(repository.NewGormBookRepository(db)),
+		NewAuthorHandler(repository.NewAuthorRepository(db)),
+		NewCopyHandler(repository.NewCopyRepository(db)),
+		NewGroupHandler(repository.NewGroupRepository(db)),
+	)
+	alice := tokenFor(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := tokenFor(t, "6563a1f0c2a4b5d6e7f80922")
+	carol := tokenFor(t, "6563a1f0c2a4b5d6e7f80933")
+
+	author := testutil.SeedAuthor(t, db, "Ursula K. Le Guin")
+	book := testutil.SeedBook(t, db, author, "The Left Hand of Darkness", "", nil)
+	bookID := book.ID.String()
+
+	group := createGroup(t, router, alice, "Readers")
+	inviteAndJoin(t, router, alice, group.ID.String(), bob)
+
+	private := createCopy(t, router, alice, bookID, map[string]any{"visibility": "private"})
+	if private.Visibility != "private" {
+		t.Fatalf("expected a private copy, got %+v", private)
+	}
+	createCopy(t, router, alice, bookID, map[string]any{"visibility": "groups"})
+
+	copiesPath := "/books/" + bookID + "/copies"
+	for token, want := range map[string]int{alice: 2, bob: 1, carol: 0, "": 0} {
+		w := doAuthJSON(router, token, http.MethodGet, copiesPath, nil)
+		var resp ListCopiesResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if len(resp.Data) != want {
+			t.Fatalf("expected %d visible copies, got %d", want, len(resp.Data))
+		}
+	}
+
+	// Counts on the book only include public copies.
+	w := doAuthJSON(router, alice, http.MethodGet, "/books/"+bookID, nil)
+	var got BookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if got.Data.CopyCount != 0 {
+		t.Fatalf("expected no public copies, got %d", got.Data.CopyCount)
+	}
+
+	w = doAuthJSON(router, alice, http.MethodPatch, copiesPath+"/"+private.ID.String(), map[string]any{"visibility": "public"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+	if w := doAuthJSON(router, carol, http.MethodGet, copiesPath+"/"+private.ID.String(), nil); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 once the copy is public, got %d", w.Code)
+	}
+}
@@ -0,0 +1,451 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"gorm.io/gorm"
+)
+
+type WorkHandler struct {
+	repo repository.WorkRepository
+}
+
+func NewWorkHandler(repo repository.WorkRepository) *WorkHandler {
+	return &WorkHandler{repo: repo}
+}
+
+func (h *WorkHandler) RegisterRoutes(r *gin.RouterGroup) {
+	works := r.Group("/works")
+	{
+		works.POST("", h.CreateWork)
+		works.GET("", h.SearchWorks)
+		works.GET("/:id", h.GetWorkByID)
+		works.PATCH("/:id", h.UpdateWork)
+		works.DELETE("/:id", h.DeleteWork)
+		works.POST("/:id/editions", h.LinkEditions)
+		works.DELETE("/:id/editions/:book_id", h.UnlinkEdition)
+	}
+}
+
+func toWorkResponse(w model.Work) WorkResponse {
+	contributors := make([]Contributor, 0, len(w.Contributors))
+	for _, c := range w.Contributors {
+		contributors = append(contributors, Contributor{
+			Author: AuthorSummary{
+				ID:   c.Author.ID,
+				Name: c.Author.Name,
+				Bio:  c.Author.Bio,
+			},
+			Role: c.Role,
+		})
+	}
+
+	editions := make([]Edition, 0, len(w.Editions))
+	for _, b := range w.Editions {
+		editions = append(editions, Edition{
+			ID:    b.ID,
+			Title: b.Title,
+			Author: AuthorSummary{
+				ID:   b.Author.ID,
+				Name: b.Author.Name,
+				Bio:  b.Author.Bio,
+			},
+			Format:      b.Format,
+			Language:    b.Language,
+			Publisher:   toPublisherSummary(b.Publisher),
+			PageCount:   b.PageCount,
+			ISBN:        b.ISBN,
+			PublishedAt: toOptionalDate(b.PublishedAt),
+		})
+	}
+
+	return WorkResponse{
+		Data: Work{
+			ID:               w.ID,
+			OriginalTitle:    w.OriginalTitle,
+			FirstPublishedAt: toOptionalDate(w.FirstPublishedAt),
+			Contributors:     contributors,
+			Editions:         editions,
+			CreatedAt:        model.Date{Time: w.CreatedAt},
+			UpdatedAt:        model.Date{Time: w.UpdatedAt},
+		},
+	}
+}
+
+func toOptionalDate(t *time.Time) *model.Date {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return &model.Date{Time: *t}
+}
+
+func toContributors(in []ContributorInput) []model.WorkContributor {
+	out := make([]model.WorkContributor, 0, len(in))
+	for _, c := range in {
+		out = append(out, model.WorkContributor{
+			AuthorID: c.AuthorID,
+			Role:     c.Role,
+		})
+	}
+	return out
+}
+
+// writeWorkError maps the repository errors shared by the work endpoints.
+func writeWorkError(c *gin.Context, err error, code, message string) {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		writeError(c, http.StatusNotFound,
+			"WORK_NOT_FOUND",
+			"work not found",
+		)
+	case errors.Is(err, repository.ErrBookNotFound):
+		writeError(c, http.StatusBadRequest,
+			"BOOK_NOT_FOUND",
+			"one or more books do not exist",
+		)
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		writeError(c, http.StatusBadRequest,
+			"AUTHOR_NOT_FOUND",
+			"contributor author does not exist",
+		)
+	default:
+		writeError(c, http.StatusInternalServerError, code, message)
+	}
+}
+
+// CreateWork godoc
+// @Summary      Create a work
+// @Description  Create a work with optional contributors, optionally linking existing books as its editions
+// @Tags         works
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      CreateWorkRequest         true  "Work to create"
+// @Success      201      {object}  WorkResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Validation error or unknown book/author"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /works [post]
+func (h *WorkHandler) CreateWork(c *gin.Context) {
+	var req CreateWorkRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	var firstPub *time.Time
+	if req.FirstPublishedAt != nil && !req.FirstPublishedAt.Time.IsZero() {
+		t := req.FirstPublishedAt.Time
+		firstPub = &t
+	}
+
+	work := model.Work{
+		OriginalTitle:    req.OriginalTitle,
+		FirstPublishedAt: firstPub,
+		Contributors:     toContributors(req.Contributors),
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.repo.Create(ctx, &work, req.BookIDs); err != nil {
+		writeWorkError(c, err, "WORK_CREATE_FAILED", "failed to create work")
+		return
+	}
+
+	created, err := h.repo.FindByID(ctx, work.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"WORK_FETCH_FAILED",
+			"failed to fetch created work",
+		)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toWorkResponse(*created))
+}
+
+// SearchWorks godoc
+// @Summary      Search works
+// @Description  Search works by original title or by the title or ISBN of any edition. Matching editions are grouped under their work.
+// @Tags         works
+// @Produce      json
+// @Param        q          query     string  false  "Title or ISBN to search for"
+// @Param        page       query     int     false  "Page number"      default(1) minimum(1)
+// @Param        page_size  query     int     false  "Items per page"   default(20) minimum(1) maximum(100)
+// @Success      200  {object}  ListWorksResponse
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /works [get]
+func (h *WorkHandler) SearchWorks(c *gin.Context) {
+	page := parseIntQuery(c, "page", 1)
+	pageSize := parseIntQuery(c, "page_size", 20)
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	params := repository.WorkSearchParams{
+		Page:     page,
+		PageSize: pageSize,
+		Query:    c.Query("q"),
+	}
+
+	result, err := h.repo.Search(c.Request.Context(), params)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"WORK_SEARCH_FAILED",
+			"failed to search works",
+		)
+		return
+	}
+
+	works := make([]Work, 0, len(result.Works))
+	for _, w := range result.Works {
+		works = append(works, toWorkResponse(w).Data)
+	}
+
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 20
+	}
+	totalPages := int((result.Total + int64(params.PageSize) - 1) / int64(params.PageSize))
+
+	c.JSON(http.StatusOK, ListWorksResponse{
+		Data: works,
+		Pagination: Pagination{
+			Page:       params.Page,
+			PageSize:   params.PageSize,
+			Total:      result.Total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// GetWorkByID godoc
+// @Summary      Get work by ID
+// @Description  Get a work with its contributors and all of its editions
+// @Tags         works
+// @Produce      json
+// @Param        id   path      string                    true  "Work ID (UUID)"
+// @Success      200  {object}  WorkResponse
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      404  {object}  validation.ErrorResponse  "Work not found"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /works/{id} [get]
+func (h *WorkHandler) GetWorkByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"WORK_INVALID_ID",
+			"invalid work id",
+		)
+		return
+	}
+
+	work, err := h.repo.FindByID(c.Request.Context(), id)
+	if err != nil {
+		writeWorkError(c, err, "WORK_FETCH_FAILED", "failed to fetch work")
+		return
+	}
+
+	c.JSON(http.StatusOK, toWorkResponse(*work))
+}
+
+// UpdateWork godoc
+// @Summary      Update a work
+// @Description  Partially update a work. Contributors, when given, replace the existing list.
+// @Tags         works
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                    true  "Work ID (UUID)"
+// @Param        payload  body      UpdateWorkRequest         true  "Work fields to update"
+// @Success      200      {object}  WorkResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid ID or validation error"
+// @Failure      404      {object}  validation.ErrorResponse  "Work not found"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /works/{id} [patch]
+func (h *WorkHandler) UpdateWork(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"WORK_INVALID_ID",
+			"invalid work id",
+		)
+		return
+	}
+
+	var req UpdateWorkRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	work, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		writeWorkError(c, err, "WORK_FETCH_FAILED", "failed to fetch work")
+		return
+	}
+
+	if req.OriginalTitle != nil {
+		work.OriginalTitle = *req.OriginalTitle
+	}
+	if req.FirstPublishedAt != nil {
+		if req.FirstPublishedAt.Time.IsZero() {
+			work.FirstPublishedAt = nil
+		} else {
+			t := req.FirstPublishedAt.Time
+			work.FirstPublishedAt = &t
+		}
+	}
+
+	work.Contributors = nil
+	if req.Contributors != nil {
+		work.Contributors = toContributors(req.Contributors)
+	}
+
+	if err := h.repo.Update(ctx, work); err != nil {
+		writeWorkError(c, err, "WORK_UPDATE_FAILED", "failed to update work")
+		return
+	}
+
+	updated, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError,
+			"WORK_FETCH_FAILED",
+			"failed to fetch updated work",
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWorkResponse(*updated))
+}
+
+// DeleteWork godoc
+// @Summary      Delete a work
+// @Description  Delete a work. Its editions are kept as standalone books.
+// @Tags         works
+// @Produce      json
+// @Param        id   path      string                    true  "Work ID (UUID)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      404  {object}  validation.ErrorResponse  "Work not found"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /works/{id} [delete]
+func (h *WorkHandler) DeleteWork(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"WORK_INVALID_ID",
+			"invalid work id",
+		)
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
+		writeWorkError(c, err, "WORK_DELETE_FAILED", "failed to delete work")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LinkEditions godoc
+// @Summary      Link books to a work
+// @Description  Link existing books to a work as its editions. Books already linked elsewhere are moved.
+// @Tags         works
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                    true  "Work ID (UUID)"
+// @Param        payload  body      LinkEditionsRequest       true  "Books to link"
+// @Success      200      {object}  WorkResponse
+// @Failure      400      {object}  validation.ErrorResponse  "Invalid ID, validation error or unknown book"
+// @Failure      404      {object}  validation.ErrorResponse  "Work not found"
+// @Failure      500      {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /works/{id}/editions [post]
+func (h *WorkHandler) LinkEditions(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"WORK_INVALID_ID",
+			"invalid work id",
+		)
+		return
+	}
+
+	var req LinkEditionsRequest
+	if !validation.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.repo.LinkEditions(ctx, id, req.BookIDs); err != nil {
+		writeWorkError(c, err, "WORK_LINK_FAILED", "failed to link editions")
+		return
+	}
+
+	work, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		writeWorkError(c, err, "WORK_FETCH_FAILED", "failed to fetch work")
+		return
+	}
+
+	c.JSON(http.StatusOK, toWorkResponse(*work))
+}
+
+// UnlinkEdition godoc
+// @Summary      Unlink a book from a work
+// @Description  Remove a book from a work's editions without deleting the book
+// @Tags         works
+// @Produce      json
+// @Param        id       path      string                    true  "Work ID (UUID)"
+// @Param        book_id  path      string                    true  "Book ID (UUID)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  validation.ErrorResponse  "Invalid ID"
+// @Failure      404  {object}  validation.ErrorResponse  "Edition not found"
+// @Failure      500  {object}  validation.ErrorResponse  "Internal server error"
+// @Router       /works/{id}/editions/{book_id} [delete]
+func (h *WorkHandler) UnlinkEdition(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"WORK_INVALID_ID",
+			"invalid work id",
+		)
+		return
+	}
+
+	bookID, err := uuid.Parse(c.Param("book_id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest,
+			"INVALID_BOOK_ID",
+			"invalid book id",
+		)
+		return
+	}
+
+	if err := h.repo.UnlinkEdition(c.Request.Context(), id, bookID); err != nil {
+		if errors.Is(err, repository.ErrBookNotFound) {
+			writeError(c, http.StatusNotFound,
+				"EDITION_NOT_FOUND",
+				"book is not an edition of this work",
+			)
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError,
+			"WORK_UNLINK_FAILED",
+			"failed to unlink edition",
+		)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
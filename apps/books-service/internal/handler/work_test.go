@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+)
+
+func createEdition(t *testing.T, router *gin.Engine, payload map[string]any) uuid.UUID {
+	t.Helper()
+
+	w := doJSON(router, http.MethodPost, "/books", payload)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp BookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return resp.Data.ID
+}
+
+func TestWorks_LinkEditionsAndSearchGroupsThem(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewWorkHandler(repository.NewWorkRepository(db)))
+
+	author := testutil.SeedAuthor(t, db, "Gabriel García Márquez")
+	translator := testutil.SeedAuthor(t, db, "Gregory Rabassa")
+
+	hardcover := createEdition(t, router, map[string]any{
+		"title":     "Cien años de soledad",
+		"author_id": author.ID.String(),
+		"format":    "hardcover",
+		"language":  "es",
+	})
+	paperback := createEdition(t, router, map[string]any{
+		"title":     "One Hundred Years of Solitude",
+		"author_id": author.ID.String(),
+		"format":    "paperback",
+		"language":  "en",
+		"isbn":      "978-0-06-088328-7",
+	})
+	createEdition(t, router, map[string]any{
+		"title":     "Love in the Time of Cholera",
+		"author_id": author.ID.String(),
+	})
+
+	w := doJSON(router, http.MethodPost, "/works", map[string]any{
+		"original_title":     "Cien años de soledad",
+		"first_published_at": "1967-05-30",
+		"contributors": []map[string]any{
+			{"author_id": author.ID.String(), "role": "author"},
+			{"author_id": translator.ID.String(), "role": "translator"},
+		},
+		"book_ids": []string{hardcover.String()},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var work WorkResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &work); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(work.Data.Contributors) != 2 || len(work.Data.Editions) != 1 {
+		t.Fatalf("expected 2 contributors and 1 edition, got %+v", work.Data)
+	}
+
+	w = doJSON(router, http.MethodPost, "/works/"+work.Data.ID.String()+"/editions", map[string]any{
+		"book_ids": []string{paperback.String()},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	w = doJSON(router, http.MethodGet, "/works?q=9780060883287", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var list ListWorksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if list.Pagination.Total != 1 || len(list.Data) != 1 {
+		t.Fatalf("expected exactly one work, got %+v", list)
+	}
+	if len(list.Data[0].Editions) != 2 {
+		t.Fatalf("expected both editions grouped under the work, got %+v", list.Data[0].Editions)
+	}
+
+	w = doJSON(router, http.MethodGet, "/books/"+paperback.String(), nil)
+	var book BookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &book); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if book.Data.Work == nil || book.Data.Work.ID != work.Data.ID {
+		t.Errorf("expected book to reference its work, got %+v", book.Data.Work)
+	}
+	if book.Data.ISBN != "9780060883287" {
+		t.Errorf("expected normalized isbn, got %q", book.Data.ISBN)
+	}
+}
+
+func TestWorks_LinkUnknownBook(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewWorkHandler(repository.NewWorkRepository(db)))
+
+	w := doJSON(router, http.MethodPost, "/works", map[string]any{"original_title": "Solaris"})
+	var work WorkResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &work); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	w = doJSON(router, http.MethodPost, "/works/"+work.Data.ID.String()+"/editions", map[string]any{
+		"book_ids": []string{uuid.NewString()},
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d, body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreateBook_InvalidISBN(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	router := newTestRouter(NewBookHandler(repository.NewGormBookRepository(db)), NewWorkHandler(repository.NewWorkRepository(db)))
+
+	author := testutil.SeedAuthor(t, db, "Lem")
+
+	w := doJSON(router, http.MethodPost, "/books", map[string]any{
+		"title":     "Solaris",
+		"author_id": author.ID.String(),
+		"isbn":      "978-0-15-602760-2",
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d, body=%s", w.Code, w.Body.String())
+	}
+
+	var resp validation.ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.Code != "INVALID_ISBN" {
+		t.Errorf("expected error code INVALID_ISBN, got %q", resp.Code)
+	}
+}
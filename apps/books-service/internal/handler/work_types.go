@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+)
+
+type ContributorInput struct {
+	AuthorID uuid.UUID `json:"author_id" binding:"required"`
+	Role     string    `json:"role" binding:"required,oneof=author translator editor illustrator"`
+}
+
+type CreateWorkRequest struct {
+	OriginalTitle    string             `json:"original_title" binding:"required,min=1,max=500"`
+	FirstPublishedAt *model.Date        `json:"first_published_at" swaggertype:"string" example:"1965-08-01"`
+	Contributors     []ContributorInput `json:"contributors" binding:"omitempty,dive"`
+	BookIDs          []uuid.UUID        `json:"book_ids"`
+}
+
+type UpdateWorkRequest struct {
+	OriginalTitle    *string            `json:"original_title" binding:"omitempty,min=1,max=500"`
+	FirstPublishedAt *model.Date        `json:"first_published_at" swaggertype:"string" example:"1965-08-01"`
+	Contributors     []ContributorInput `json:"contributors" binding:"omitempty,dive"`
+}
+
+type LinkEditionsRequest struct {
+	BookIDs []uuid.UUID `json:"book_ids" binding:"required,min=1"`
+}
+
+type Contributor struct {
+	Author AuthorSummary `json:"author"`
+	Role   string        `json:"role" example:"translator"`
+}
+
+type Edition struct {
+	ID          uuid.UUID         `json:"id"`
+	Title       string            `json:"title"`
+	Author      AuthorSummary     `json:"author"`
+	Format      string            `json:"format,omitempty" example:"paperback"`
+	Language    string            `json:"language,omitempty" example:"en"`
+	Publisher   *PublisherSummary `json:"publisher,omitempty"`
+	PageCount   *int              `json:"page_count,omitempty"`
+	ISBN        string            `json:"isbn,omitempty" example:"9780441172719"`
+	PublishedAt *model.Date       `json:"published_at,omitempty" swaggertype:"string" example:"2025-11-24"`
+}
+
+type Work struct {
+	ID               uuid.UUID     `json:"id"`
+	OriginalTitle    string        `json:"original_title"`
+	FirstPublishedAt *model.Date   `json:"first_published_at,omitempty" swaggertype:"string" example:"1965-08-01"`
+	Contributors     []Contributor `json:"contributors"`
+	Editions         []Edition     `json:"editions"`
+	CreatedAt        model.Date    `json:"created_at" swaggertype:"string" example:"2025-11-24"`
+	UpdatedAt        model.Date    `json:"updated_at" swaggertype:"string" example:"2025-11-24"`
+}
+
+type WorkSummary struct {
+	ID            uuid.UUID `json:"id"`
+	OriginalTitle string    `json:"original_title"`
+}
+
+type WorkResponse struct {
+	Data Work `json:"data"`
+}
+
+type ListWorksResponse struct {
+	Data       []Work     `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
@@ -52,7 +52,11 @@ func TestMain(m *testing.M) {
 	}
 	testDB = db
 
-	if err := db.AutoMigrate(&model.Author{}, &model.Book{}); err != nil {
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		panic("failed to enable pg_trgm: " + err.Error())
+	}
+
+	if err := db.AutoMigrate(&model.Author{}, &model.Series{}, &model.Work{}, &model.WorkContributor{}, &model.Publisher{}, &model.Book{}, &model.Tag{}, &model.Shelf{}, &model.ShelfEntry{}, &model.Review{}, &model.ProgressEntry{}, &model.Copy{}, &model.Hold{}, &model.Notification{}, &model.NotificationPreference{}, &model.Group{}, &model.GroupMember{}, &model.GroupInvite{}, &model.CopyGroup{}, &model.Follow{}, &model.AccountSettings{}, &model.Block{}, &model.BookSimilarity{}, &model.Activity{}); err != nil {
 		panic("failed to migrate: " + err.Error())
 	}
 
@@ -83,7 +87,7 @@ func resetDB(t *testing.T) {
 	if err != nil {
 		t.Fatalf("get sql.DB failed: %v", err)
 	}
-	_, err = sqlDB.Exec("TRUNCATE TABLE books, authors RESTART IDENTITY CASCADE;")
+	_, err = sqlDB.Exec("TRUNCATE TABLE activities, book_similarities, blocks, account_settings, follows, copy_groups, group_invites, group_members, groups, notification_preferences, notifications, holds, copies, progress_entries, reviews, shelf_entries, shelves, book_tags, tags, books, work_contributors, works, series, publishers, authors RESTART IDENTITY CASCADE;")
 	if err != nil {
 		t.Fatalf("truncate failed: %v", err)
 	}
@@ -604,3 +608,55 @@ func TestGetAuthorWithBooks_Integration(t *testing.T) {
 		}
 	}
 }
+
+func TestListBooks_FuzzySearch_Integration(t *testing.T) {
+	resetDB(t)
+
+	srv := newTestServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	authorID := createTestAuthor(t, client, srv.URL, "Fyodor Dostoevsky", "")
+	createTestBook(t, client, srv.URL, authorID, "Crime and Punishment", "")
+	createTestBook(t, client, srv.URL, authorID, "The Brothers Karamazov", "")
+
+	listTitles := func(query string) ([]string, []string) {
+		t.Helper()
+
+		resp, err := client.Get(srv.URL + "/api/books?" + query)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+
+		var body struct {
+			Data []struct {
+				Title string `json:"title"`
+			} `json:"data"`
+			DidYouMean []string `json:"did_you_mean"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+
+		titles := make([]string, 0, len(body.Data))
+		for _, b := range body.Data {
+			titles = append(titles, b.Title)
+		}
+		return titles, body.DidYouMean
+	}
+
+	if titles, _ := listTitles("q=Dostoyevsky&fuzzy=true"); len(titles) != 2 {
+		t.Fatalf("expected both books by the author's name, got %v", titles)
+	}
+	if titles, _ := listTitles("q=brothers+karamasov&fuzzy=true"); len(titles) == 0 || titles[0] != "The Brothers Karamazov" {
+		t.Fatalf("expected the misspelled title ranked first, got %v", titles)
+	}
+	if titles, suggestions := listTitles("q=Dostoyevsky"); len(titles) != 0 || len(suggestions) == 0 {
+		t.Fatalf("expected no substring matches and a suggestion, got %v and %v", titles, suggestions)
+	}
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	ActivityBookAdded       = "book-added"
+	ActivityFinishedReading = "finished-reading"
+	ActivityReviewWritten   = "review-written"
+	ActivityCopyLent        = "copy-lent"
+)
+
+// Activity records something a user did, for their followers' feeds. It is
+// written in the same transaction as the action itself.
+type Activity struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	UserID    string     `gorm:"size:64;not null;index:idx_activities_user_created,priority:1"`
+	Type      string     `gorm:"size:40;not null"`
+	BookID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Book      Book       `gorm:"foreignKey:BookID"`
+	ReviewID  *uuid.UUID `gorm:"type:uuid;index"`
+	Review    *Review    `gorm:"foreignKey:ReviewID"`
+	CopyID    *uuid.UUID `gorm:"type:uuid;index"`
+	CreatedAt time.Time  `gorm:"index:idx_activities_user_created,priority:2"`
+}
+
+func (a *Activity) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
@@ -14,13 +14,46 @@ type Book struct {
 	Author      Author    `gorm:"foreignKey:AuthorID"`
 	Description string
 	PublishedAt *time.Time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	CoverKey    string
+	Tags        []Tag      `gorm:"many2many:book_tags"`
+	SeriesID    *uuid.UUID `gorm:"type:uuid;index"`
+	Series      *Series    `gorm:"foreignKey:SeriesID"`
+	// SeriesVolume allows fractional volumes such as 2.5 for novellas.
+	SeriesVolume *float64
+	// PreviousVolume and NextVolume are filled in by the repository on reads.
+	PreviousVolume *SeriesVolumeRef `gorm:"-"`
+	NextVolume     *SeriesVolumeRef `gorm:"-"`
+	// A book is one edition of a work; the fields below describe the edition.
+	WorkID      *uuid.UUID `gorm:"type:uuid;index"`
+	Work        *Work      `gorm:"foreignKey:WorkID"`
+	Format      string
+	Language    string
+	PublisherID *uuid.UUID `gorm:"type:uuid;index"`
+	Publisher   *Publisher `gorm:"foreignKey:PublisherID"`
+	PageCount   *int
+	ISBN        string `gorm:"column:isbn;index"`
+	// Rating aggregates are maintained by the review repository as reviews
+	// change, so listing books never has to scan reviews.
+	RatingCount   int64   `gorm:"not null;default:0"`
+	RatingSum     int64   `gorm:"not null;default:0"`
+	RatingAverage float64 `gorm:"not null;default:0;index"`
+	// OwnerID is the member who added the book; catalog books have none
+	// and are always public.
+	OwnerID    *string `gorm:"size:64;index"`
+	Visibility string  `gorm:"size:20;not null;default:public"`
+	// CopyCount and AvailableCopies are filled in by the repository on reads.
+	CopyCount       int64 `gorm:"-"`
+	AvailableCopies int64 `gorm:"-"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 func (b *Book) BeforeCreate(tx *gorm.DB) (err error) {
 	if b.ID == uuid.Nil {
 		b.ID = uuid.New()
 	}
+	if b.Visibility == "" {
+		b.Visibility = VisibilityPublic
+	}
 	return
 }
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	CopyStatusAvailable = "available"
+	CopyStatusOnLoan    = "on-loan"
+	CopyStatusLost      = "lost"
+	CopyStatusWithdrawn = "withdrawn"
+	// CopyStatusReserved marks a copy set aside for the member at the head
+	// of the book's hold queue. Only the hold repository sets it.
+	CopyStatusReserved = "reserved"
+)
+
+const (
+	CopyConditionNew     = "new"
+	CopyConditionLikeNew = "like-new"
+	CopyConditionGood    = "good"
+	CopyConditionFair    = "fair"
+	CopyConditionPoor    = "poor"
+)
+
+// Copy is a physical copy of a catalog book owned by one member.
+type Copy struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	BookID     uuid.UUID `gorm:"type:uuid;not null;index:idx_copies_book_status,priority:1"`
+	Book       Book      `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE"`
+	OwnerID    string    `gorm:"size:64;not null;index"`
+	Condition  string    `gorm:"size:20;not null;default:good"`
+	AcquiredAt *time.Time
+	Notes      string
+	Status     string `gorm:"size:20;not null;default:available;index:idx_copies_book_status,priority:2"`
+	Visibility string `gorm:"size:20;not null;default:public"`
+
+	// BorrowerID and DueAt describe the loan of a copy that is on loan;
+	// both are optional and cleared when it comes back. DueAt is the day
+	// the copy is due back. ReminderSentAt and OverdueAt record when the
+	// borrower was reminded and when the loan was found overdue, so each
+	// happens once per due date.
+	BorrowerID     *string    `gorm:"size:64;index"`
+	DueAt          *time.Time `gorm:"index"`
+	ReminderSentAt *time.Time
+	OverdueAt      *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// GroupIDs are the groups a copy with groups visibility is shared
+	// with, filled in by the repository.
+	GroupIDs []uuid.UUID `gorm:"-"`
+}
+
+func (c *Copy) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	if c.Status == "" {
+		c.Status = CopyStatusAvailable
+	}
+	if c.Condition == "" {
+		c.Condition = CopyConditionGood
+	}
+	if c.Visibility == "" {
+		c.Visibility = VisibilityPublic
+	}
+	return
+}
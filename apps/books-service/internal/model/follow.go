@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+const (
+	// FollowStatusPending is a request to follow a private account that
+	// its owner hasn't approved yet.
+	FollowStatusPending  = "pending"
+	FollowStatusAccepted = "accepted"
+)
+
+// Follow is one user following another.
+type Follow struct {
+	FollowerID string `gorm:"size:64;primaryKey"`
+	FolloweeID string `gorm:"size:64;primaryKey;index:idx_follows_followee_status,priority:1"`
+	Status     string `gorm:"size:20;not null;default:accepted;index:idx_follows_followee_status,priority:2"`
+	CreatedAt  time.Time
+	AcceptedAt *time.Time
+}
+
+// AccountSettings holds a user's social settings. Users without a row
+// have the defaults.
+type AccountSettings struct {
+	UserID string `gorm:"size:64;primaryKey"`
+	// Private accounts approve their followers, and only they and their
+	// followers see who they follow and are followed by.
+	Private   bool `gorm:"not null;default:false"`
+	UpdatedAt time.Time
+}
+
+// Block stops BlockedID from following BlockerID and hides BlockerID's
+// books, copies and reviews from them.
+type Block struct {
+	BlockerID string `gorm:"size:64;primaryKey"`
+	BlockedID string `gorm:"size:64;primaryKey;index"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	GroupRoleOwner  = "owner"
+	GroupRoleAdmin  = "admin"
+	GroupRoleMember = "member"
+)
+
+// GroupRoleRank orders roles by the permissions they carry.
+func GroupRoleRank(role string) int {
+	switch role {
+	case GroupRoleOwner:
+		return 3
+	case GroupRoleAdmin:
+		return 2
+	case GroupRoleMember:
+		return 1
+	}
+	return 0
+}
+
+// Group is a club, office or circle of friends whose members share a
+// library: every copy a member owns is in it, as are copies shared only
+// with the group.
+type Group struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name        string    `gorm:"size:100;not null"`
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (g *Group) BeforeCreate(tx *gorm.DB) (err error) {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return
+}
+
+// GroupMember is a user's membership of a group. Each group has exactly
+// one owner.
+type GroupMember struct {
+	GroupID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Group    Group     `gorm:"foreignKey:GroupID;constraint:OnDelete:CASCADE"`
+	UserID   string    `gorm:"size:64;primaryKey;index"`
+	Role     string    `gorm:"size:20;not null;default:member"`
+	JoinedAt time.Time `gorm:"not null"`
+}
+
+// GroupInvite lets whoever holds the token join the group until it
+// expires or is revoked.
+type GroupInvite struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	GroupID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	Group     Group     `gorm:"foreignKey:GroupID;constraint:OnDelete:CASCADE"`
+	Token     string    `gorm:"size:64;not null;uniqueIndex"`
+	CreatedBy string    `gorm:"size:64;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	Uses      int       `gorm:"not null;default:0"`
+	CreatedAt time.Time
+}
+
+func (i *GroupInvite) BeforeCreate(tx *gorm.DB) (err error) {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return
+}
+
+// CopyGroup restricts a copy to a group. A copy with no rows is visible to
+// everyone; one with rows only to its owner and those groups' members.
+type CopyGroup struct {
+	CopyID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Copy    Copy      `gorm:"foreignKey:CopyID;constraint:OnDelete:CASCADE"`
+	GroupID uuid.UUID `gorm:"type:uuid;primaryKey;index"`
+	Group   Group     `gorm:"foreignKey:GroupID;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	HoldStatusWaiting   = "waiting"
+	HoldStatusOffered   = "offered"
+	HoldStatusFulfilled = "fulfilled"
+	HoldStatusCancelled = "cancelled"
+	HoldStatusExpired   = "expired"
+)
+
+// Hold is a user's place in the waitlist for a book. Waiting holds are
+// served first come, first served; the head of the queue is offered the
+// next copy that becomes available and has until OfferExpiresAt to take it.
+type Hold struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	BookID         uuid.UUID  `gorm:"type:uuid;not null;index:idx_holds_book_status,priority:1"`
+	Book           Book       `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE"`
+	UserID         string     `gorm:"size:64;not null;index"`
+	Status         string     `gorm:"size:20;not null;default:waiting;index:idx_holds_book_status,priority:2"`
+	CopyID         *uuid.UUID `gorm:"type:uuid"`
+	OfferedAt      *time.Time
+	OfferExpiresAt *time.Time `gorm:"index"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Active reports whether the hold still occupies a place in the queue.
+func (h Hold) Active() bool {
+	return h.Status == HoldStatusWaiting || h.Status == HoldStatusOffered
+}
+
+func (h *Hold) BeforeCreate(tx *gorm.DB) (err error) {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	if h.Status == "" {
+		h.Status = HoldStatusWaiting
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidISBN = errors.New("invalid ISBN")
+
+// NormalizeISBN strips hyphens and spaces and validates the ISBN-10 or
+// ISBN-13 check digit. ISBN-10s are returned as-is, not converted.
+func NormalizeISBN(s string) (string, error) {
+	var b strings.Builder
+	for _, r := range s {
+		if r == '-' || r == ' ' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	isbn := strings.ToUpper(b.String())
+
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i, r := range isbn {
+			var d int
+			switch {
+			case r >= '0' && r <= '9':
+				d = int(r - '0')
+			case r == 'X' && i == 9:
+				d = 10
+			default:
+				return "", ErrInvalidISBN
+			}
+			sum += d * (10 - i)
+		}
+		if sum%11 != 0 {
+			return "", ErrInvalidISBN
+		}
+	case 13:
+		sum := 0
+		for i, r := range isbn {
+			if r < '0' || r > '9' {
+				return "", ErrInvalidISBN
+			}
+			d := int(r - '0')
+			if i%2 == 1 {
+				d *= 3
+			}
+			sum += d
+		}
+		if sum%10 != 0 {
+			return "", ErrInvalidISBN
+		}
+	default:
+		return "", ErrInvalidISBN
+	}
+
+	return isbn, nil
+}
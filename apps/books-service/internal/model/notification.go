@@ -0,0 +1,69 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// NotificationHoldAvailable tells a member a copy is being held for
+	// them at the head of a book's hold queue.
+	NotificationHoldAvailable = "hold-available"
+	// NotificationHoldExpired tells a member their hold offer lapsed before
+	// they took it up.
+	NotificationHoldExpired = "hold-expired"
+	// NotificationNewFollower tells a user someone started following them.
+	NotificationNewFollower = "new-follower"
+	// NotificationFollowRequest asks the owner of a private account to
+	// approve a follower.
+	NotificationFollowRequest = "follow-request"
+	// NotificationLoanDueSoon reminds a borrower that a copy is due back
+	// soon.
+	NotificationLoanDueSoon = "loan-due-soon"
+	// NotificationLoanOverdue tells a copy's owner and borrower that it
+	// wasn't returned by its due date.
+	NotificationLoanOverdue = "loan-overdue"
+)
+
+// NotificationTypes lists every notification type, in the order the
+// preferences are presented.
+var NotificationTypes = []string{
+	NotificationHoldAvailable,
+	NotificationHoldExpired,
+	NotificationNewFollower,
+	NotificationFollowRequest,
+	NotificationLoanDueSoon,
+	NotificationLoanOverdue,
+}
+
+// Notification is an entry in a user's in-app inbox.
+type Notification struct {
+	ID     uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	UserID string     `gorm:"size:64;not null;index:idx_notifications_user_created,priority:1"`
+	Type   string     `gorm:"size:40;not null"`
+	Title  string     `gorm:"size:200;not null"`
+	Body   string     `gorm:"not null"`
+	BookID *uuid.UUID `gorm:"type:uuid"`
+	ReadAt *time.Time
+	// CreatedAt and ID together order the inbox and make up its cursor.
+	CreatedAt time.Time `gorm:"index:idx_notifications_user_created,priority:2"`
+	UpdatedAt time.Time
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) (err error) {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return
+}
+
+// NotificationPreference records whether a user wants notifications of a
+// type. Types without a row are enabled.
+type NotificationPreference struct {
+	UserID    string `gorm:"size:64;primaryKey"`
+	Type      string `gorm:"size:40;primaryKey"`
+	Enabled   bool   `gorm:"not null"`
+	UpdatedAt time.Time
+}
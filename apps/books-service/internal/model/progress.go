@@ -0,0 +1,82 @@
+package model
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProgressEntry is one reading-progress update a user logged for a book,
+// optionally describing the reading session that got them there.
+type ProgressEntry struct {
+	ID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID string    `gorm:"size:64;not null;index:idx_progress_entries_user_book,priority:1"`
+	BookID uuid.UUID `gorm:"type:uuid;not null;index:idx_progress_entries_user_book,priority:2"`
+	Book   Book      `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE"`
+	// Page and Percent describe the same position; when the book's page
+	// count is known the repository fills in whichever one was left out.
+	Page         *int
+	Percent      *float64
+	SessionStart *time.Time
+	SessionEnd   *time.Time
+	Note         string
+	RecordedAt   time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ReadingPace summarises progress entries, oldest first.
+type ReadingPace struct {
+	CurrentPage     *int
+	Percent         *float64
+	PagesPerDay     *float64
+	EstimatedFinish *time.Time
+}
+
+// CalculatePace works out how fast a book is being read. Pace is the pages
+// read since startedAt spread over the days elapsed until the latest entry,
+// counting at least one day so a first evening's reading isn't extrapolated.
+func CalculatePace(entries []ProgressEntry, startedAt time.Time, pageCount *int) ReadingPace {
+	var pace ReadingPace
+	if len(entries) == 0 {
+		return pace
+	}
+
+	latest := entries[len(entries)-1]
+	pace.CurrentPage = latest.Page
+	pace.Percent = latest.Percent
+
+	if latest.Page == nil {
+		return pace
+	}
+
+	days := latest.RecordedAt.Sub(startedAt).Hours() / 24
+	if days < 1 {
+		days = 1
+	}
+	perDay := math.Round(float64(*latest.Page)/days*10) / 10
+	if perDay <= 0 {
+		return pace
+	}
+	pace.PagesPerDay = &perDay
+
+	if pageCount != nil && *pageCount > *latest.Page {
+		remaining := float64(*pageCount-*latest.Page) / perDay
+		finish := latest.RecordedAt.Add(time.Duration(math.Ceil(remaining*24)) * time.Hour)
+		pace.EstimatedFinish = &finish
+	} else if pageCount != nil {
+		finish := latest.RecordedAt
+		pace.EstimatedFinish = &finish
+	}
+
+	return pace
+}
+
+func (p *ProgressEntry) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Publisher struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name        string    `gorm:"not null;uniqueIndex"`
+	Website     string
+	Description string
+	Books       []Book `json:"books,omitempty" gorm:"foreignKey:PublisherID"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (p *Publisher) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Review is one user's rating of a book. A user reviews a book at most once.
+type Review struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	BookID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reviews_book_user,priority:1"`
+	Book      Book      `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE"`
+	UserID    string    `gorm:"size:64;not null;index;uniqueIndex:idx_reviews_book_user,priority:2"`
+	Rating    int       `gorm:"not null;check:chk_reviews_rating,rating BETWEEN 1 AND 5"`
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (r *Review) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
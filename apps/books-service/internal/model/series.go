@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Series struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name        string    `gorm:"not null;index"`
+	Description string
+	Books       []Book `gorm:"foreignKey:SeriesID"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// SeriesVolumeRef points at a neighbouring volume of a book within its series.
+type SeriesVolumeRef struct {
+	ID     uuid.UUID
+	Title  string
+	Volume float64
+}
+
+func (s *Series) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
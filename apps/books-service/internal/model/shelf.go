@@ -0,0 +1,77 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Built-in shelf slugs. Every user has these three; a book sits on at most
+// one of them at a time.
+const (
+	ShelfWantToRead       = "want-to-read"
+	ShelfCurrentlyReading = "currently-reading"
+	ShelfRead             = "read"
+)
+
+type BuiltinShelf struct {
+	Slug string
+	Name string
+}
+
+var BuiltinShelves = []BuiltinShelf{
+	{Slug: ShelfWantToRead, Name: "Want to Read"},
+	{Slug: ShelfCurrentlyReading, Name: "Currently Reading"},
+	{Slug: ShelfRead, Name: "Read"},
+}
+
+// Shelf belongs to a single user, identified by the auth-service user id.
+type Shelf struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	UserID    string       `gorm:"size:64;not null;uniqueIndex:idx_shelves_user_slug,priority:1"`
+	Slug      string       `gorm:"size:120;not null;uniqueIndex:idx_shelves_user_slug,priority:2"`
+	Name      string       `gorm:"size:100;not null"`
+	Builtin   bool         `gorm:"not null;default:false"`
+	Entries   []ShelfEntry `gorm:"foreignKey:ShelfID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ShelfEntry places a book on a shelf along with the reading dates.
+type ShelfEntry struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ShelfID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_shelf_entries_shelf_book,priority:1"`
+	BookID     uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_shelf_entries_shelf_book,priority:2"`
+	Book       Book      `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE"`
+	AddedAt    time.Time `gorm:"not null"`
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ShelfSlug derives the URL-safe identifier for a shelf name, so
+// "Sci-Fi Favourites" becomes "sci-fi-favourites".
+func ShelfSlug(name string) string {
+	slug := nonSlugChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+	return strings.Trim(slug, "-")
+}
+
+func (s *Shelf) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+func (e *ShelfEntry) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
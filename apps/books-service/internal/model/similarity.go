@@ -0,0 +1,12 @@
+package model
+
+import "github.com/google/uuid"
+
+// BookSimilarity scores how related SimilarBookID is to BookID. The table
+// is rebuilt periodically by the similarity repository; pairs with nothing
+// in common have no row.
+type BookSimilarity struct {
+	BookID        uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_book_similarities_score,priority:1"`
+	SimilarBookID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Score         float64   `gorm:"not null;index:idx_book_similarities_score,priority:2,sort:desc"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Tag struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name      string    `gorm:"not null;uniqueIndex"`
+	Books     []Book    `gorm:"many2many:book_tags"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NormalizeTagName lowercases and trims a tag so "SciFi " and "scifi" are the same tag.
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func (t *Tag) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return
+}
+
+func (t *Tag) BeforeSave(tx *gorm.DB) (err error) {
+	t.Name = NormalizeTagName(t.Name)
+	return
+}
@@ -0,0 +1,11 @@
+package model
+
+// Who can see an owned book or copy. The owner always sees their own.
+const (
+	VisibilityPublic = "public"
+	// VisibilityGroups limits an item to members of groups its owner
+	// belongs to: for a copy, the groups it is shared with, or all of the
+	// owner's groups when it is shared with none in particular.
+	VisibilityGroups  = "groups"
+	VisibilityPrivate = "private"
+)
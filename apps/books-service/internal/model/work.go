@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Work is the abstract creation shared by all of its editions, e.g. the
+// novel itself rather than a particular paperback or translation.
+type Work struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OriginalTitle    string    `gorm:"not null;index"`
+	FirstPublishedAt *time.Time
+	Contributors     []WorkContributor `gorm:"foreignKey:WorkID"`
+	Editions         []Book            `gorm:"foreignKey:WorkID"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type WorkContributor struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	WorkID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	AuthorID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Author   Author    `gorm:"foreignKey:AuthorID"`
+	Role     string    `gorm:"not null"`
+}
+
+const (
+	ContributorRoleAuthor      = "author"
+	ContributorRoleTranslator  = "translator"
+	ContributorRoleEditor      = "editor"
+	ContributorRoleIllustrator = "illustrator"
+)
+
+const (
+	EditionFormatHardcover = "hardcover"
+	EditionFormatPaperback = "paperback"
+	EditionFormatEbook     = "ebook"
+	EditionFormatAudiobook = "audiobook"
+)
+
+func (w *Work) BeforeCreate(tx *gorm.DB) (err error) {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return
+}
+
+func (c *WorkContributor) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type ActivityFeedParams struct {
+	Limit int
+	After *Cursor
+}
+
+type ActivityRepository interface {
+	// Feed returns, newest first, what the users userID follows did, left
+	// out anything about books or copies userID can't see.
+	Feed(ctx context.Context, userID string, params ActivityFeedParams) ([]model.Activity, error)
+}
+
+type GormActivityRepository struct {
+	db *gorm.DB
+}
+
+func NewActivityRepository(db *gorm.DB) ActivityRepository {
+	return &GormActivityRepository{db: db}
+}
+
+func (r *GormActivityRepository) Feed(ctx context.Context, userID string, params ActivityFeedParams) ([]model.Activity, error) {
+	db := r.db.WithContext(ctx)
+
+	q := db.Preload("Book").Preload("Review").
+		Where("activities.user_id IN (SELECT followee_id FROM follows WHERE follower_id = ? AND status = ?)",
+			userID, model.FollowStatusAccepted).
+		Where(notBlockedSQL+"activities.user_id)", userID).
+		Where("activities.book_id IN (?)", visibleBooks(db.Model(&model.Book{}).Select("books.id"))).
+		Where("activities.copy_id IS NULL OR activities.copy_id IN (?)",
+			visibleCopies(db.Model(&model.Copy{}).Select("copies.id"), userID))
+	if params.After != nil {
+		q = afterCursor(q, "activities", params.After)
+	}
+
+	var activities []model.Activity
+	if err := q.Order("activities.created_at DESC, activities.id DESC").
+		Limit(params.Limit).
+		Find(&activities).Error; err != nil {
+
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+// recordActivity adds an entry to the user's activity. Repositories call
+// it inside the transaction making the change it records.
+func recordActivity(tx *gorm.DB, userID, kind string, bookID uuid.UUID, reviewID, copyID *uuid.UUID) error {
+	return tx.Omit("Book", "Review").Create(&model.Activity{
+		UserID:   userID,
+		Type:     kind,
+		BookID:   bookID,
+		ReviewID: reviewID,
+		CopyID:   copyID,
+	}).Error
+}
@@ -10,29 +10,35 @@ import (
 
 type AuthorRepository interface {
 	Create(ctx context.Context, author *model.Author) error
-	List(ctx context.Context) ([]model.Author, error)
-	FindByID(ctx context.Context, id uuid.UUID) (*model.Author, error)
+	// List and FindByID load the AuthorIncludes include names, or all of
+	// them when it is nil.
+	List(ctx context.Context, include ...string) ([]model.Author, error)
+	FindByID(ctx context.Context, id uuid.UUID, include ...string) (*model.Author, error)
+	// FindByIDs loads the authors with the given IDs, in no particular
+	// order, skipping those that don't exist.
+	FindByIDs(ctx context.Context, ids []uuid.UUID, include ...string) ([]model.Author, error)
 	Update(ctx context.Context, author *model.Author) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type GormAuthorRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	search SearchIndex
 }
 
-func NewAuthorRepository(db *gorm.DB) AuthorRepository {
-	return &GormAuthorRepository{db: db}
+func NewAuthorRepository(db *gorm.DB, opts ...RepositoryOption) AuthorRepository {
+	o := applyRepositoryOptions(opts)
+	return &GormAuthorRepository{db: db, search: o.search}
 }
 
 func (r *GormAuthorRepository) Create(ctx context.Context, author *model.Author) error {
 	return r.db.WithContext(ctx).Create(author).Error
 }
 
-func (r *GormAuthorRepository) List(ctx context.Context) ([]model.Author, error) {
+func (r *GormAuthorRepository) List(ctx context.Context, include ...string) ([]model.Author, error) {
 	var authors []model.Author
 
-	if err := r.db.WithContext(ctx).
-		Preload("Books").
+	if err := preloadAuthor(r.db.WithContext(ctx), include).
 		Order("created_at DESC").
 		Find(&authors).Error; err != nil {
 
@@ -42,11 +48,10 @@ func (r *GormAuthorRepository) List(ctx context.Context) ([]model.Author, error)
 	return authors, nil
 }
 
-func (r *GormAuthorRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Author, error) {
+func (r *GormAuthorRepository) FindByID(ctx context.Context, id uuid.UUID, include ...string) (*model.Author, error) {
 	var author model.Author
 
-	if err := r.db.WithContext(ctx).
-		Preload("Books").
+	if err := preloadAuthor(r.db.WithContext(ctx), include).
 		First(&author, "id = ?", id).Error; err != nil {
 
 		return nil, err
@@ -55,8 +60,34 @@ func (r *GormAuthorRepository) FindByID(ctx context.Context, id uuid.UUID) (*mod
 	return &author, nil
 }
 
+func (r *GormAuthorRepository) FindByIDs(ctx context.Context, ids []uuid.UUID, include ...string) ([]model.Author, error) {
+	var authors []model.Author
+
+	if err := preloadAuthor(r.db.WithContext(ctx), include).
+		Where("id IN ?", ids).
+		Find(&authors).Error; err != nil {
+
+		return nil, err
+	}
+
+	return authors, nil
+}
+
 func (r *GormAuthorRepository) Update(ctx context.Context, author *model.Author) error {
-	return r.db.WithContext(ctx).Save(author).Error
+	if err := r.db.WithContext(ctx).Save(author).Error; err != nil {
+		return err
+	}
+
+	// Books are searchable by their author's name.
+	var bookIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&model.Book{}).
+		Where("author_id = ?", author.ID).
+		Pluck("id", &bookIDs).Error; err != nil {
+
+		return err
+	}
+	syncSearch(ctx, r.db, r.search, bookIDs...)
+	return nil
 }
 
 func (r *GormAuthorRepository) Delete(ctx context.Context, id uuid.UUID) error {
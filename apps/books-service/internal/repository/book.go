@@ -3,60 +3,181 @@ package repository
 import (
 	"context"
 	"errors"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BookListParams struct {
-	Page      int
-	PageSize  int
-	Sort      string
-	Query     string
-	AuthorID  *uuid.UUID
-	PubAfter  *time.Time
-	PubBefore *time.Time
+	Page     int
+	PageSize int
+	// Offset, when set, skips that many books instead of Page.
+	Offset      int
+	Sort        string
+	Query       string
+	AuthorID    *uuid.UUID
+	SeriesID    *uuid.UUID
+	WorkID      *uuid.UUID
+	PublisherID *uuid.UUID
+	PubAfter    *time.Time
+	PubBefore   *time.Time
+	MinRating   *float64
+	Available   bool
+
+	// Language limits results to books in that language code, and Decade
+	// to books published in the ten years from that year, matching the
+	// values of the language and decade facets.
+	Language string
+	Decade   *int
+
+	// Fuzzy matches Query against titles and author names by trigram
+	// similarity of at least FuzzyThreshold, tolerating typos, instead of
+	// by substring.
+	Fuzzy          bool
+	FuzzyThreshold float64
+
+	Tags        []string
+	TagMode     string
+	ExcludeTags []string
+
+	// Shelf limits results to one of ShelfUserID's shelves, by UUID or slug.
+	Shelf       string
+	ShelfUserID string
+
+	// Facets lists the FacetAuthor, FacetDecade, FacetTag and FacetLanguage
+	// breakdowns to count over all matching books.
+	Facets []string
+
+	// GroupID limits results to the group's combined library: books with a
+	// copy owned by a member that is public or visible to the group.
+	// GroupViewerID must be a member.
+	GroupID       *uuid.UUID
+	GroupViewerID string
+
+	// Include names the BookIncludes to load with each book; nil loads
+	// them all.
+	Include []string
 }
 
+const (
+	TagModeAny = "any"
+	TagModeAll = "all"
+)
+
 type BookListResult struct {
 	Books []model.Book
 	Total int64
+	// Suggestions offers titles and author names close to Query when it
+	// matched nothing.
+	Suggestions []string
+	// Facets holds the most common values of each requested facet, most
+	// common first.
+	Facets map[string][]FacetCount
 }
 
+// BookRepository reads books as seen by the user on the context: books
+// whose owner limited their visibility are hidden from everyone else who
+// may not see them.
 type BookRepository interface {
 	Create(ctx context.Context, book *model.Book) error
-	FindByID(ctx context.Context, id uuid.UUID) (*model.Book, error)
+	// FindByID loads the relations include names, or all of them when
+	// it is nil.
+	FindByID(ctx context.Context, id uuid.UUID, include ...string) (*model.Book, error)
+	// FindByIDs loads the books with the given IDs the caller can see, in
+	// no particular order, skipping the rest.
+	FindByIDs(ctx context.Context, ids []uuid.UUID, include ...string) ([]model.Book, error)
 	List(ctx context.Context, params BookListParams) (BookListResult, error)
+	// ListByAuthors pages each author's books, newest first, in one query.
+	// Only PageSize, Offset and Include of params apply.
+	ListByAuthors(ctx context.Context, authorIDs []uuid.UUID, params BookListParams) (map[uuid.UUID]BookListResult, error)
 	Update(ctx context.Context, book *model.Book) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type GormBookRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	search SearchIndex
 }
 
 var ErrAuthorNotFound = errors.New("author not found")
 
-func NewGormBookRepository(db *gorm.DB) *GormBookRepository {
-	return &GormBookRepository{db: db}
+func NewGormBookRepository(db *gorm.DB, opts ...RepositoryOption) *GormBookRepository {
+	o := applyRepositoryOptions(opts)
+	return &GormBookRepository{db: db, search: o.search}
 }
 
 func (r *GormBookRepository) Create(ctx context.Context, book *model.Book) error {
-	return r.db.WithContext(ctx).Create(book).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tags, err := resolveTags(tx, book.Tags)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Omit("Tags").Create(book).Error; err != nil {
+			return err
+		}
+
+		if book.OwnerID != nil {
+			if err := recordActivity(tx, *book.OwnerID, model.ActivityBookAdded, book.ID, nil, nil); err != nil {
+				return err
+			}
+		}
+
+		book.Tags = tags
+		if len(tags) == 0 {
+			return nil
+		}
+		return tx.Model(book).Association("Tags").Replace(tags)
+	})
+	if err != nil {
+		return err
+	}
+
+	syncSearch(ctx, r.db, r.search, book.ID)
+	return nil
 }
 
-func (r *GormBookRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Book, error) {
+func (r *GormBookRepository) FindByID(ctx context.Context, id uuid.UUID, include ...string) (*model.Book, error) {
 	var book model.Book
-	if err := r.db.WithContext(ctx).
-		Preload("Author").
-		First(&book, "id = ?", id).Error; err != nil {
+	if err := preloadBook(visibleBooks(r.db.WithContext(ctx)), include).
+		First(&book, "books.id = ?", id).Error; err != nil {
 
 		return nil, err
 	}
-	return &book, nil
+
+	books := []model.Book{book}
+	if included(include, IncludeSeries) {
+		if err := attachSeriesNeighbours(r.db.WithContext(ctx), books); err != nil {
+			return nil, err
+		}
+	}
+	if err := attachCopyCounts(r.db.WithContext(ctx), books); err != nil {
+		return nil, err
+	}
+	return &books[0], nil
+}
+
+func (r *GormBookRepository) FindByIDs(ctx context.Context, ids []uuid.UUID, include ...string) ([]model.Book, error) {
+	var books []model.Book
+	if err := preloadBook(visibleBooks(r.db.WithContext(ctx)), include).
+		Where("books.id IN ?", ids).
+		Find(&books).Error; err != nil {
+
+		return nil, err
+	}
+
+	if included(include, IncludeSeries) {
+		if err := attachSeriesNeighbours(r.db.WithContext(ctx), books); err != nil {
+			return nil, err
+		}
+	}
+	if err := attachCopyCounts(r.db.WithContext(ctx), books); err != nil {
+		return nil, err
+	}
+	return books, nil
 }
 
 func (r *GormBookRepository) List(ctx context.Context, params BookListParams) (BookListResult, error) {
@@ -67,44 +188,52 @@ func (r *GormBookRepository) List(ctx context.Context, params BookListParams) (B
 		params.PageSize = 20
 	}
 
-	db := r.db.WithContext(ctx).Model(&model.Book{}).Preload("Author")
-
-	if params.AuthorID != nil {
-		db = db.Where("author_id = ?", *params.AuthorID)
-	}
+	var result BookListResult
+	err := withFuzzySearch(r.db.WithContext(ctx), params, func(db *gorm.DB) error {
+		var err error
+		result, err = r.list(db, params)
+		return err
+	})
+	return result, err
+}
 
-	if params.PubAfter != nil {
-		db = db.Where("published_at >= ?", *params.PubAfter)
+// list runs List's queries on base.
+func (r *GormBookRepository) list(base *gorm.DB, params BookListParams) (BookListResult, error) {
+	db, rank, err := filterBooks(base, r.search, params)
+	if err != nil {
+		return BookListResult{}, err
 	}
+	db = preloadBook(db, params.Include)
 
-	if params.PubBefore != nil {
-		db = db.Where("published_at <= ?", *params.PubBefore)
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return BookListResult{}, err
 	}
 
-	dialect := r.db.Dialector.Name()
-	if params.Query != "" {
-		like := "%" + params.Query + "%"
-		if dialect == "postgres" {
-			db = db.Where(
-				"title ILIKE ? OR description ILIKE ?",
-				like, like,
-			)
-		} else {
-			q := strings.ToLower(params.Query)
-			like := "%" + q + "%"
-			db = db.Where(
-				"LOWER(title) LIKE ? OR LOWER(description) LIKE ?",
-				like, like,
-			)
+	var facets map[string][]FacetCount
+	if len(params.Facets) > 0 {
+		facets, err = countFacets(base, r.search, params)
+		if err != nil {
+			return BookListResult{}, err
 		}
 	}
 
-	var total int64
-	if err := db.Count(&total).Error; err != nil {
-		return BookListResult{}, err
+	if total == 0 && params.Query != "" {
+		// Offer looser matches than the search itself used.
+		suggestions, err := suggestSearchTerms(base, params.Query, fuzzyThreshold(params)/2)
+		if err != nil {
+			return BookListResult{}, err
+		}
+		return BookListResult{Suggestions: suggestions, Facets: facets}, nil
 	}
 
 	switch params.Sort {
+	case "relevance":
+		if rank == nil {
+			db = db.Order("created_at DESC")
+			break
+		}
+		db = db.Order(clause.OrderBy{Expression: rank}).Order("title ASC")
 	case "title_asc":
 		db = db.Order("title ASC")
 	case "title_desc":
@@ -115,6 +244,14 @@ func (r *GormBookRepository) List(ctx context.Context, params BookListParams) (B
 		db = db.Order("published_at DESC NULLS LAST")
 	case "created_at_asc":
 		db = db.Order("created_at ASC")
+	case "publisher_asc":
+		db = db.Order("(SELECT name FROM publishers WHERE publishers.id = books.publisher_id) ASC NULLS LAST").Order("title ASC")
+	case "publisher_desc":
+		db = db.Order("(SELECT name FROM publishers WHERE publishers.id = books.publisher_id) DESC NULLS LAST").Order("title ASC")
+	case "rating_desc":
+		db = db.Order("rating_average DESC").Order("rating_count DESC").Order("title ASC")
+	case "series_volume_asc":
+		db = db.Order("series_volume ASC NULLS LAST").Order("title ASC")
 	case "created_at_desc", "":
 		fallthrough
 	default:
@@ -122,6 +259,9 @@ func (r *GormBookRepository) List(ctx context.Context, params BookListParams) (B
 	}
 
 	offset := (params.Page - 1) * params.PageSize
+	if params.Offset > 0 {
+		offset = params.Offset
+	}
 
 	var books []model.Book
 	if err := db.
@@ -132,31 +272,307 @@ func (r *GormBookRepository) List(ctx context.Context, params BookListParams) (B
 		return BookListResult{}, err
 	}
 
+	if included(params.Include, IncludeSeries) {
+		if err := attachSeriesNeighbours(base, books); err != nil {
+			return BookListResult{}, err
+		}
+	}
+	if err := attachCopyCounts(base, books); err != nil {
+		return BookListResult{}, err
+	}
+
 	return BookListResult{
-		Books: books,
-		Total: total,
+		Books:  books,
+		Total:  total,
+		Facets: facets,
 	}, nil
 }
 
+func (r *GormBookRepository) ListByAuthors(ctx context.Context, authorIDs []uuid.UUID, params BookListParams) (map[uuid.UUID]BookListResult, error) {
+	if params.PageSize <= 0 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+
+	db := r.db.WithContext(ctx)
+	results := make(map[uuid.UUID]BookListResult, len(authorIDs))
+	if len(authorIDs) == 0 {
+		return results, nil
+	}
+	matched := func() *gorm.DB {
+		return visibleBooks(db.Model(&model.Book{})).Where("books.author_id IN ?", authorIDs)
+	}
+
+	var totals []groupCount
+	if err := matched().
+		Select("books.author_id AS group_id, COUNT(*) AS count").
+		Group("books.author_id").
+		Scan(&totals).Error; err != nil {
+
+		return nil, err
+	}
+	for _, t := range totals {
+		results[t.GroupID] = BookListResult{Total: t.Count}
+	}
+
+	var books []model.Book
+	if err := preloadBook(pagePerGroup(db, matched(), "books", "author_id", "created_at DESC, books.id DESC",
+		params.Offset, params.PageSize), params.Include).
+		Find(&books).Error; err != nil {
+
+		return nil, err
+	}
+	if included(params.Include, IncludeSeries) {
+		if err := attachSeriesNeighbours(db, books); err != nil {
+			return nil, err
+		}
+	}
+	if err := attachCopyCounts(db, books); err != nil {
+		return nil, err
+	}
+
+	for _, b := range books {
+		result := results[b.AuthorID]
+		result.Books = append(result.Books, b)
+		results[b.AuthorID] = result
+	}
+	return results, nil
+}
+
+// Update saves the scalar fields of book. Tags are replaced only when
+// book.Tags is non-nil, so an empty slice clears them.
 func (r *GormBookRepository) Update(ctx context.Context, book *model.Book) error {
-	return r.db.WithContext(ctx).
-		Model(&model.Book{}).
-		Where("id = ?", book.ID).
-		Updates(map[string]any{
-			"title":        book.Title,
-			"description":  book.Description,
-			"author_id":    book.AuthorID,
-			"published_at": book.PublishedAt,
-		}).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Model(&model.Book{}).
+			Where("id = ?", book.ID).
+			Updates(map[string]any{
+				"title":         book.Title,
+				"description":   book.Description,
+				"author_id":     book.AuthorID,
+				"published_at":  book.PublishedAt,
+				"cover_key":     book.CoverKey,
+				"series_id":     book.SeriesID,
+				"series_volume": book.SeriesVolume,
+				"work_id":       book.WorkID,
+				"format":        book.Format,
+				"language":      book.Language,
+				"publisher_id":  book.PublisherID,
+				"page_count":    book.PageCount,
+				"isbn":          book.ISBN,
+				"visibility":    book.Visibility,
+			}).Error; err != nil {
+
+			return err
+		}
+
+		if book.Tags == nil {
+			return nil
+		}
+
+		tags, err := resolveTags(tx, book.Tags)
+		if err != nil {
+			return err
+		}
+		book.Tags = tags
+		return tx.Model(&model.Book{ID: book.ID}).Association("Tags").Replace(tags)
+	})
+	if err != nil {
+		return err
+	}
+
+	syncSearch(ctx, r.db, r.search, book.ID)
+	return nil
 }
 
 func (r *GormBookRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&model.Book{}, "id = ?", id)
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureBookVisible(tx, id); err != nil {
+			if errors.Is(err, ErrBookNotFound) {
+				return gorm.ErrRecordNotFound
+			}
+			return err
+		}
+		if err := tx.Exec("DELETE FROM book_tags WHERE book_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM shelf_entries WHERE book_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM reviews WHERE book_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM progress_entries WHERE book_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM holds WHERE book_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM copy_groups WHERE copy_id IN (SELECT id FROM copies WHERE book_id = ?)", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM copies WHERE book_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM book_similarities WHERE book_id = ? OR similar_book_id = ?", id, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM activities WHERE book_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&model.Book{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+
+	syncSearch(ctx, r.db, r.search, id)
 	return nil
 }
+
+func fuzzyThreshold(params BookListParams) float64 {
+	if params.FuzzyThreshold <= 0 || params.FuzzyThreshold > 1 {
+		return DefaultFuzzyThreshold
+	}
+	return params.FuzzyThreshold
+}
+
+// filterBooks starts a books query limited to those the user on db's
+// context can see and that match params' filters, with q answered by
+// index. rank orders the matches by relevance, or is nil. Paging and sort
+// are left to the caller.
+func filterBooks(db *gorm.DB, index SearchIndex, params BookListParams) (filtered *gorm.DB, rank clause.Expression, err error) {
+	db = visibleBooks(db.Model(&model.Book{}))
+
+	if params.AuthorID != nil {
+		db = db.Where("author_id = ?", *params.AuthorID)
+	}
+
+	if params.SeriesID != nil {
+		db = db.Where("series_id = ?", *params.SeriesID)
+	}
+
+	if params.WorkID != nil {
+		db = db.Where("work_id = ?", *params.WorkID)
+	}
+
+	if params.PublisherID != nil {
+		db = db.Where("publisher_id = ?", *params.PublisherID)
+	}
+
+	if params.Available {
+		db = db.Where(
+			"EXISTS (SELECT 1 FROM copies WHERE copies.book_id = books.id AND copies.status = ? AND "+publicCopiesSQL+")",
+			model.CopyStatusAvailable,
+		)
+	}
+
+	if params.MinRating != nil {
+		db = db.Where("rating_average >= ?", *params.MinRating)
+	}
+
+	if params.Shelf != "" {
+		shelved := whereShelfRef(
+			db.Session(&gorm.Session{NewDB: true}).Table("shelf_entries").
+				Select("shelf_entries.book_id").
+				Joins("JOIN shelves ON shelves.id = shelf_entries.shelf_id").
+				Where("shelves.user_id = ?", params.ShelfUserID),
+			"shelves", params.Shelf,
+		)
+		db = db.Where("books.id IN (?)", shelved)
+	}
+
+	if params.GroupID != nil {
+		var count int64
+		if err := db.Session(&gorm.Session{NewDB: true}).Model(&model.GroupMember{}).
+			Where("group_id = ? AND user_id = ?", *params.GroupID, params.GroupViewerID).
+			Count(&count).Error; err != nil {
+
+			return nil, nil, err
+		}
+		if count == 0 {
+			return nil, nil, ErrNotGroupMember
+		}
+
+		db = db.Where(
+			"EXISTS (SELECT 1 FROM copies JOIN group_members gm ON gm.user_id = copies.owner_id AND gm.group_id = ?"+
+				" WHERE copies.book_id = books.id AND copies.status <> ?"+
+				" AND ("+publicCopiesSQL+" OR (copies.visibility = ? AND ("+
+				"EXISTS (SELECT 1 FROM copy_groups cg WHERE cg.copy_id = copies.id AND cg.group_id = ?)"+
+				" OR NOT EXISTS (SELECT 1 FROM copy_groups cg WHERE cg.copy_id = copies.id)))))",
+			*params.GroupID, model.CopyStatusWithdrawn, model.VisibilityGroups, *params.GroupID,
+		)
+	}
+
+	if params.PubAfter != nil {
+		db = db.Where("published_at >= ?", *params.PubAfter)
+	}
+
+	if params.PubBefore != nil {
+		db = db.Where("published_at <= ?", *params.PubBefore)
+	}
+
+	if params.Language != "" {
+		db = db.Where("books.language = ?", params.Language)
+	}
+
+	if params.Decade != nil {
+		start := time.Date(*params.Decade, time.January, 1, 0, 0, 0, 0, time.UTC)
+		db = db.Where("published_at >= ? AND published_at < ?", start, start.AddDate(10, 0, 0))
+	}
+
+	if tags := normalizeTagNames(params.Tags); len(tags) > 0 {
+		if params.TagMode == TagModeAll {
+			db = db.Where(
+				"books.id IN (SELECT bt.book_id FROM book_tags bt JOIN tags t ON t.id = bt.tag_id WHERE t.name IN ? GROUP BY bt.book_id HAVING COUNT(DISTINCT t.id) = ?)",
+				tags, len(tags),
+			)
+		} else {
+			db = db.Where(
+				"books.id IN (SELECT bt.book_id FROM book_tags bt JOIN tags t ON t.id = bt.tag_id WHERE t.name IN ?)",
+				tags,
+			)
+		}
+	}
+
+	if excluded := normalizeTagNames(params.ExcludeTags); len(excluded) > 0 {
+		db = db.Where(
+			"books.id NOT IN (SELECT bt.book_id FROM book_tags bt JOIN tags t ON t.id = bt.tag_id WHERE t.name IN ?)",
+			excluded,
+		)
+	}
+
+	if params.Query == "" {
+		return db, nil, nil
+	}
+	return index.Match(db, SearchQuery{
+		Text:      params.Query,
+		Fuzzy:     params.Fuzzy,
+		Threshold: fuzzyThreshold(params),
+	})
+}
+
+func orderTagsByName(db *gorm.DB) *gorm.DB {
+	return db.Order("tags.name ASC")
+}
+
+func normalizeTagNames(names []string) []string {
+	out := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, n := range names {
+		n = model.NormalizeTagName(n)
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		out = append(out, n)
+	}
+	return out
+}
@@ -20,7 +20,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("failed to connect to test database: %v", err)
 	}
 
-	if err := db.AutoMigrate(&model.Author{}, &model.Book{}); err != nil {
+	if err := db.AutoMigrate(&model.Author{}, &model.Series{}, &model.Work{}, &model.WorkContributor{}, &model.Publisher{}, &model.Book{}, &model.Tag{}, &model.Shelf{}, &model.ShelfEntry{}, &model.Review{}, &model.ProgressEntry{}, &model.Copy{}, &model.Hold{}, &model.Notification{}, &model.NotificationPreference{}, &model.Group{}, &model.GroupMember{}, &model.GroupInvite{}, &model.CopyGroup{}, &model.Follow{}, &model.AccountSettings{}, &model.Block{}, &model.BookSimilarity{}, &model.Activity{}); err != nil {
 		t.Fatalf("failed to migrate test database: %v", err)
 	}
 
@@ -154,3 +154,75 @@ func TestGormBookRepository_List_FilterByAuthorAndPagination(t *testing.T) {
 		t.Fatalf("expected book author_id=%s, got %s", author2.ID, result.Books[0].AuthorID)
 	}
 }
+
+func TestGormBookRepository_Include(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewGormBookRepository(db)
+
+	author1, _ := seedBooks(t, db)
+	ctx := context.Background()
+
+	result, err := repo.List(ctx, BookListParams{AuthorID: &author1.ID, Include: []string{}})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(result.Books) != 2 {
+		t.Fatalf("expected 2 books, got %d", len(result.Books))
+	}
+	if result.Books[0].Author.ID != uuid.Nil {
+		t.Fatalf("expected the author not to be loaded, got %+v", result.Books[0].Author)
+	}
+
+	book, err := repo.FindByID(ctx, result.Books[0].ID, IncludeAuthor)
+	if err != nil {
+		t.Fatalf("FindByID returned error: %v", err)
+	}
+	if book.Author.Name != "Author One" || book.Tags != nil {
+		t.Fatalf("expected only the author to be loaded, got author=%+v tags=%v", book.Author, book.Tags)
+	}
+
+	authors := NewAuthorRepository(db)
+	author, err := authors.FindByID(ctx, author1.ID, []string{}...)
+	if err != nil {
+		t.Fatalf("FindByID returned error: %v", err)
+	}
+	if author.Books != nil {
+		t.Fatalf("expected books not to be loaded, got %d", len(author.Books))
+	}
+	if author, err = authors.FindByID(ctx, author1.ID); err != nil || len(author.Books) != 2 {
+		t.Fatalf("expected both books by default, got %+v, err=%v", author, err)
+	}
+}
+
+func TestGormBookRepository_ListByAuthors(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewGormBookRepository(db)
+
+	author1, author2 := seedBooks(t, db)
+	missing := uuid.New()
+	ctx := context.Background()
+
+	pages, err := repo.ListByAuthors(ctx, []uuid.UUID{author1.ID, author2.ID, missing}, BookListParams{PageSize: 1})
+	if err != nil {
+		t.Fatalf("ListByAuthors returned error: %v", err)
+	}
+
+	first := pages[author1.ID]
+	if first.Total != 2 || len(first.Books) != 1 || first.Books[0].Title != "Clean Architecture" {
+		t.Fatalf("expected the newest of author1's 2 books, got %+v", first)
+	}
+	if second := pages[author2.ID]; second.Total != 1 || len(second.Books) != 1 {
+		t.Fatalf("expected author2's book, got %+v", second)
+	}
+	if none := pages[missing]; none.Total != 0 || len(none.Books) != 0 {
+		t.Fatalf("expected nothing for an unknown author, got %+v", none)
+	}
+
+	pages, err = repo.ListByAuthors(ctx, []uuid.UUID{author1.ID}, BookListParams{PageSize: 1, Offset: 1})
+	if err != nil {
+		t.Fatalf("ListByAuthors returned error: %v", err)
+	}
+	if page := pages[author1.ID]; page.Total != 2 || len(page.Books) != 1 || page.Books[0].Title != "Clean Code" {
+		t.Fatalf("expected the older book on the second page, got %+v", page)
+	}
+}
@@ -0,0 +1,370 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// ErrCopyReserved means the copy is reserved for a hold, so its status
+// can't change and it can't be deleted until the offer ends.
+var ErrCopyReserved = errors.New("copy is reserved for a hold")
+
+// CopyRepository reads copies as seen by a viewer: private copies are
+// hidden from everyone but their owner, and groups-only copies from anyone
+// outside the groups they are visible to. An empty viewer ID sees only
+// public copies.
+type CopyRepository interface {
+	Create(ctx context.Context, cp *model.Copy) error
+	List(ctx context.Context, bookID uuid.UUID, status, viewerID string) ([]model.Copy, error)
+	FindByID(ctx context.Context, bookID, id uuid.UUID, viewerID string) (*model.Copy, error)
+	Update(ctx context.Context, cp *model.Copy) error
+	SetGroups(ctx context.Context, cp *model.Copy, groupIDs []uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type GormCopyRepository struct {
+	db    *gorm.DB
+	holds *GormHoldRepository
+}
+
+type CopyRepositoryOption func(*GormCopyRepository)
+
+// WithHoldOffers offers copies that are added or become available to the
+// head of the book's hold queue, with offers open for offerTTL. The offer
+// is made in the same transaction as the change, so either both happen or
+// neither does.
+func WithHoldOffers(offerTTL time.Duration) CopyRepositoryOption {
+	return func(r *GormCopyRepository) {
+		r.holds = newGormHoldRepository(r.db, offerTTL)
+	}
+}
+
+func NewCopyRepository(db *gorm.DB, opts ...CopyRepositoryOption) CopyRepository {
+	r := &GormCopyRepository{db: db}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *GormCopyRepository) Create(ctx context.Context, cp *model.Copy) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, cp.BookID); err != nil {
+			return err
+		}
+		if err := ensureBookVisible(tx, cp.BookID); err != nil {
+			return err
+		}
+		if err := tx.Omit("Book").Create(cp).Error; err != nil {
+			return err
+		}
+		return r.offerToHolds(tx, cp)
+	})
+}
+
+// List returns the copies of a book the viewer can see, oldest first,
+// optionally only those with the given status.
+func (r *GormCopyRepository) List(ctx context.Context, bookID uuid.UUID, status, viewerID string) ([]model.Copy, error) {
+	db := visibleCopies(r.db.WithContext(ctx), viewerID).Where("book_id = ?", bookID)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	var copies []model.Copy
+	if err := db.Order("created_at ASC").Find(&copies).Error; err != nil {
+		return nil, err
+	}
+
+	if err := attachCopyGroups(r.db.WithContext(ctx), copies); err != nil {
+		return nil, err
+	}
+	return copies, nil
+}
+
+func (r *GormCopyRepository) FindByID(ctx context.Context, bookID, id uuid.UUID, viewerID string) (*model.Copy, error) {
+	var cp model.Copy
+
+	if err := visibleCopies(r.db.WithContext(ctx), viewerID).
+		First(&cp, "id = ? AND book_id = ?", id, bookID).Error; err != nil {
+
+		return nil, err
+	}
+
+	copies := []model.Copy{cp}
+	if err := attachCopyGroups(r.db.WithContext(ctx), copies); err != nil {
+		return nil, err
+	}
+	return &copies[0], nil
+}
+
+// Update saves the copy's fields. An empty Status keeps the stored one and
+// is filled in with it; changing the status of a reserved copy fails with
+// ErrCopyReserved. The book's hold queue is locked meanwhile, so a copy
+// can't be offered to a hold between the check and the write. A copy that
+// stops being groups-only drops the groups it was shared with, and one
+// going out on loan shows up in its owner's activity. The borrower and due
+// date are only kept while the copy is on loan; a new due date means a new
+// reminder.
+func (r *GormCopyRepository) Update(ctx context.Context, cp *model.Copy) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, cp.BookID); err != nil {
+			return err
+		}
+
+		var previous model.Copy
+		if err := tx.Select("status", "due_at").First(&previous, "id = ?", cp.ID).Error; err != nil {
+			return err
+		}
+
+		fields := map[string]any{
+			"condition":   cp.Condition,
+			"acquired_at": cp.AcquiredAt,
+			"notes":       cp.Notes,
+			"visibility":  cp.Visibility,
+			"updated_at":  time.Now(),
+		}
+		switch cp.Status {
+		case "", previous.Status:
+			cp.Status = previous.Status
+		default:
+			if previous.Status == model.CopyStatusReserved {
+				return ErrCopyReserved
+			}
+			fields["status"] = cp.Status
+		}
+
+		if cp.Status != model.CopyStatusOnLoan {
+			cp.BorrowerID, cp.DueAt = nil, nil
+		}
+		fields["borrower_id"] = cp.BorrowerID
+		fields["due_at"] = cp.DueAt
+		if !sameTime(cp.DueAt, previous.DueAt) {
+			cp.ReminderSentAt, cp.OverdueAt = nil, nil
+			fields["reminder_sent_at"] = nil
+			fields["overdue_at"] = nil
+		}
+
+		if err := tx.
+			Model(&model.Copy{}).
+			Where("id = ?", cp.ID).
+			Updates(fields).Error; err != nil {
+
+			return err
+		}
+
+		if cp.Status == model.CopyStatusOnLoan && previous.Status != model.CopyStatusOnLoan {
+			if err := recordActivity(tx, cp.OwnerID, model.ActivityCopyLent, cp.BookID, nil, &cp.ID); err != nil {
+				return err
+			}
+		}
+
+		if cp.Visibility != model.VisibilityGroups {
+			cp.GroupIDs = nil
+			if err := tx.Where("copy_id = ?", cp.ID).Delete(&model.CopyGroup{}).Error; err != nil {
+				return err
+			}
+		}
+
+		return r.offerToHolds(tx, cp)
+	})
+}
+
+// SetGroups makes the copy groups-only and shares it with the given
+// groups, replacing any earlier ones; no groups makes it public again. The
+// copy's owner must belong to every group.
+func (r *GormCopyRepository) SetGroups(ctx context.Context, cp *model.Copy, groupIDs []uuid.UUID) error {
+	groupIDs = uniqueUUIDs(groupIDs)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, cp.BookID); err != nil {
+			return err
+		}
+
+		if len(groupIDs) > 0 {
+			var count int64
+			if err := tx.Model(&model.GroupMember{}).
+				Where("user_id = ? AND group_id IN ?", cp.OwnerID, groupIDs).
+				Count(&count).Error; err != nil {
+
+				return err
+			}
+			if int(count) != len(groupIDs) {
+				return ErrNotGroupMember
+			}
+		}
+
+		if err := tx.Where("copy_id = ?", cp.ID).Delete(&model.CopyGroup{}).Error; err != nil {
+			return err
+		}
+		for _, id := range groupIDs {
+			if err := tx.Omit("Copy", "Group").Create(&model.CopyGroup{CopyID: cp.ID, GroupID: id}).Error; err != nil {
+				return err
+			}
+		}
+
+		visibility := model.VisibilityPublic
+		if len(groupIDs) > 0 {
+			visibility = model.VisibilityGroups
+		}
+		if err := tx.Model(&model.Copy{}).
+			Where("id = ?", cp.ID).
+			Update("visibility", visibility).Error; err != nil {
+
+			return err
+		}
+
+		cp.Visibility = visibility
+		cp.GroupIDs = groupIDs
+
+		// Holders who couldn't see the copy before may be able to now.
+		return r.offerToHolds(tx, cp)
+	})
+}
+
+// offerToHolds offers an available copy to the book's hold queue, if the
+// repository offers copies at all. The caller must hold the book lock.
+func (r *GormCopyRepository) offerToHolds(tx *gorm.DB, cp *model.Copy) error {
+	if r.holds == nil || cp.Status != model.CopyStatusAvailable {
+		return nil
+	}
+	_, err := r.holds.offerCopies(tx, cp.BookID)
+	return err
+}
+
+// Delete removes the copy unless it is reserved for a hold, in which case
+// it fails with ErrCopyReserved.
+func (r *GormCopyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cp model.Copy
+		if err := tx.Select("book_id").First(&cp, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := lockBook(tx, cp.BookID); err != nil {
+			return err
+		}
+
+		if err := tx.Where("copy_id = ?", id).Delete(&model.CopyGroup{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("copy_id = ?", id).Delete(&model.Activity{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&model.Copy{}, "id = ? AND status <> ?", id, model.CopyStatusReserved)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCopyReserved
+		}
+		return nil
+	})
+}
+
+// publicCopiesSQL matches copies everyone can see.
+const publicCopiesSQL = "copies.visibility = '" + model.VisibilityPublic + "'"
+
+// visibleCopies limits a copies query to those viewerID can see: public
+// copies, their own, and groups-only copies shared with one of their
+// groups, or shared with no group in particular by an owner they share a
+// group with. Copies of owners who blocked them are left out.
+func visibleCopies(db *gorm.DB, viewerID string) *gorm.DB {
+	return db.Where(
+		publicCopiesSQL+
+			" OR copies.owner_id = ?"+
+			" OR (copies.visibility = ? AND ("+
+			"EXISTS (SELECT 1 FROM copy_groups cg JOIN group_members gm ON gm.group_id = cg.group_id WHERE cg.copy_id = copies.id AND gm.user_id = ?)"+
+			" OR (NOT EXISTS (SELECT 1 FROM copy_groups cg WHERE cg.copy_id = copies.id) AND "+sharesGroupSQL+"copies.owner_id))))",
+		viewerID, model.VisibilityGroups, viewerID, viewerID,
+	).Where(notBlockedSQL+"copies.owner_id)", viewerID)
+}
+
+func attachCopyGroups(db *gorm.DB, copies []model.Copy) error {
+	if len(copies) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(copies))
+	for _, cp := range copies {
+		ids = append(ids, cp.ID)
+	}
+
+	var links []model.CopyGroup
+	if err := db.Where("copy_id IN ?", ids).Find(&links).Error; err != nil {
+		return err
+	}
+
+	byCopy := make(map[uuid.UUID][]uuid.UUID, len(links))
+	for _, l := range links {
+		byCopy[l.CopyID] = append(byCopy[l.CopyID], l.GroupID)
+	}
+	for i := range copies {
+		copies[i].GroupIDs = byCopy[copies[i].ID]
+	}
+	return nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	out := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// attachCopyCounts fills in how many copies each book has, not counting
+// withdrawn ones or those that aren't public, and how many are
+// available, in one query for the page.
+func attachCopyCounts(db *gorm.DB, books []model.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(books))
+	for _, b := range books {
+		ids = append(ids, b.ID)
+	}
+
+	var counts []struct {
+		BookID    uuid.UUID
+		Total     int64
+		Available int64
+	}
+	if err := db.Model(&model.Copy{}).
+		Select("book_id, COUNT(*) AS total, SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS available", model.CopyStatusAvailable).
+		Where("book_id IN ? AND status <> ?", ids, model.CopyStatusWithdrawn).
+		Where(publicCopiesSQL).
+		Group("book_id").
+		Scan(&counts).Error; err != nil {
+
+		return err
+	}
+
+	byBook := make(map[uuid.UUID]int, len(counts))
+	for i, c := range counts {
+		byBook[c.BookID] = i
+	}
+	for i := range books {
+		if j, ok := byBook[books[i].ID]; ok {
+			books[i].CopyCount = counts[j].Total
+			books[i].AvailableCopies = counts[j].Available
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+)
+
+func TestGormCopyRepository_ReservedCopy(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewCopyRepository(db)
+	ctx := context.Background()
+
+	author, _ := seedBooks(t, db)
+	var book model.Book
+	if err := db.First(&book, "author_id = ?", author.ID).Error; err != nil {
+		t.Fatalf("failed to load book: %v", err)
+	}
+
+	cp := model.Copy{BookID: book.ID, OwnerID: "owner"}
+	if err := repo.Create(ctx, &cp); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	// The copy is offered to a hold after the caller loaded it.
+	stale := cp
+	if err := db.Model(&model.Copy{}).Where("id = ?", cp.ID).
+		Update("status", model.CopyStatusReserved).Error; err != nil {
+
+		t.Fatalf("failed to reserve copy: %v", err)
+	}
+
+	// Saving other fields keeps the reservation.
+	stale.Status = ""
+	stale.Notes = "signed"
+	if err := repo.Update(ctx, &stale); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if stale.Status != model.CopyStatusReserved {
+		t.Fatalf("expected the stored status to be filled in, got %q", stale.Status)
+	}
+
+	var stored model.Copy
+	if err := db.First(&stored, "id = ?", cp.ID).Error; err != nil {
+		t.Fatalf("failed to reload copy: %v", err)
+	}
+	if stored.Status != model.CopyStatusReserved || stored.Notes != "signed" {
+		t.Fatalf("expected a reserved copy with the new notes, got %q and %q", stored.Status, stored.Notes)
+	}
+
+	stale.Status = model.CopyStatusAvailable
+	if err := repo.Update(ctx, &stale); !errors.Is(err, ErrCopyReserved) {
+		t.Fatalf("expected ErrCopyReserved changing the status, got %v", err)
+	}
+	if err := repo.Delete(ctx, cp.ID); !errors.Is(err, ErrCopyReserved) {
+		t.Fatalf("expected ErrCopyReserved deleting, got %v", err)
+	}
+	if err := db.First(&stored, "id = ?", cp.ID).Error; err != nil {
+		t.Fatalf("expected the reserved copy to remain: %v", err)
+	}
+}
+
+func TestGormCopyRepository_OffersReturnedCopyInSameTransaction(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewCopyRepository(db, WithHoldOffers(time.Hour))
+	holds := NewHoldRepository(db, time.Hour)
+	ctx := context.Background()
+
+	author, _ := seedBooks(t, db)
+	var book model.Book
+	if err := db.First(&book, "author_id = ?", author.ID).Error; err != nil {
+		t.Fatalf("failed to load book: %v", err)
+	}
+
+	cp := model.Copy{BookID: book.ID, OwnerID: "owner", Status: model.CopyStatusOnLoan}
+	if err := repo.Create(ctx, &cp); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	hold := model.Hold{BookID: book.ID, UserID: "reader"}
+	if err := holds.Join(ctx, &hold); err != nil {
+		t.Fatalf("Join returned error: %v", err)
+	}
+
+	// With the holder's notification failing, the copy's return is
+	// rolled back along with the offer.
+	if err := db.Migrator().DropTable(&model.Notification{}); err != nil {
+		t.Fatalf("failed to drop notifications: %v", err)
+	}
+	cp.Status = model.CopyStatusAvailable
+	if err := repo.Update(ctx, &cp); err == nil {
+		t.Fatalf("expected Update to fail when the offer fails")
+	}
+	var stored model.Copy
+	if err := db.First(&stored, "id = ?", cp.ID).Error; err != nil {
+		t.Fatalf("failed to reload copy: %v", err)
+	}
+	if stored.Status != model.CopyStatusOnLoan {
+		t.Fatalf("expected the copy to stay on loan, got %q", stored.Status)
+	}
+
+	if err := db.AutoMigrate(&model.Notification{}); err != nil {
+		t.Fatalf("failed to restore notifications: %v", err)
+	}
+	cp.Status = model.CopyStatusAvailable
+	if err := repo.Update(ctx, &cp); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	var offered model.Copy
+	if err := db.First(&offered, "id = ?", cp.ID).Error; err != nil {
+		t.Fatalf("failed to reload copy: %v", err)
+	}
+	if offered.Status != model.CopyStatusReserved {
+		t.Fatalf("expected the returned copy to be reserved for the hold, got %q", offered.Status)
+	}
+	if err := db.First(&hold, "id = ?", hold.ID).Error; err != nil || hold.Status != model.HoldStatusOffered {
+		t.Fatalf("expected the hold to be offered the copy, got %q, %v", hold.Status, err)
+	}
+}
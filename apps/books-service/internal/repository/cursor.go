@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Cursor marks the last item of a page of a list ordered newest first by
+// created_at, then id; the next page starts after it.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// afterCursor limits a query on table to the items after c.
+func afterCursor(db *gorm.DB, table string, c *Cursor) *gorm.DB {
+	return db.Where(
+		table+".created_at < ? OR ("+table+".created_at = ? AND "+table+".id < ?)",
+		c.CreatedAt, c.CreatedAt, c.ID,
+	)
+}
+
+// groupCount counts the rows of one group, as for the totals of
+// pagePerGroup.
+type groupCount struct {
+	GroupID uuid.UUID
+	Count   int64
+}
+
+// pagePerGroup pages query, a query on table, within each group sharing
+// column: it keeps rows offset+1 to offset+limit of each group in order.
+// The result reads like table, so it can be scanned and preloaded as such.
+func pagePerGroup(db *gorm.DB, query *gorm.DB, table, column, order string, offset, limit int) *gorm.DB {
+	ranked := query.Select(table + ".*, ROW_NUMBER() OVER (PARTITION BY " + table + "." + column + " ORDER BY " + order + ") AS group_row")
+	return db.Table("(?) AS "+table, ranked).
+		Where("group_row > ? AND group_row <= ?", offset, offset+limit).
+		Order(table + "." + column).
+		Order(order)
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	FacetAuthor   = "author"
+	FacetDecade   = "decade"
+	FacetTag      = "tag"
+	FacetLanguage = "language"
+)
+
+// maxFacetValues caps how many values each facet returns.
+const maxFacetValues = 20
+
+type FacetCount struct {
+	// Value is what to filter by: an author_id, decade, tags or language
+	// parameter.
+	Value string
+	Label string
+	Count int64
+}
+
+// facetSQL selects facet, value, label and count rows for each facet over
+// the IDs in the matched CTE.
+func facetSQL(db *gorm.DB, facet string) string {
+	decade := publishedDecadeSQL(db)
+
+	switch facet {
+	case FacetAuthor:
+		return "SELECT 'author' AS facet, CAST(books.author_id AS TEXT) AS value, authors.name AS label, COUNT(*) AS count" +
+			" FROM matched JOIN books ON books.id = matched.id JOIN authors ON authors.id = books.author_id" +
+			" GROUP BY books.author_id, authors.name"
+	case FacetDecade:
+		return "SELECT 'decade' AS facet, CAST(" + decade + " AS TEXT) AS value, NULL AS label, COUNT(*) AS count" +
+			" FROM matched JOIN books ON books.id = matched.id" +
+			" WHERE books.published_at IS NOT NULL GROUP BY " + decade
+	case FacetTag:
+		return "SELECT 'tag' AS facet, tags.name AS value, tags.name AS label, COUNT(*) AS count" +
+			" FROM matched JOIN book_tags ON book_tags.book_id = matched.id JOIN tags ON tags.id = book_tags.tag_id" +
+			" GROUP BY tags.name"
+	case FacetLanguage:
+		return "SELECT 'language' AS facet, books.language AS value, books.language AS label, COUNT(*) AS count" +
+			" FROM matched JOIN books ON books.id = matched.id" +
+			" WHERE COALESCE(books.language, '') <> '' GROUP BY books.language"
+	}
+	return ""
+}
+
+// countFacets counts the most common values of each requested facet among
+// the books matching params, in one query.
+func countFacets(db *gorm.DB, index SearchIndex, params BookListParams) (map[string][]FacetCount, error) {
+	facets := make(map[string][]FacetCount)
+	var parts []string
+	for _, facet := range params.Facets {
+		sql := facetSQL(db, facet)
+		if sql == "" || facets[facet] != nil {
+			continue
+		}
+		facets[facet] = []FacetCount{}
+		parts = append(parts, "SELECT * FROM ("+sql+" ORDER BY count DESC, value ASC LIMIT "+
+			strconv.Itoa(maxFacetValues)+") AS facet_"+facet)
+	}
+	if len(parts) == 0 {
+		return facets, nil
+	}
+
+	filtered, _, err := filterBooks(db, index, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Facet string
+		Value string
+		Label *string
+		Count int64
+	}
+	if err := db.Raw(
+		"WITH matched AS (?) "+strings.Join(parts, " UNION ALL "),
+		filtered.Select("books.id"),
+	).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		fc := FacetCount{Value: row.Value, Count: row.Count}
+		switch {
+		case row.Facet == FacetDecade:
+			fc.Label = row.Value + "s"
+		case row.Label != nil:
+			fc.Label = *row.Label
+		}
+		facets[row.Facet] = append(facets[row.Facet], fc)
+	}
+	for _, counts := range facets {
+		sort.SliceStable(counts, func(i, j int) bool {
+			if counts[i].Count != counts[j].Count {
+				return counts[i].Count > counts[j].Count
+			}
+			return counts[i].Value < counts[j].Value
+		})
+	}
+	return facets, nil
+}
@@ -0,0 +1,396 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrAlreadyFollowing      = errors.New("already following the user")
+	ErrNotFollowing          = errors.New("not following the user")
+	ErrFollowRequestNotFound = errors.New("follow request not found")
+	ErrBlocked               = errors.New("blocked by the user")
+	ErrAlreadyBlocked        = errors.New("already blocking the user")
+	ErrNotBlocked            = errors.New("not blocking the user")
+)
+
+type FollowListParams struct {
+	Page     int
+	PageSize int
+}
+
+type FollowListResult struct {
+	Follows []model.Follow
+	Total   int64
+}
+
+type BlockListResult struct {
+	Blocks []model.Block
+	Total  int64
+}
+
+type FollowRepository interface {
+	// Follow makes followerID follow followeeID, or asks to when the
+	// followee's account is private.
+	Follow(ctx context.Context, followerID, followeeID string) (*model.Follow, error)
+	// Unfollow stops following the user or withdraws a pending request.
+	Unfollow(ctx context.Context, followerID, followeeID string) error
+	Followers(ctx context.Context, userID string, params FollowListParams) (FollowListResult, error)
+	Following(ctx context.Context, userID string, params FollowListParams) (FollowListResult, error)
+	// CanSeeConnections reports whether viewerID may list userID's
+	// followers and followings.
+	CanSeeConnections(ctx context.Context, viewerID, userID string) (bool, error)
+
+	Requests(ctx context.Context, userID string, params FollowListParams) (FollowListResult, error)
+	Approve(ctx context.Context, userID, followerID string) (*model.Follow, error)
+	Reject(ctx context.Context, userID, followerID string) error
+
+	Settings(ctx context.Context, userID string) (*model.AccountSettings, error)
+	// SetPrivate changes whether the account is private. Making it public
+	// approves every pending request.
+	SetPrivate(ctx context.Context, userID string, private bool) (*model.AccountSettings, error)
+
+	// Block blocks the user and removes any follow between the two.
+	Block(ctx context.Context, blockerID, blockedID string) error
+	Unblock(ctx context.Context, blockerID, blockedID string) error
+	Blocks(ctx context.Context, userID string, params FollowListParams) (BlockListResult, error)
+}
+
+type GormFollowRepository struct {
+	db *gorm.DB
+}
+
+func NewFollowRepository(db *gorm.DB) FollowRepository {
+	return &GormFollowRepository{db: db}
+}
+
+func (r *GormFollowRepository) Follow(ctx context.Context, followerID, followeeID string) (*model.Follow, error) {
+	var follow model.Follow
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.Block{}).
+			Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)",
+				followeeID, followerID, followerID, followeeID).
+			Count(&count).Error; err != nil {
+
+			return err
+		}
+		if count > 0 {
+			return ErrBlocked
+		}
+
+		if err := tx.Model(&model.Follow{}).
+			Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+			Count(&count).Error; err != nil {
+
+			return err
+		}
+		if count > 0 {
+			return ErrAlreadyFollowing
+		}
+
+		settings, err := accountSettings(tx, followeeID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		follow = model.Follow{
+			FollowerID: followerID,
+			FolloweeID: followeeID,
+			Status:     model.FollowStatusAccepted,
+			CreatedAt:  now,
+			AcceptedAt: &now,
+		}
+		notification := model.Notification{
+			UserID: followeeID,
+			Type:   model.NotificationNewFollower,
+			Title:  "You have a new follower",
+			Body:   fmt.Sprintf("User %s started following you.", followerID),
+		}
+		if settings.Private {
+			follow.Status = model.FollowStatusPending
+			follow.AcceptedAt = nil
+			notification.Type = model.NotificationFollowRequest
+			notification.Title = "New follow request"
+			notification.Body = fmt.Sprintf("User %s asked to follow you.", followerID)
+		}
+
+		if err := tx.Create(&follow).Error; err != nil {
+			return err
+		}
+		return enqueueNotification(tx, &notification)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &follow, nil
+}
+
+func (r *GormFollowRepository) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	result := r.db.WithContext(ctx).
+		Delete(&model.Follow{}, "follower_id = ? AND followee_id = ?", followerID, followeeID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFollowing
+	}
+	return nil
+}
+
+// Followers lists who follows the user, most recent first.
+func (r *GormFollowRepository) Followers(ctx context.Context, userID string, params FollowListParams) (FollowListResult, error) {
+	return listFollows(r.db.WithContext(ctx).
+		Where("followee_id = ? AND status = ?", userID, model.FollowStatusAccepted), params)
+}
+
+// Following lists who the user follows, most recent first.
+func (r *GormFollowRepository) Following(ctx context.Context, userID string, params FollowListParams) (FollowListResult, error) {
+	return listFollows(r.db.WithContext(ctx).
+		Where("follower_id = ? AND status = ?", userID, model.FollowStatusAccepted), params)
+}
+
+func (r *GormFollowRepository) CanSeeConnections(ctx context.Context, viewerID, userID string) (bool, error) {
+	if viewerID != "" && viewerID == userID {
+		return true, nil
+	}
+
+	db := r.db.WithContext(ctx)
+
+	var count int64
+	if err := db.Model(&model.Block{}).
+		Where("blocker_id = ? AND blocked_id = ?", userID, viewerID).
+		Count(&count).Error; err != nil {
+
+		return false, err
+	}
+	if count > 0 {
+		return false, nil
+	}
+
+	settings, err := accountSettings(db, userID)
+	if err != nil {
+		return false, err
+	}
+	if !settings.Private {
+		return true, nil
+	}
+
+	if err := db.Model(&model.Follow{}).
+		Where("follower_id = ? AND followee_id = ? AND status = ?", viewerID, userID, model.FollowStatusAccepted).
+		Count(&count).Error; err != nil {
+
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Requests lists the pending requests to follow the user, oldest first.
+func (r *GormFollowRepository) Requests(ctx context.Context, userID string, params FollowListParams) (FollowListResult, error) {
+	params = normalizeFollowListParams(params)
+	db := r.db.WithContext(ctx).
+		Model(&model.Follow{}).
+		Where("followee_id = ? AND status = ?", userID, model.FollowStatusPending)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return FollowListResult{}, err
+	}
+
+	var follows []model.Follow
+	if err := db.Order("created_at ASC, follower_id ASC").
+		Limit(params.PageSize).
+		Offset((params.Page - 1) * params.PageSize).
+		Find(&follows).Error; err != nil {
+
+		return FollowListResult{}, err
+	}
+
+	return FollowListResult{Follows: follows, Total: total}, nil
+}
+
+func (r *GormFollowRepository) Approve(ctx context.Context, userID, followerID string) (*model.Follow, error) {
+	var follow model.Follow
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.First(&follow, "follower_id = ? AND followee_id = ? AND status = ?",
+			followerID, userID, model.FollowStatusPending).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrFollowRequestNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(&model.Follow{}).
+			Where("follower_id = ? AND followee_id = ?", followerID, userID).
+			Updates(map[string]any{"status": model.FollowStatusAccepted, "accepted_at": now}).Error; err != nil {
+
+			return err
+		}
+
+		follow.Status = model.FollowStatusAccepted
+		follow.AcceptedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &follow, nil
+}
+
+func (r *GormFollowRepository) Reject(ctx context.Context, userID, followerID string) error {
+	result := r.db.WithContext(ctx).
+		Delete(&model.Follow{}, "follower_id = ? AND followee_id = ? AND status = ?",
+			followerID, userID, model.FollowStatusPending)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrFollowRequestNotFound
+	}
+	return nil
+}
+
+func (r *GormFollowRepository) Settings(ctx context.Context, userID string) (*model.AccountSettings, error) {
+	return accountSettings(r.db.WithContext(ctx), userID)
+}
+
+func (r *GormFollowRepository) SetPrivate(ctx context.Context, userID string, private bool) (*model.AccountSettings, error) {
+	settings := model.AccountSettings{UserID: userID, Private: private, UpdatedAt: time.Now()}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"private", "updated_at"}),
+		}).Create(&settings).Error; err != nil {
+
+			return err
+		}
+
+		if private {
+			return nil
+		}
+		return tx.Model(&model.Follow{}).
+			Where("followee_id = ? AND status = ?", userID, model.FollowStatusPending).
+			Updates(map[string]any{"status": model.FollowStatusAccepted, "accepted_at": settings.UpdatedAt}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (r *GormFollowRepository) Block(ctx context.Context, blockerID, blockedID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.Block{}).
+			Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+			Count(&count).Error; err != nil {
+
+			return err
+		}
+		if count > 0 {
+			return ErrAlreadyBlocked
+		}
+
+		if err := tx.Where("(follower_id = ? AND followee_id = ?) OR (follower_id = ? AND followee_id = ?)",
+			blockerID, blockedID, blockedID, blockerID).
+			Delete(&model.Follow{}).Error; err != nil {
+
+			return err
+		}
+
+		return tx.Create(&model.Block{BlockerID: blockerID, BlockedID: blockedID}).Error
+	})
+}
+
+func (r *GormFollowRepository) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	result := r.db.WithContext(ctx).
+		Delete(&model.Block{}, "blocker_id = ? AND blocked_id = ?", blockerID, blockedID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotBlocked
+	}
+	return nil
+}
+
+// Blocks lists the users the user blocks, most recent first.
+func (r *GormFollowRepository) Blocks(ctx context.Context, userID string, params FollowListParams) (BlockListResult, error) {
+	params = normalizeFollowListParams(params)
+	db := r.db.WithContext(ctx).Model(&model.Block{}).Where("blocker_id = ?", userID)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return BlockListResult{}, err
+	}
+
+	var blocks []model.Block
+	if err := db.Order("created_at DESC, blocked_id ASC").
+		Limit(params.PageSize).
+		Offset((params.Page - 1) * params.PageSize).
+		Find(&blocks).Error; err != nil {
+
+		return BlockListResult{}, err
+	}
+
+	return BlockListResult{Blocks: blocks, Total: total}, nil
+}
+
+func listFollows(db *gorm.DB, params FollowListParams) (FollowListResult, error) {
+	params = normalizeFollowListParams(params)
+	db = db.Model(&model.Follow{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return FollowListResult{}, err
+	}
+
+	var follows []model.Follow
+	if err := db.Order("accepted_at DESC, follower_id ASC, followee_id ASC").
+		Limit(params.PageSize).
+		Offset((params.Page - 1) * params.PageSize).
+		Find(&follows).Error; err != nil {
+
+		return FollowListResult{}, err
+	}
+
+	return FollowListResult{Follows: follows, Total: total}, nil
+}
+
+func normalizeFollowListParams(params FollowListParams) FollowListParams {
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+	return params
+}
+
+// accountSettings returns the user's settings, or the defaults when they
+// never changed them.
+func accountSettings(db *gorm.DB, userID string) (*model.AccountSettings, error) {
+	var settings []model.AccountSettings
+	if err := db.Where("user_id = ?", userID).Limit(1).Find(&settings).Error; err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		return &model.AccountSettings{UserID: userID}, nil
+	}
+	return &settings[0], nil
+}
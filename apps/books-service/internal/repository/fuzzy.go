@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// DefaultFuzzyThreshold is the similarity, from 0 to 1, a title or author
+// name needs to match a fuzzy search: roughly the share of the search
+// term's trigrams it contains.
+const DefaultFuzzyThreshold = 0.5
+
+// maxSuggestions caps the "did you mean" alternatives offered for a
+// search that found nothing.
+const maxSuggestions = 5
+
+// similaritySQL returns an expression scoring, from 0 to 1, how well q
+// matches somewhere in the text column. Postgres uses pg_trgm's
+// word_similarity, so a short term can match a long title. Elsewhere it is
+// approximated by the share of q's trigrams found in the column, which is
+// coarser but tolerates the same kinds of typos.
+func similaritySQL(db *gorm.DB, column, q string) (string, []any) {
+	if db.Dialector.Name() == "postgres" {
+		return "word_similarity(?, " + column + ")", []any{q}
+	}
+
+	grams := trigrams(q)
+	if len(grams) == 0 {
+		return "(CASE WHEN LOWER(" + column + ") LIKE ? THEN 1.0 ELSE 0.0 END)",
+			[]any{"%" + strings.ToLower(strings.TrimSpace(q)) + "%"}
+	}
+
+	parts := make([]string, len(grams))
+	args := make([]any, len(grams))
+	for i, g := range grams {
+		parts[i] = "(CASE WHEN LOWER(" + column + ") LIKE ? THEN 1 ELSE 0 END)"
+		args[i] = "%" + g + "%"
+	}
+	return "((" + strings.Join(parts, " + ") + ") * 1.0 / " + strconv.Itoa(len(grams)) + ")", args
+}
+
+// bookSimilaritySQL scores a books row against q by the better of its
+// title and its author's name.
+func bookSimilaritySQL(db *gorm.DB, q string) (string, []any) {
+	title, titleArgs := similaritySQL(db, "books.title", q)
+	author, authorArgs := similaritySQL(db, "authors.name", q)
+
+	greatest := "MAX"
+	if db.Dialector.Name() == "postgres" {
+		greatest = "GREATEST"
+	}
+	return greatest + "(" + title + ", COALESCE((SELECT " + author +
+			" FROM authors WHERE authors.id = books.author_id), 0))",
+		append(titleArgs, authorArgs...)
+}
+
+// fuzzyBookMatchSQL returns a condition matching books whose title or
+// author's name is like q by at least threshold. Postgres uses pg_trgm's
+// <% operator so that the GIN trigram indexes on books.title and
+// authors.name can serve it; the operator compares against
+// pg_trgm.word_similarity_threshold, which withFuzzySearch sets.
+func fuzzyBookMatchSQL(db *gorm.DB, q string, threshold float64) (string, []any) {
+	if db.Dialector.Name() == "postgres" {
+		return "(? <% books.title OR books.author_id IN (SELECT authors.id FROM authors WHERE ? <% authors.name))",
+			[]any{q, q}
+	}
+
+	score, args := bookSimilaritySQL(db, q)
+	return score + " >= ?", append(args, threshold)
+}
+
+// similarToSQL returns a condition matching rows whose text column is like
+// q by at least threshold, by the <% operator on Postgres as in
+// fuzzyBookMatchSQL.
+func similarToSQL(db *gorm.DB, column, q string, threshold float64) (string, []any) {
+	if db.Dialector.Name() == "postgres" {
+		return "? <% " + column, []any{q}
+	}
+
+	score, args := similaritySQL(db, column, q)
+	return score + " >= ?", append(args, threshold)
+}
+
+// withFuzzySearch runs fn for a search by text. On Postgres it runs fn in
+// a transaction with pg_trgm.word_similarity_threshold set to the
+// search's threshold, as the <% operator reads it from the connection.
+// Otherwise fn runs on db as is.
+func withFuzzySearch(db *gorm.DB, params BookListParams, fn func(db *gorm.DB) error) error {
+	if params.Query == "" || db.Dialector.Name() != "postgres" {
+		return fn(db)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := setWordSimilarityThreshold(tx, fuzzyThreshold(params)); err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+// setWordSimilarityThreshold sets pg_trgm's word similarity threshold for
+// the rest of the transaction on Postgres.
+func setWordSimilarityThreshold(tx *gorm.DB, threshold float64) error {
+	if tx.Dialector.Name() != "postgres" {
+		return nil
+	}
+	// SET takes no bind parameters, so the number is written out.
+	return tx.Exec("SET LOCAL pg_trgm.word_similarity_threshold = " + strconv.FormatFloat(threshold, 'f', -1, 64)).Error
+}
+
+// trigrams returns the distinct three-letter runs within the words of s,
+// lowercased.
+func trigrams(s string) []string {
+	seen := make(map[string]bool)
+	var grams []string
+	for _, word := range strings.Fields(strings.ToLower(s)) {
+		runes := []rune(word)
+		for i := 0; i+3 <= len(runes); i++ {
+			g := string(runes[i : i+3])
+			if strings.ContainsAny(g, "%_\\") || seen[g] {
+				continue
+			}
+			seen[g] = true
+			grams = append(grams, g)
+		}
+	}
+	return grams
+}
+
+// suggestSearchTerms finds the visible book titles and author names most
+// like q, for offering alternatives when a search comes up empty. On
+// Postgres db must be in a transaction, as under withFuzzySearch.
+func suggestSearchTerms(db *gorm.DB, q string, threshold float64) ([]string, error) {
+	type candidate struct {
+		Term  string
+		Score float64
+	}
+
+	if err := setWordSimilarityThreshold(db, threshold); err != nil {
+		return nil, err
+	}
+
+	title, titleArgs := similaritySQL(db, "books.title", q)
+	titleMatch, titleMatchArgs := similarToSQL(db, "books.title", q, threshold)
+	var titles []candidate
+	if err := visibleBooks(db.Model(&model.Book{})).
+		Select("books.title AS term, "+title+" AS score", titleArgs...).
+		Where(titleMatch, titleMatchArgs...).
+		Order("score DESC").
+		Limit(maxSuggestions).
+		Scan(&titles).Error; err != nil {
+
+		return nil, err
+	}
+
+	name, nameArgs := similaritySQL(db, "authors.name", q)
+	nameMatch, nameMatchArgs := similarToSQL(db, "authors.name", q, threshold)
+	var authors []candidate
+	if err := db.Table("authors").
+		Select("authors.name AS term, "+name+" AS score", nameArgs...).
+		Where(nameMatch, nameMatchArgs...).
+		Where("authors.id IN (?)", visibleBooks(db.Model(&model.Book{})).Select("books.author_id")).
+		Order("score DESC").
+		Limit(maxSuggestions).
+		Scan(&authors).Error; err != nil {
+
+		return nil, err
+	}
+
+	candidates := append(titles, authors...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	seen := make(map[string]bool)
+	terms := make([]string, 0, maxSuggestions)
+	for _, c := range candidates {
+		key := strings.ToLower(c.Term)
+		if seen[key] || strings.EqualFold(c.Term, q) {
+			continue
+		}
+		seen[key] = true
+		terms = append(terms, c.Term)
+		if len(terms) == maxSuggestions {
+			break
+		}
+	}
+	return terms, nil
+}
@@ -0,0 +1,359 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotGroupMember     = errors.New("not a member of the group")
+	ErrAlreadyGroupMember = errors.New("already a member of the group")
+	ErrGroupOwnerFixed    = errors.New("the group owner's membership cannot be changed")
+	ErrInviteNotFound     = errors.New("invite not found")
+	ErrInviteExpired      = errors.New("invite has expired")
+)
+
+// UserGroup is a group as seen by one of its members.
+type UserGroup struct {
+	Group       model.Group
+	Role        string
+	MemberCount int64
+}
+
+type GroupRepository interface {
+	Create(ctx context.Context, group *model.Group, ownerID string) error
+	ListForUser(ctx context.Context, userID string) ([]UserGroup, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Group, error)
+	Update(ctx context.Context, group *model.Group) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	Membership(ctx context.Context, groupID uuid.UUID, userID string) (*model.GroupMember, error)
+	Members(ctx context.Context, groupID uuid.UUID) ([]model.GroupMember, error)
+	SetRole(ctx context.Context, groupID uuid.UUID, userID, role string) (*model.GroupMember, error)
+	RemoveMember(ctx context.Context, groupID uuid.UUID, userID string) error
+
+	CreateInvite(ctx context.Context, invite *model.GroupInvite) error
+	ListInvites(ctx context.Context, groupID uuid.UUID) ([]model.GroupInvite, error)
+	RevokeInvite(ctx context.Context, groupID, inviteID uuid.UUID) error
+	Join(ctx context.Context, token, userID string) (*model.GroupMember, error)
+}
+
+type GormGroupRepository struct {
+	db *gorm.DB
+}
+
+func NewGroupRepository(db *gorm.DB) GroupRepository {
+	return &GormGroupRepository{db: db}
+}
+
+// Create makes the group with ownerID as its owner.
+func (r *GormGroupRepository) Create(ctx context.Context, group *model.Group, ownerID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(group).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("Group").Create(&model.GroupMember{
+			GroupID:  group.ID,
+			UserID:   ownerID,
+			Role:     model.GroupRoleOwner,
+			JoinedAt: group.CreatedAt,
+		}).Error
+	})
+}
+
+// ListForUser returns the groups the user belongs to, by name.
+func (r *GormGroupRepository) ListForUser(ctx context.Context, userID string) ([]UserGroup, error) {
+	db := r.db.WithContext(ctx)
+
+	var memberships []model.GroupMember
+	if err := db.Preload("Group").
+		Joins("JOIN groups ON groups.id = group_members.group_id").
+		Where("group_members.user_id = ?", userID).
+		Order("groups.name ASC").
+		Find(&memberships).Error; err != nil {
+
+		return nil, err
+	}
+	if len(memberships) == 0 {
+		return []UserGroup{}, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(memberships))
+	for _, m := range memberships {
+		ids = append(ids, m.GroupID)
+	}
+
+	var counts []struct {
+		GroupID uuid.UUID
+		Count   int64
+	}
+	if err := db.Model(&model.GroupMember{}).
+		Select("group_id, COUNT(*) AS count").
+		Where("group_id IN ?", ids).
+		Group("group_id").
+		Scan(&counts).Error; err != nil {
+
+		return nil, err
+	}
+	byGroup := make(map[uuid.UUID]int64, len(counts))
+	for _, c := range counts {
+		byGroup[c.GroupID] = c.Count
+	}
+
+	groups := make([]UserGroup, 0, len(memberships))
+	for _, m := range memberships {
+		groups = append(groups, UserGroup{Group: m.Group, Role: m.Role, MemberCount: byGroup[m.GroupID]})
+	}
+	return groups, nil
+}
+
+func (r *GormGroupRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Group, error) {
+	var group model.Group
+	if err := r.db.WithContext(ctx).First(&group, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *GormGroupRepository) Update(ctx context.Context, group *model.Group) error {
+	return r.db.WithContext(ctx).
+		Model(&model.Group{}).
+		Where("id = ?", group.ID).
+		Updates(map[string]any{
+			"name":        group.Name,
+			"description": group.Description,
+			"updated_at":  time.Now(),
+		}).Error
+}
+
+// Delete removes the group, its memberships and invites, and stops
+// sharing copies with it.
+func (r *GormGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := unshareCopies(tx, id, ""); err != nil {
+			return err
+		}
+		if err := tx.Where("group_id = ?", id).Delete(&model.GroupInvite{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("group_id = ?", id).Delete(&model.GroupMember{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&model.Group{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// Membership returns the user's membership of the group, or
+// ErrNotGroupMember.
+func (r *GormGroupRepository) Membership(ctx context.Context, groupID uuid.UUID, userID string) (*model.GroupMember, error) {
+	var member model.GroupMember
+	err := r.db.WithContext(ctx).
+		First(&member, "group_id = ? AND user_id = ?", groupID, userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotGroupMember
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// Members lists the group's members, longest-standing first.
+func (r *GormGroupRepository) Members(ctx context.Context, groupID uuid.UUID) ([]model.GroupMember, error) {
+	var members []model.GroupMember
+	if err := r.db.WithContext(ctx).
+		Where("group_id = ?", groupID).
+		Order("joined_at ASC, user_id ASC").
+		Find(&members).Error; err != nil {
+
+		return nil, err
+	}
+	return members, nil
+}
+
+// SetRole makes a member an admin or a plain member. The owner's role is
+// fixed.
+func (r *GormGroupRepository) SetRole(ctx context.Context, groupID uuid.UUID, userID, role string) (*model.GroupMember, error) {
+	member, err := r.Membership(ctx, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == model.GroupRoleOwner {
+		return nil, ErrGroupOwnerFixed
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&model.GroupMember{}).
+		Where("group_id = ? AND user_id = ?", groupID, userID).
+		Update("role", role).Error; err != nil {
+
+		return nil, err
+	}
+
+	member.Role = role
+	return member, nil
+}
+
+// RemoveMember takes the user out of the group. Their copies shared with
+// the group stop being shared with it.
+func (r *GormGroupRepository) RemoveMember(ctx context.Context, groupID uuid.UUID, userID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var member model.GroupMember
+		err := tx.First(&member, "group_id = ? AND user_id = ?", groupID, userID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotGroupMember
+		}
+		if err != nil {
+			return err
+		}
+		if member.Role == model.GroupRoleOwner {
+			return ErrGroupOwnerFixed
+		}
+
+		if err := unshareCopies(tx, groupID, userID); err != nil {
+			return err
+		}
+
+		return tx.Where("group_id = ? AND user_id = ?", groupID, userID).
+			Delete(&model.GroupMember{}).Error
+	})
+}
+
+// CreateInvite stores the invite with a fresh random token.
+func (r *GormGroupRepository) CreateInvite(ctx context.Context, invite *model.GroupInvite) error {
+	token, err := newInviteToken()
+	if err != nil {
+		return err
+	}
+	invite.Token = token
+
+	return r.db.WithContext(ctx).Omit("Group").Create(invite).Error
+}
+
+// ListInvites returns the group's invites that can still be used, newest
+// first.
+func (r *GormGroupRepository) ListInvites(ctx context.Context, groupID uuid.UUID) ([]model.GroupInvite, error) {
+	var invites []model.GroupInvite
+	if err := r.db.WithContext(ctx).
+		Where("group_id = ? AND expires_at > ?", groupID, time.Now()).
+		Order("created_at DESC").
+		Find(&invites).Error; err != nil {
+
+		return nil, err
+	}
+	return invites, nil
+}
+
+func (r *GormGroupRepository) RevokeInvite(ctx context.Context, groupID, inviteID uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Delete(&model.GroupInvite{}, "id = ? AND group_id = ?", inviteID, groupID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInviteNotFound
+	}
+	return nil
+}
+
+// Join adds the user to the group the invite is for.
+func (r *GormGroupRepository) Join(ctx context.Context, token, userID string) (*model.GroupMember, error) {
+	var member model.GroupMember
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var invite model.GroupInvite
+		err := tx.First(&invite, "token = ?", token).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInviteNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !invite.ExpiresAt.After(time.Now()) {
+			return ErrInviteExpired
+		}
+
+		var count int64
+		if err := tx.Model(&model.GroupMember{}).
+			Where("group_id = ? AND user_id = ?", invite.GroupID, userID).
+			Count(&count).Error; err != nil {
+
+			return err
+		}
+		if count > 0 {
+			return ErrAlreadyGroupMember
+		}
+
+		member = model.GroupMember{
+			GroupID:  invite.GroupID,
+			UserID:   userID,
+			Role:     model.GroupRoleMember,
+			JoinedAt: time.Now(),
+		}
+		if err := tx.Omit("Group").Create(&member).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.GroupInvite{}).
+			Where("id = ?", invite.ID).
+			Update("uses", gorm.Expr("uses + 1")).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// unshareCopies stops sharing copies with the group, only ownerID's when
+// it is set. A copy left shared with no group becomes private rather than
+// visible to all of its owner's other groups.
+func unshareCopies(tx *gorm.DB, groupID uuid.UUID, ownerID string) error {
+	q := tx.Model(&model.CopyGroup{}).Where("group_id = ?", groupID)
+	if ownerID != "" {
+		q = q.Where("copy_id IN (SELECT id FROM copies WHERE owner_id = ?)", ownerID)
+	}
+
+	var copyIDs []uuid.UUID
+	if err := q.Pluck("copy_id", &copyIDs).Error; err != nil {
+		return err
+	}
+	if len(copyIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("group_id = ? AND copy_id IN ?", groupID, copyIDs).
+		Delete(&model.CopyGroup{}).Error; err != nil {
+
+		return err
+	}
+
+	return tx.Model(&model.Copy{}).
+		Where("id IN ? AND visibility = ?", copyIDs, model.VisibilityGroups).
+		Where("NOT EXISTS (SELECT 1 FROM copy_groups cg WHERE cg.copy_id = copies.id)").
+		Update("visibility", model.VisibilityPrivate).Error
+}
+
+func newInviteToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,441 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrHoldAlreadyExists = errors.New("already in the hold queue for this book")
+	ErrHoldNotFound      = errors.New("not in the hold queue for this book")
+	ErrNoHoldOffer       = errors.New("no copy is on offer for this hold")
+	ErrHoldOfferExpired  = errors.New("the hold offer has expired")
+)
+
+// DefaultHoldOfferTTL is how long the head of a queue has to collect an
+// offered copy before it moves on to the next person.
+const DefaultHoldOfferTTL = 48 * time.Hour
+
+type HoldRepository interface {
+	Join(ctx context.Context, hold *model.Hold) error
+	Leave(ctx context.Context, bookID uuid.UUID, userID string) error
+	Accept(ctx context.Context, bookID uuid.UUID, userID string) (*model.Hold, error)
+	Find(ctx context.Context, bookID uuid.UUID, userID string) (*model.Hold, int, error)
+	QueueLength(ctx context.Context, bookID uuid.UUID) (int64, error)
+	OfferAvailable(ctx context.Context, bookID uuid.UUID) (int, error)
+	ExpireOffers(ctx context.Context, now time.Time) (int, error)
+}
+
+type GormHoldRepository struct {
+	db       *gorm.DB
+	offerTTL time.Duration
+	now      func() time.Time
+}
+
+func NewHoldRepository(db *gorm.DB, offerTTL time.Duration) HoldRepository {
+	return newGormHoldRepository(db, offerTTL)
+}
+
+func newGormHoldRepository(db *gorm.DB, offerTTL time.Duration) *GormHoldRepository {
+	if offerTTL <= 0 {
+		offerTTL = DefaultHoldOfferTTL
+	}
+	return &GormHoldRepository{db: db, offerTTL: offerTTL, now: time.Now}
+}
+
+// Join puts the user at the back of the book's queue. If a copy is already
+// available and nobody is ahead of them, the copy is offered straight away.
+func (r *GormHoldRepository) Join(ctx context.Context, hold *model.Hold) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, hold.BookID); err != nil {
+			return err
+		}
+		if err := ensureBookVisible(tx, hold.BookID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := activeHolds(tx, hold.BookID).
+			Where("user_id = ?", hold.UserID).
+			Count(&count).Error; err != nil {
+
+			return err
+		}
+		if count > 0 {
+			return ErrHoldAlreadyExists
+		}
+
+		hold.Status = model.HoldStatusWaiting
+		if err := tx.Omit("Book").Create(hold).Error; err != nil {
+			return err
+		}
+
+		if _, err := r.offerCopies(tx, hold.BookID); err != nil {
+			return err
+		}
+		return tx.First(hold, "id = ?", hold.ID).Error
+	})
+}
+
+// Leave removes the user from the queue. Giving up an offer releases the
+// copy to whoever is next.
+func (r *GormHoldRepository) Leave(ctx context.Context, bookID uuid.UUID, userID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, bookID); err != nil {
+			return err
+		}
+
+		hold, err := findActiveHold(tx, bookID, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := r.closeHold(tx, hold, model.HoldStatusCancelled); err != nil {
+			return err
+		}
+
+		_, err = r.offerCopies(tx, bookID)
+		return err
+	})
+}
+
+// Accept lends the offered copy to the user, which also takes them out of
+// the queue. A lapsed offer is expired and passed on instead.
+func (r *GormHoldRepository) Accept(ctx context.Context, bookID uuid.UUID, userID string) (*model.Hold, error) {
+	var hold *model.Hold
+	lapsed := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, bookID); err != nil {
+			return err
+		}
+
+		var err error
+		hold, err = findActiveHold(tx, bookID, userID)
+		if err != nil {
+			return err
+		}
+		if hold.Status != model.HoldStatusOffered {
+			return ErrNoHoldOffer
+		}
+
+		if !hold.OfferExpiresAt.After(r.now()) {
+			if err := r.closeHold(tx, hold, model.HoldStatusExpired); err != nil {
+				return err
+			}
+			// Commit the expiry and pass the copy on before reporting it.
+			lapsed = true
+			_, err := r.offerCopies(tx, bookID)
+			return err
+		}
+
+		now := r.now()
+		if err := tx.Model(&model.Copy{}).
+			Where("id = ?", hold.CopyID).
+			Updates(map[string]any{
+				"status":           model.CopyStatusOnLoan,
+				"borrower_id":      hold.UserID,
+				"due_at":           nil,
+				"reminder_sent_at": nil,
+				"overdue_at":       nil,
+				"updated_at":       now,
+			}).Error; err != nil {
+
+			return err
+		}
+		var cp model.Copy
+		if err := tx.Select("id", "owner_id").First(&cp, "id = ?", hold.CopyID).Error; err != nil {
+			return err
+		}
+		if err := recordActivity(tx, cp.OwnerID, model.ActivityCopyLent, bookID, nil, &cp.ID); err != nil {
+			return err
+		}
+
+		hold.Status = model.HoldStatusFulfilled
+		hold.UpdatedAt = now
+		return tx.Model(&model.Hold{}).
+			Where("id = ?", hold.ID).
+			Updates(map[string]any{"status": hold.Status, "updated_at": now}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if lapsed {
+		return nil, ErrHoldOfferExpired
+	}
+
+	return hold, nil
+}
+
+// Find returns the user's active hold on a book and their position in the
+// queue: 0 while a copy is on offer to them, otherwise 1 for the head.
+func (r *GormHoldRepository) Find(ctx context.Context, bookID uuid.UUID, userID string) (*model.Hold, int, error) {
+	db := r.db.WithContext(ctx)
+
+	hold, err := findActiveHold(db, bookID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if hold.Status == model.HoldStatusOffered {
+		return hold, 0, nil
+	}
+
+	var ahead int64
+	if err := db.Model(&model.Hold{}).
+		Where("book_id = ? AND status = ?", bookID, model.HoldStatusWaiting).
+		Where("created_at < ? OR (created_at = ? AND id < ?)", hold.CreatedAt, hold.CreatedAt, hold.ID).
+		Count(&ahead).Error; err != nil {
+
+		return nil, 0, err
+	}
+
+	return hold, int(ahead) + 1, nil
+}
+
+// QueueLength counts the people still waiting for a copy of the book.
+func (r *GormHoldRepository) QueueLength(ctx context.Context, bookID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.Hold{}).
+		Where("book_id = ? AND status = ?", bookID, model.HoldStatusWaiting).
+		Count(&count).Error
+	return count, err
+}
+
+// OfferAvailable offers the book's available copies to the front of its
+// queue, one copy per person, and reports how many offers were made. Call
+// it whenever a copy may have become available.
+func (r *GormHoldRepository) OfferAvailable(ctx context.Context, bookID uuid.UUID) (int, error) {
+	var offered int
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, bookID); err != nil {
+			return err
+		}
+
+		var err error
+		offered, err = r.offerCopies(tx, bookID)
+		return err
+	})
+
+	return offered, err
+}
+
+// ExpireOffers lapses every offer whose window closed before now and passes
+// the copies on to the next people in line. It reports how many offers
+// lapsed.
+func (r *GormHoldRepository) ExpireOffers(ctx context.Context, now time.Time) (int, error) {
+	var due []model.Hold
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND offer_expires_at <= ?", model.HoldStatusOffered, now).
+		Order("offer_expires_at ASC").
+		Find(&due).Error; err != nil {
+
+		return 0, err
+	}
+
+	expired := 0
+	for _, h := range due {
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := lockBook(tx, h.BookID); err != nil {
+				return err
+			}
+			// Re-read under the lock: the holder may have accepted or
+			// left since the scan above.
+			var hold model.Hold
+			if err := tx.First(&hold, "id = ?", h.ID).Error; err != nil {
+				return err
+			}
+			if hold.Status != model.HoldStatusOffered || hold.OfferExpiresAt.After(now) {
+				return nil
+			}
+
+			if err := r.closeHold(tx, &hold, model.HoldStatusExpired); err != nil {
+				return err
+			}
+			expired++
+
+			_, err := r.offerCopies(tx, hold.BookID)
+			return err
+		})
+		if err != nil && !errors.Is(err, ErrBookNotFound) {
+			return expired, err
+		}
+	}
+
+	return expired, nil
+}
+
+// offerCopies pairs the oldest waiting holds with the book's available
+// copies, reserving each copy for the hold it is offered to. Copies
+// restricted to groups only go to holders who can see them. The caller
+// must hold the book lock.
+func (r *GormHoldRepository) offerCopies(tx *gorm.DB, bookID uuid.UUID) (int, error) {
+	var waiting []model.Hold
+	if err := tx.Where("book_id = ? AND status = ?", bookID, model.HoldStatusWaiting).
+		Order("created_at ASC, id ASC").
+		Find(&waiting).Error; err != nil {
+
+		return 0, err
+	}
+
+	offered := 0
+	for _, hold := range waiting {
+		var available int64
+		if err := tx.Model(&model.Copy{}).
+			Where("book_id = ? AND status = ?", bookID, model.CopyStatusAvailable).
+			Count(&available).Error; err != nil {
+
+			return offered, err
+		}
+		if available == 0 {
+			return offered, nil
+		}
+
+		var copies []model.Copy
+		if err := visibleCopies(tx.Model(&model.Copy{}), hold.UserID).
+			Where("book_id = ? AND status = ?", bookID, model.CopyStatusAvailable).
+			Order("created_at ASC, id ASC").
+			Limit(1).
+			Find(&copies).Error; err != nil {
+
+			return offered, err
+		}
+		if len(copies) == 0 {
+			continue
+		}
+		cp := copies[0]
+
+		now := r.now()
+		if err := tx.Model(&model.Copy{}).
+			Where("id = ?", cp.ID).
+			Updates(map[string]any{"status": model.CopyStatusReserved, "updated_at": now}).Error; err != nil {
+
+			return offered, err
+		}
+
+		expires := now.Add(r.offerTTL)
+		if err := tx.Model(&model.Hold{}).
+			Where("id = ?", hold.ID).
+			Updates(map[string]any{
+				"status":           model.HoldStatusOffered,
+				"copy_id":          cp.ID,
+				"offered_at":       now,
+				"offer_expires_at": expires,
+				"updated_at":       now,
+			}).Error; err != nil {
+
+			return offered, err
+		}
+
+		if err := notifyHold(tx, hold, model.NotificationHoldAvailable,
+			"Your hold is ready",
+			"A copy of %q is being held for you until %s.", expires); err != nil {
+
+			return offered, err
+		}
+
+		offered++
+	}
+
+	return offered, nil
+}
+
+// closeHold ends an active hold with the given status, putting a copy that
+// was on offer back into circulation.
+func (r *GormHoldRepository) closeHold(tx *gorm.DB, hold *model.Hold, status string) error {
+	now := r.now()
+
+	if hold.Status == model.HoldStatusOffered && hold.CopyID != nil {
+		if err := tx.Model(&model.Copy{}).
+			Where("id = ? AND status = ?", *hold.CopyID, model.CopyStatusReserved).
+			Updates(map[string]any{"status": model.CopyStatusAvailable, "updated_at": now}).Error; err != nil {
+
+			return err
+		}
+	}
+
+	if status == model.HoldStatusExpired {
+		if err := notifyHold(tx, *hold, model.NotificationHoldExpired,
+			"Your hold offer expired",
+			"The copy of %q held for you until %s has passed to the next person in line.", *hold.OfferExpiresAt); err != nil {
+
+			return err
+		}
+	}
+
+	hold.Status = status
+	hold.UpdatedAt = now
+	return tx.Model(&model.Hold{}).
+		Where("id = ?", hold.ID).
+		Updates(map[string]any{"status": status, "updated_at": now}).Error
+}
+
+// notifyHold tells the holder about their hold. body is a format string
+// taking the book's title and a time.
+func notifyHold(tx *gorm.DB, hold model.Hold, kind, title, body string, at time.Time) error {
+	var bookTitle string
+	if err := tx.Model(&model.Book{}).
+		Select("title").
+		Where("id = ?", hold.BookID).
+		Scan(&bookTitle).Error; err != nil {
+
+		return err
+	}
+
+	bookID := hold.BookID
+	return enqueueNotification(tx, &model.Notification{
+		UserID: hold.UserID,
+		Type:   kind,
+		Title:  title,
+		Body:   fmt.Sprintf(body, bookTitle, at.UTC().Format("Jan 2, 15:04 UTC")),
+		BookID: &bookID,
+	})
+}
+
+func activeHolds(db *gorm.DB, bookID uuid.UUID) *gorm.DB {
+	return db.Model(&model.Hold{}).
+		Where("book_id = ? AND status IN ?", bookID, []string{model.HoldStatusWaiting, model.HoldStatusOffered})
+}
+
+func findActiveHold(db *gorm.DB, bookID uuid.UUID, userID string) (*model.Hold, error) {
+	var hold model.Hold
+	err := activeHolds(db, bookID).Where("user_id = ?", userID).First(&hold).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrHoldNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// lockBook takes a row lock on the book for the rest of the transaction so
+// that queue changes for one book are applied one at a time: two copies
+// coming back at once are offered to two different people rather than
+// both to the head of the queue. SQLite has no row locks, but it only
+// allows one writer at a time anyway.
+func lockBook(tx *gorm.DB, bookID uuid.UUID) error {
+	var book model.Book
+	err := forUpdate(tx.Model(&model.Book{}).Select("id").Where("id = ?", bookID)).First(&book).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrBookNotFound
+	}
+	return err
+}
+
+// forUpdate locks the rows q reads for the rest of the transaction where
+// the database has row locks.
+func forUpdate(q *gorm.DB) *gorm.DB {
+	if q.Dialector.Name() == "postgres" {
+		return q.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	return q
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+// Relations book and author queries can load along with their rows.
+const (
+	IncludeAuthor    = "author"
+	IncludeTags      = "tags"
+	IncludeSeries    = "series"
+	IncludeWork      = "work"
+	IncludePublisher = "publisher"
+	IncludeBooks     = "books"
+)
+
+var (
+	BookIncludes   = []string{IncludeAuthor, IncludeTags, IncludeSeries, IncludeWork, IncludePublisher}
+	AuthorIncludes = []string{IncludeBooks}
+)
+
+// included reports whether relation should be loaded. A nil include
+// stands for every relation, so callers that don't choose get them all;
+// an empty one loads none.
+func included(include []string, relation string) bool {
+	return include == nil || slices.Contains(include, relation)
+}
+
+// preloadBook preloads the book relations include names.
+func preloadBook(db *gorm.DB, include []string) *gorm.DB {
+	if included(include, IncludeAuthor) {
+		db = db.Preload("Author")
+	}
+	if included(include, IncludeTags) {
+		db = db.Preload("Tags", orderTagsByName)
+	}
+	if included(include, IncludeSeries) {
+		db = db.Preload("Series")
+	}
+	if included(include, IncludeWork) {
+		db = db.Preload("Work")
+	}
+	if included(include, IncludePublisher) {
+		db = db.Preload("Publisher")
+	}
+	return db
+}
+
+// preloadAuthor preloads the author relations include names.
+func preloadAuthor(db *gorm.DB, include []string) *gorm.DB {
+	if included(include, IncludeBooks) {
+		db = db.Preload("Books", visibleBooks)
+	}
+	return db
+}
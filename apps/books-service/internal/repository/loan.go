@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// LoanRepository follows copies that are on loan towards their due dates.
+// A copy's due date is a day: the loan is due soon from lead before the
+// start of that day, and overdue once the day has passed, both in UTC.
+type LoanRepository interface {
+	RemindDue(ctx context.Context, now time.Time, lead time.Duration) (int, error)
+	MarkOverdue(ctx context.Context, now time.Time) (int, error)
+}
+
+type GormLoanRepository struct {
+	db *gorm.DB
+}
+
+func NewLoanRepository(db *gorm.DB) LoanRepository {
+	return &GormLoanRepository{db: db}
+}
+
+// RemindDue reminds the borrowers of copies falling due within lead of now
+// that they are due back, once per due date, and reports how many were
+// reminded. Loans without a known borrower are skipped.
+func (r *GormLoanRepository) RemindDue(ctx context.Context, now time.Time, lead time.Duration) (int, error) {
+	today := startOfDay(now)
+
+	var due []model.Copy
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND borrower_id IS NOT NULL AND reminder_sent_at IS NULL", model.CopyStatusOnLoan).
+		Where("due_at >= ? AND due_at <= ?", today, now.Add(lead)).
+		Order("due_at ASC").
+		Find(&due).Error; err != nil {
+
+		return 0, err
+	}
+
+	reminded := 0
+	for _, cp := range due {
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			// Claiming the copy first means a copy returned or reminded
+			// since the scan above is left alone.
+			result := tx.Model(&model.Copy{}).
+				Where("id = ? AND status = ? AND reminder_sent_at IS NULL", cp.ID, model.CopyStatusOnLoan).
+				Where("due_at = ?", *cp.DueAt).
+				Update("reminder_sent_at", now)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+
+			if err := notifyLoan(tx, cp, *cp.BorrowerID, model.NotificationLoanDueSoon,
+				"A loan is due back soon",
+				"The copy of %q you borrowed is due back on %s."); err != nil {
+
+				return err
+			}
+			reminded++
+			return nil
+		})
+		if err != nil {
+			return reminded, err
+		}
+	}
+
+	return reminded, nil
+}
+
+// MarkOverdue marks copies still on loan after their due date as overdue
+// and tells their owner and borrower, once per due date. It reports how
+// many loans were marked.
+func (r *GormLoanRepository) MarkOverdue(ctx context.Context, now time.Time) (int, error) {
+	var due []model.Copy
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND overdue_at IS NULL AND due_at < ?", model.CopyStatusOnLoan, startOfDay(now)).
+		Order("due_at ASC").
+		Find(&due).Error; err != nil {
+
+		return 0, err
+	}
+
+	marked := 0
+	for _, cp := range due {
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&model.Copy{}).
+				Where("id = ? AND status = ? AND overdue_at IS NULL", cp.ID, model.CopyStatusOnLoan).
+				Where("due_at = ?", *cp.DueAt).
+				Update("overdue_at", now)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+
+			if err := notifyLoan(tx, cp, cp.OwnerID, model.NotificationLoanOverdue,
+				"A loan is overdue",
+				"The copy of %q you lent out was due back on %s."); err != nil {
+
+				return err
+			}
+			if cp.BorrowerID != nil {
+				if err := notifyLoan(tx, cp, *cp.BorrowerID, model.NotificationLoanOverdue,
+					"A loan is overdue",
+					"The copy of %q you borrowed was due back on %s."); err != nil {
+
+					return err
+				}
+			}
+			marked++
+			return nil
+		})
+		if err != nil {
+			return marked, err
+		}
+	}
+
+	return marked, nil
+}
+
+// notifyLoan tells userID about the loan of cp. body is a format string
+// taking the book's title and the due date.
+func notifyLoan(tx *gorm.DB, cp model.Copy, userID, kind, title, body string) error {
+	var bookTitle string
+	if err := tx.Model(&model.Book{}).
+		Select("title").
+		Where("id = ?", cp.BookID).
+		Scan(&bookTitle).Error; err != nil {
+
+		return err
+	}
+
+	bookID := cp.BookID
+	return enqueueNotification(tx, &model.Notification{
+		UserID: userID,
+		Type:   kind,
+		Title:  title,
+		Body:   fmt.Sprintf(body, bookTitle, cp.DueAt.UTC().Format("Jan 2, 2006")),
+		BookID: &bookID,
+	})
+}
+
+func startOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+)
+
+func TestGormLoanRepository_RemindAndMarkOverdue(t *testing.T) {
+	db := setupTestDB(t)
+	copies := NewCopyRepository(db)
+	loans := NewLoanRepository(db)
+	ctx := context.Background()
+
+	author, _ := seedBooks(t, db)
+	var book model.Book
+	if err := db.First(&book, "author_id = ?", author.ID).Error; err != nil {
+		t.Fatalf("failed to load book: %v", err)
+	}
+
+	now := time.Date(2025, 12, 6, 9, 0, 0, 0, time.UTC)
+	day := func(offset int) *time.Time {
+		d := startOfDay(now).AddDate(0, 0, offset)
+		return &d
+	}
+	borrower := "borrower"
+
+	lend := func(due *time.Time) model.Copy {
+		t.Helper()
+		cp := model.Copy{BookID: book.ID, OwnerID: "owner"}
+		if err := copies.Create(ctx, &cp); err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+		cp.Status = model.CopyStatusOnLoan
+		cp.BorrowerID = &borrower
+		cp.DueAt = due
+		if err := copies.Update(ctx, &cp); err != nil {
+			t.Fatalf("Update returned error: %v", err)
+		}
+		return cp
+	}
+	dueSoon := lend(day(1))
+	lend(day(5))
+	dueToday := lend(day(0))
+	late := lend(day(-1))
+
+	n, err := loans.RemindDue(ctx, now, 48*time.Hour)
+	if err != nil || n != 2 {
+		t.Fatalf("expected 2 reminders, got %d, %v", n, err)
+	}
+	if n, _ := loans.RemindDue(ctx, now, 48*time.Hour); n != 0 {
+		t.Fatalf("expected each loan reminded once, got %d more", n)
+	}
+
+	n, err = loans.MarkOverdue(ctx, now)
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 overdue loan, got %d, %v", n, err)
+	}
+	if n, _ := loans.MarkOverdue(ctx, now); n != 0 {
+		t.Fatalf("expected each loan marked once, got %d more", n)
+	}
+
+	var stored model.Copy
+	if err := db.First(&stored, "id = ?", late.ID).Error; err != nil {
+		t.Fatalf("failed to reload copy: %v", err)
+	}
+	if stored.OverdueAt == nil {
+		t.Fatalf("expected the late copy to be marked overdue")
+	}
+
+	var notifications []model.Notification
+	if err := db.Order("user_id, type").Find(&notifications).Error; err != nil {
+		t.Fatalf("failed to load notifications: %v", err)
+	}
+	var got []string
+	for _, n := range notifications {
+		got = append(got, n.UserID+" "+n.Type)
+	}
+	want := []string{"borrower loan-due-soon", "borrower loan-due-soon", "borrower loan-overdue", "owner loan-overdue"}
+	if len(got) != len(want) {
+		t.Fatalf("expected notifications %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected notifications %v, got %v", want, got)
+		}
+	}
+
+	// A new due date earns a new reminder; a returned copy drops its loan.
+	dueSoon.DueAt = day(2)
+	dueSoon.Status = ""
+	if err := copies.Update(ctx, &dueSoon); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	dueToday.Status = model.CopyStatusAvailable
+	if err := copies.Update(ctx, &dueToday); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	var returned model.Copy
+	if err := db.First(&returned, "id = ?", dueToday.ID).Error; err != nil {
+		t.Fatalf("failed to reload copy: %v", err)
+	}
+	if returned.BorrowerID != nil || returned.DueAt != nil || returned.ReminderSentAt != nil {
+		t.Fatalf("expected a returned copy to have no loan, got %+v", returned)
+	}
+
+	if n, _ := loans.RemindDue(ctx, now, 48*time.Hour); n != 1 {
+		t.Fatalf("expected a reminder for the new due date, got %d", n)
+	}
+}
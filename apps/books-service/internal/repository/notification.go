@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationListParams struct {
+	UnreadOnly bool
+	Limit      int
+	After      *Cursor
+}
+
+// NotificationRepository is the inbox store. Enqueue is also the way other
+// parts of the service send notifications.
+type NotificationRepository interface {
+	Enqueue(ctx context.Context, n *model.Notification) error
+	List(ctx context.Context, userID string, params NotificationListParams) ([]model.Notification, error)
+	UnreadCount(ctx context.Context, userID string) (int64, error)
+	MarkRead(ctx context.Context, userID string, id uuid.UUID) (*model.Notification, error)
+	MarkAllRead(ctx context.Context, userID string) (int64, error)
+	Preferences(ctx context.Context, userID string) ([]model.NotificationPreference, error)
+	SetPreferences(ctx context.Context, userID string, prefs []model.NotificationPreference) error
+}
+
+type GormNotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &GormNotificationRepository{db: db}
+}
+
+// Enqueue adds a notification to the user's inbox unless they turned its
+// type off.
+func (r *GormNotificationRepository) Enqueue(ctx context.Context, n *model.Notification) error {
+	return enqueueNotification(r.db.WithContext(ctx), n)
+}
+
+// List returns the user's notifications, newest first.
+func (r *GormNotificationRepository) List(ctx context.Context, userID string, params NotificationListParams) ([]model.Notification, error) {
+	db := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if params.UnreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+	if params.After != nil {
+		db = afterCursor(db, "notifications", params.After)
+	}
+
+	var notifications []model.Notification
+	if err := db.Order("created_at DESC, id DESC").
+		Limit(params.Limit).
+		Find(&notifications).Error; err != nil {
+
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *GormNotificationRepository) UnreadCount(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead marks one of the user's notifications read. Marking it again
+// keeps the original read time.
+func (r *GormNotificationRepository) MarkRead(ctx context.Context, userID string, id uuid.UUID) (*model.Notification, error) {
+	db := r.db.WithContext(ctx)
+
+	var n model.Notification
+	if err := db.First(&n, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	if n.ReadAt != nil {
+		return &n, nil
+	}
+
+	now := time.Now()
+	if err := db.Model(&n).Updates(map[string]any{"read_at": now, "updated_at": now}).Error; err != nil {
+		return nil, err
+	}
+	n.ReadAt = &now
+
+	return &n, nil
+}
+
+// MarkAllRead marks every unread notification of the user read and reports
+// how many there were.
+func (r *GormNotificationRepository) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&model.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Updates(map[string]any{"read_at": now, "updated_at": now})
+	return result.RowsAffected, result.Error
+}
+
+// Preferences returns the user's setting for every notification type,
+// filling in the enabled default for types they never changed.
+func (r *GormNotificationRepository) Preferences(ctx context.Context, userID string) ([]model.NotificationPreference, error) {
+	var stored []model.NotificationPreference
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Find(&stored).Error; err != nil {
+
+		return nil, err
+	}
+
+	byType := make(map[string]model.NotificationPreference, len(stored))
+	for _, p := range stored {
+		byType[p.Type] = p
+	}
+
+	prefs := make([]model.NotificationPreference, 0, len(model.NotificationTypes))
+	for _, t := range model.NotificationTypes {
+		p, ok := byType[t]
+		if !ok {
+			p = model.NotificationPreference{UserID: userID, Type: t, Enabled: true}
+		}
+		prefs = append(prefs, p)
+	}
+
+	return prefs, nil
+}
+
+func (r *GormNotificationRepository) SetPreferences(ctx context.Context, userID string, prefs []model.NotificationPreference) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for i := range prefs {
+		prefs[i].UserID = userID
+		prefs[i].UpdatedAt = now
+	}
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+		}).
+		Create(&prefs).Error
+}
+
+// enqueueNotification stores a notification unless the recipient turned
+// its type off. Repositories call it inside their own transactions so the
+// notification is only sent if the change it reports is committed.
+func enqueueNotification(db *gorm.DB, n *model.Notification) error {
+	var prefs []model.NotificationPreference
+	if err := db.Where("user_id = ? AND type = ?", n.UserID, n.Type).
+		Limit(1).
+		Find(&prefs).Error; err != nil {
+
+		return err
+	}
+	if len(prefs) > 0 && !prefs[0].Enabled {
+		return nil
+	}
+
+	return db.Create(n).Error
+}
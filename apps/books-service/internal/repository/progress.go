@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotCurrentlyReading = errors.New("book is not on the currently-reading shelf")
+	ErrPageOutOfRange      = errors.New("page exceeds the book's page count")
+)
+
+type ProgressRepository interface {
+	Log(ctx context.Context, entry *model.ProgressEntry) error
+	History(ctx context.Context, userID string, bookID uuid.UUID) ([]model.ProgressEntry, error)
+	StartedAt(ctx context.Context, userID string, bookID uuid.UUID) (*time.Time, error)
+}
+
+type GormProgressRepository struct {
+	db *gorm.DB
+}
+
+func NewProgressRepository(db *gorm.DB) ProgressRepository {
+	return &GormProgressRepository{db: db}
+}
+
+// Log records a progress entry for a book on the user's currently-reading
+// shelf. Page and percent are derived from each other when the book's page
+// count is known.
+func (r *GormProgressRepository) Log(ctx context.Context, entry *model.ProgressEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var book model.Book
+		err := visibleBooks(tx).Select("id", "page_count").First(&book, "books.id = ?", entry.BookID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrBookNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var reading int64
+		if err := tx.Model(&model.ShelfEntry{}).
+			Joins("JOIN shelves ON shelves.id = shelf_entries.shelf_id").
+			Where("shelves.user_id = ? AND shelves.slug = ?", entry.UserID, model.ShelfCurrentlyReading).
+			Where("shelf_entries.book_id = ?", entry.BookID).
+			Count(&reading).Error; err != nil {
+
+			return err
+		}
+		if reading == 0 {
+			return ErrNotCurrentlyReading
+		}
+
+		if book.PageCount != nil && *book.PageCount > 0 {
+			total := *book.PageCount
+			switch {
+			case entry.Page != nil:
+				if *entry.Page > total {
+					return ErrPageOutOfRange
+				}
+				percent := math.Round(float64(*entry.Page)/float64(total)*1000) / 10
+				entry.Percent = &percent
+			case entry.Percent != nil:
+				page := int(math.Round(*entry.Percent / 100 * float64(total)))
+				entry.Page = &page
+			}
+		}
+
+		if entry.RecordedAt.IsZero() {
+			entry.RecordedAt = time.Now()
+			if entry.SessionEnd != nil {
+				entry.RecordedAt = *entry.SessionEnd
+			}
+		}
+
+		return tx.Omit("Book").Create(entry).Error
+	})
+}
+
+// History returns the user's progress entries for a book, oldest first.
+func (r *GormProgressRepository) History(ctx context.Context, userID string, bookID uuid.UUID) ([]model.ProgressEntry, error) {
+	var entries []model.ProgressEntry
+
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND book_id = ?", userID, bookID).
+		Order("recorded_at ASC").
+		Find(&entries).Error; err != nil {
+
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// StartedAt returns when the user started reading the book, taken from the
+// built-in shelf the book is on. It is nil if no start date is recorded.
+func (r *GormProgressRepository) StartedAt(ctx context.Context, userID string, bookID uuid.UUID) (*time.Time, error) {
+	var entries []model.ShelfEntry
+
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN shelves ON shelves.id = shelf_entries.shelf_id").
+		Where("shelves.user_id = ? AND shelves.builtin = ?", userID, true).
+		Where("shelf_entries.book_id = ? AND shelf_entries.started_at IS NOT NULL", bookID).
+		Limit(1).
+		Find(&entries).Error; err != nil {
+
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	return entries[0].StartedAt, nil
+}
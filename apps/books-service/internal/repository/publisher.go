@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPublisherAlreadyExists = errors.New("publisher already exists")
+	ErrPublisherHasBooks      = errors.New("publisher still has books")
+)
+
+type PublisherRepository interface {
+	Create(ctx context.Context, publisher *model.Publisher) error
+	List(ctx context.Context) ([]model.Publisher, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Publisher, error)
+	Update(ctx context.Context, publisher *model.Publisher) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type GormPublisherRepository struct {
+	db *gorm.DB
+}
+
+func NewPublisherRepository(db *gorm.DB) PublisherRepository {
+	return &GormPublisherRepository{db: db}
+}
+
+func (r *GormPublisherRepository) Create(ctx context.Context, publisher *model.Publisher) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensurePublisherNameFree(tx, publisher.Name, uuid.Nil); err != nil {
+			return err
+		}
+		return tx.Create(publisher).Error
+	})
+}
+
+func (r *GormPublisherRepository) List(ctx context.Context) ([]model.Publisher, error) {
+	var publishers []model.Publisher
+
+	if err := r.db.WithContext(ctx).
+		Order("name ASC").
+		Find(&publishers).Error; err != nil {
+
+		return nil, err
+	}
+
+	return publishers, nil
+}
+
+func (r *GormPublisherRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Publisher, error) {
+	var publisher model.Publisher
+
+	if err := r.db.WithContext(ctx).
+		Preload("Books", visibleBooks).
+		First(&publisher, "id = ?", id).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &publisher, nil
+}
+
+func (r *GormPublisherRepository) Update(ctx context.Context, publisher *model.Publisher) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensurePublisherNameFree(tx, publisher.Name, publisher.ID); err != nil {
+			return err
+		}
+		return tx.Omit("Books").Save(publisher).Error
+	})
+}
+
+// Delete refuses to remove a publisher that still has books so that
+// catalog entries never silently lose their publisher.
+func (r *GormPublisherRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var books int64
+		if err := tx.Model(&model.Book{}).Where("publisher_id = ?", id).Count(&books).Error; err != nil {
+			return err
+		}
+		if books > 0 {
+			return ErrPublisherHasBooks
+		}
+
+		result := tx.Delete(&model.Publisher{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func ensurePublisherNameFree(tx *gorm.DB, name string, self uuid.UUID) error {
+	var count int64
+	if err := tx.Model(&model.Publisher{}).
+		Where("LOWER(name) = LOWER(?) AND id <> ?", name, self).
+		Count(&count).Error; err != nil {
+
+		return err
+	}
+	if count > 0 {
+		return ErrPublisherAlreadyExists
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// DefaultRetentionPeriod is how long closed holds and read notifications
+// are kept.
+const DefaultRetentionPeriod = 90 * 24 * time.Hour
+
+// RetentionRepository clears out records that are only kept as history:
+// holds that have left the queue and notifications that have been read.
+// Nothing else in the service is soft-deleted.
+type RetentionRepository interface {
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+type GormRetentionRepository struct {
+	db *gorm.DB
+}
+
+func NewRetentionRepository(db *gorm.DB) RetentionRepository {
+	return &GormRetentionRepository{db: db}
+}
+
+// Purge deletes holds closed and notifications read before the given time
+// and reports how many rows went.
+func (r *GormRetentionRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		holds := tx.
+			Where("status IN ? AND updated_at < ?", []string{model.HoldStatusFulfilled, model.HoldStatusCancelled, model.HoldStatusExpired}, before).
+			Delete(&model.Hold{})
+		if holds.Error != nil {
+			return holds.Error
+		}
+
+		notifications := tx.
+			Where("read_at IS NOT NULL AND read_at < ?", before).
+			Delete(&model.Notification{})
+		if notifications.Error != nil {
+			return notifications.Error
+		}
+
+		purged = holds.RowsAffected + notifications.RowsAffected
+		return nil
+	})
+
+	return purged, err
+}
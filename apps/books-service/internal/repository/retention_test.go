@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+)
+
+func TestGormRetentionRepository_Purge(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	author, _ := seedBooks(t, db)
+	var book model.Book
+	if err := db.First(&book, "author_id = ?", author.ID).Error; err != nil {
+		t.Fatalf("failed to load book: %v", err)
+	}
+
+	now := time.Now()
+	old := now.Add(-2 * DefaultRetentionPeriod)
+	holds := []model.Hold{
+		{BookID: book.ID, UserID: "a", Status: model.HoldStatusFulfilled, UpdatedAt: old},
+		{BookID: book.ID, UserID: "b", Status: model.HoldStatusCancelled, UpdatedAt: now},
+		{BookID: book.ID, UserID: "c", Status: model.HoldStatusWaiting, UpdatedAt: old},
+	}
+	notifications := []model.Notification{
+		{UserID: "a", Type: model.NotificationHoldExpired, Title: "t", Body: "b", ReadAt: &old},
+		{UserID: "a", Type: model.NotificationHoldExpired, Title: "t", Body: "b", ReadAt: &now},
+		{UserID: "a", Type: model.NotificationHoldExpired, Title: "t", Body: "b", CreatedAt: old},
+	}
+	if err := db.Omit("Book").Create(&holds).Error; err != nil {
+		t.Fatalf("failed to seed holds: %v", err)
+	}
+	if err := db.Create(&notifications).Error; err != nil {
+		t.Fatalf("failed to seed notifications: %v", err)
+	}
+
+	purged, err := NewRetentionRepository(db).Purge(ctx, now.Add(-DefaultRetentionPeriod))
+	if err != nil {
+		t.Fatalf("Purge returned error: %v", err)
+	}
+	if purged != 2 {
+		t.Fatalf("expected the old closed hold and old read notification purged, got %d", purged)
+	}
+
+	var remaining int64
+	db.Model(&model.Hold{}).Count(&remaining)
+	if remaining != 2 {
+		t.Fatalf("expected 2 holds left, got %d", remaining)
+	}
+	db.Model(&model.Notification{}).Count(&remaining)
+	if remaining != 2 {
+		t.Fatalf("expected 2 notifications left, got %d", remaining)
+	}
+}
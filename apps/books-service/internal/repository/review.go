@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+var ErrReviewAlreadyExists = errors.New("user has already reviewed this book")
+
+type ReviewListParams struct {
+	Page     int
+	PageSize int
+	// Offset, when set, skips that many reviews instead of Page.
+	Offset int
+	Sort   string
+}
+
+type ReviewListResult struct {
+	Reviews []model.Review
+	Total   int64
+}
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *model.Review) error
+	List(ctx context.Context, bookID uuid.UUID, params ReviewListParams) (ReviewListResult, error)
+	// ListByBooks pages each book's reviews, newest first, in one query.
+	// Only PageSize and Offset of params apply.
+	ListByBooks(ctx context.Context, bookIDs []uuid.UUID, params ReviewListParams) (map[uuid.UUID]ReviewListResult, error)
+	FindByID(ctx context.Context, bookID, id uuid.UUID) (*model.Review, error)
+	Update(ctx context.Context, review *model.Review) error
+	Delete(ctx context.Context, review *model.Review) error
+}
+
+type GormReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) ReviewRepository {
+	return &GormReviewRepository{db: db}
+}
+
+// Create stores the review and folds its rating into the book's aggregates.
+func (r *GormReviewRepository) Create(ctx context.Context, review *model.Review) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.Review{}).
+			Where("book_id = ? AND user_id = ?", review.BookID, review.UserID).
+			Count(&count).Error; err != nil {
+
+			return err
+		}
+		if count > 0 {
+			return ErrReviewAlreadyExists
+		}
+
+		if err := ensureBookVisible(tx, review.BookID); err != nil {
+			return err
+		}
+		if err := adjustBookRating(tx, review.BookID, 1, review.Rating); err != nil {
+			return err
+		}
+		if err := tx.Omit("Book").Create(review).Error; err != nil {
+			return err
+		}
+		return recordActivity(tx, review.UserID, model.ActivityReviewWritten, review.BookID, &review.ID, nil)
+	})
+}
+
+func (r *GormReviewRepository) List(ctx context.Context, bookID uuid.UUID, params ReviewListParams) (ReviewListResult, error) {
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+
+	db := visibleReviews(r.db.WithContext(ctx).Model(&model.Review{})).Where("book_id = ?", bookID)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return ReviewListResult{}, err
+	}
+
+	switch params.Sort {
+	case "created_at_asc":
+		db = db.Order("created_at ASC")
+	case "rating_desc":
+		db = db.Order("rating DESC").Order("created_at DESC")
+	case "rating_asc":
+		db = db.Order("rating ASC").Order("created_at DESC")
+	case "created_at_desc", "":
+		fallthrough
+	default:
+		db = db.Order("created_at DESC")
+	}
+
+	offset := (params.Page - 1) * params.PageSize
+	if params.Offset > 0 {
+		offset = params.Offset
+	}
+
+	var reviews []model.Review
+	if err := db.
+		Limit(params.PageSize).
+		Offset(offset).
+		Find(&reviews).Error; err != nil {
+
+		return ReviewListResult{}, err
+	}
+
+	return ReviewListResult{Reviews: reviews, Total: total}, nil
+}
+
+func (r *GormReviewRepository) ListByBooks(ctx context.Context, bookIDs []uuid.UUID, params ReviewListParams) (map[uuid.UUID]ReviewListResult, error) {
+	if params.PageSize <= 0 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+
+	db := r.db.WithContext(ctx)
+	results := make(map[uuid.UUID]ReviewListResult, len(bookIDs))
+	if len(bookIDs) == 0 {
+		return results, nil
+	}
+	matched := func() *gorm.DB {
+		return visibleReviews(db.Model(&model.Review{})).Where("reviews.book_id IN ?", bookIDs)
+	}
+
+	var totals []groupCount
+	if err := matched().
+		Select("reviews.book_id AS group_id, COUNT(*) AS count").
+		Group("reviews.book_id").
+		Scan(&totals).Error; err != nil {
+
+		return nil, err
+	}
+	for _, t := range totals {
+		results[t.GroupID] = ReviewListResult{Total: t.Count}
+	}
+
+	var reviews []model.Review
+	if err := pagePerGroup(db, matched(), "reviews", "book_id", "created_at DESC, reviews.id DESC",
+		params.Offset, params.PageSize).
+		Find(&reviews).Error; err != nil {
+
+		return nil, err
+	}
+
+	for _, rv := range reviews {
+		result := results[rv.BookID]
+		result.Reviews = append(result.Reviews, rv)
+		results[rv.BookID] = result
+	}
+	return results, nil
+}
+
+func (r *GormReviewRepository) FindByID(ctx context.Context, bookID, id uuid.UUID) (*model.Review, error) {
+	var review model.Review
+
+	if err := visibleReviews(r.db.WithContext(ctx)).
+		First(&review, "id = ? AND book_id = ?", id, bookID).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// Update saves the review's rating and text, moving the book's aggregates
+// by the difference from the stored rating. The stored review stays locked
+// until then, so concurrent edits each move the aggregates from the rating
+// the one before left.
+func (r *GormReviewRepository) Update(ctx context.Context, review *model.Review) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stored model.Review
+		if err := forUpdate(tx).First(&stored, "id = ?", review.ID).Error; err != nil {
+			return err
+		}
+
+		if delta := review.Rating - stored.Rating; delta != 0 {
+			if err := adjustBookRating(tx, review.BookID, 0, delta); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&model.Review{}).
+			Where("id = ?", review.ID).
+			Updates(map[string]any{
+				"rating":     review.Rating,
+				"body":       review.Body,
+				"updated_at": review.UpdatedAt,
+			}).Error
+	})
+}
+
+// Delete removes the review and takes its rating out of the book's
+// aggregates, once however many callers delete it at the same time.
+func (r *GormReviewRepository) Delete(ctx context.Context, review *model.Review) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stored model.Review
+		if err := forUpdate(tx).First(&stored, "id = ?", review.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("review_id = ?", stored.ID).Delete(&model.Activity{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&model.Review{}, "id = ?", stored.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return gorm.ErrRecordNotFound
+		}
+		return adjustBookRating(tx, stored.BookID, -1, -stored.Rating)
+	})
+}
+
+// adjustBookRating applies a change in review count and rating sum to the
+// book and recomputes the average from the new totals.
+func adjustBookRating(tx *gorm.DB, bookID uuid.UUID, countDelta, sumDelta int) error {
+	result := tx.Model(&model.Book{}).
+		Where("id = ?", bookID).
+		UpdateColumns(map[string]any{
+			"rating_count": gorm.Expr("rating_count + ?", countDelta),
+			"rating_sum":   gorm.Expr("rating_sum + ?", sumDelta),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBookNotFound
+	}
+
+	return tx.Model(&model.Book{}).
+		Where("id = ?", bookID).
+		UpdateColumn("rating_average", gorm.Expr(
+			"CASE WHEN rating_count > 0 THEN CAST(rating_sum AS DOUBLE PRECISION) / rating_count ELSE 0 END",
+		)).Error
+}
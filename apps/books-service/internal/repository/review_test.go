@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+func TestGormReviewRepository_RatingAggregates(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewReviewRepository(db)
+	ctx := context.Background()
+
+	author, _ := seedBooks(t, db)
+	var book model.Book
+	if err := db.First(&book, "author_id = ?", author.ID).Error; err != nil {
+		t.Fatalf("failed to load book: %v", err)
+	}
+
+	aggregates := func() (int64, int64) {
+		t.Helper()
+		var b model.Book
+		if err := db.First(&b, "id = ?", book.ID).Error; err != nil {
+			t.Fatalf("failed to reload book: %v", err)
+		}
+		return b.RatingCount, b.RatingSum
+	}
+
+	mine := model.Review{BookID: book.ID, UserID: "me", Rating: 2}
+	theirs := model.Review{BookID: book.ID, UserID: "them", Rating: 5}
+	for _, rv := range []*model.Review{&mine, &theirs} {
+		if err := repo.Create(ctx, rv); err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+	}
+
+	// Each update moves the sum from the rating stored before it.
+	for _, rating := range []int{4, 4, 1} {
+		edit := mine
+		edit.Rating = rating
+		if err := repo.Update(ctx, &edit); err != nil {
+			t.Fatalf("Update returned error: %v", err)
+		}
+	}
+	if count, sum := aggregates(); count != 2 || sum != 6 {
+		t.Fatalf("expected 2 ratings summing to 6, got %d and %d", count, sum)
+	}
+
+	// Deleting the same review twice takes its rating out once.
+	if err := repo.Delete(ctx, &mine); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if err := repo.Delete(ctx, &mine); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected ErrRecordNotFound deleting again, got %v", err)
+	}
+	if count, sum := aggregates(); count != 1 || sum != 5 {
+		t.Fatalf("expected 1 rating summing to 5, got %d and %d", count, sum)
+	}
+}
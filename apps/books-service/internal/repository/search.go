@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/search"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SearchQuery struct {
+	Text string
+	// Fuzzy tolerates typos, matching by trigram similarity of at least
+	// Threshold.
+	Fuzzy     bool
+	Threshold float64
+}
+
+// SearchIndex answers the q part of book searches. Visibility, the other
+// filters and paging are applied in SQL on top of what it matches.
+type SearchIndex interface {
+	// Match narrows db, a books query, to the books matching q. rank
+	// orders them best first; it is nil when the index can't rank them.
+	Match(db *gorm.DB, q SearchQuery) (matched *gorm.DB, rank clause.Expression, err error)
+	// Sync brings the given books up to date in the index, dropping those
+	// that no longer exist. Repositories call it after each change.
+	Sync(ctx context.Context, db *gorm.DB, ids ...uuid.UUID) error
+	// Reindex rebuilds the index from every book, returning how many it
+	// holds.
+	Reindex(ctx context.Context, db *gorm.DB) (int, error)
+}
+
+// RepositoryOption configures the repositories that read or change what
+// books search finds.
+type RepositoryOption func(*repositoryOptions)
+
+type repositoryOptions struct {
+	search SearchIndex
+}
+
+// WithSearchIndex sets the index book searches use. Without it they query
+// the books table directly.
+func WithSearchIndex(index SearchIndex) RepositoryOption {
+	return func(o *repositoryOptions) {
+		o.search = index
+	}
+}
+
+func applyRepositoryOptions(opts []RepositoryOption) repositoryOptions {
+	o := repositoryOptions{search: NewSQLSearchIndex()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// syncSearch updates the index after a committed change. The change
+// stands even if this fails, so failures are logged; a reindex repairs
+// the index.
+func syncSearch(ctx context.Context, db *gorm.DB, index SearchIndex, ids ...uuid.UUID) {
+	if err := index.Sync(ctx, db, ids...); err != nil {
+		log.Printf("failed to update search index for books %v: %v", ids, err)
+	}
+}
+
+// SQLSearchIndex searches the books table itself: by substring, or with
+// Fuzzy by pg_trgm similarity on Postgres and a trigram approximation
+// elsewhere. There is nothing to keep in sync. Fuzzy matches on Postgres
+// filter at pg_trgm's word similarity threshold, so run them under
+// withFuzzySearch.
+type SQLSearchIndex struct{}
+
+func NewSQLSearchIndex() *SQLSearchIndex {
+	return &SQLSearchIndex{}
+}
+
+func (SQLSearchIndex) Match(db *gorm.DB, q SearchQuery) (*gorm.DB, clause.Expression, error) {
+	if q.Fuzzy {
+		score, args := bookSimilaritySQL(db, q.Text)
+		rank := clause.Expr{SQL: score + " DESC", Vars: args, WithoutParentheses: true}
+		match, matchArgs := fuzzyBookMatchSQL(db, q.Text, q.Threshold)
+		return db.Where(match, matchArgs...), rank, nil
+	}
+
+	if db.Dialector.Name() == "postgres" {
+		like := "%" + q.Text + "%"
+		return db.Where("title ILIKE ? OR description ILIKE ?", like, like), nil, nil
+	}
+	like := "%" + strings.ToLower(q.Text) + "%"
+	return db.Where("LOWER(title) LIKE ? OR LOWER(description) LIKE ?", like, like), nil, nil
+}
+
+func (SQLSearchIndex) Sync(ctx context.Context, db *gorm.DB, ids ...uuid.UUID) error {
+	return nil
+}
+
+func (SQLSearchIndex) Reindex(ctx context.Context, db *gorm.DB) (int, error) {
+	var count int64
+	err := db.WithContext(ctx).Model(&model.Book{}).Count(&count).Error
+	return int(count), err
+}
+
+// reindexBatchSize is how many books a reindex loads at a time.
+const reindexBatchSize = 500
+
+// EmbeddedSearchIndex keeps titles, author names and descriptions in an
+// in-process index, for single-node deployments.
+type EmbeddedSearchIndex struct {
+	index *search.Index
+}
+
+func NewEmbeddedSearchIndex(index *search.Index) *EmbeddedSearchIndex {
+	return &EmbeddedSearchIndex{index: index}
+}
+
+// Match hands every hit to SQL, so visibility, filters and paging see the
+// whole result. The hits are bound as one array value, since GORM would
+// expand a slice into a placeholder per hit.
+func (e *EmbeddedSearchIndex) Match(db *gorm.DB, q SearchQuery) (*gorm.DB, clause.Expression, error) {
+	hits := e.index.Search(q.Text, search.Options{
+		Fuzzy:     q.Fuzzy,
+		Threshold: q.Threshold,
+	})
+	if len(hits) == 0 {
+		return db.Where("1 = 0"), nil, nil
+	}
+
+	ids := make([]string, len(hits))
+	for i, h := range hits {
+		ids[i] = h.ID.String()
+	}
+
+	if db.Dialector.Name() == "postgres" {
+		// A Postgres array literal; UUIDs need no quoting.
+		list := "{" + strings.Join(ids, ",") + "}"
+		rank := clause.Expr{SQL: "array_position(?::uuid[], books.id) ASC", Vars: []any{list}, WithoutParentheses: true}
+		return db.Where("books.id = ANY(?::uuid[])", list), rank, nil
+	}
+
+	list, err := json.Marshal(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	rank := clause.Expr{
+		SQL:                "(SELECT hit.key FROM json_each(?) AS hit WHERE hit.value = books.id) ASC",
+		Vars:               []any{string(list)},
+		WithoutParentheses: true,
+	}
+	return db.Where("books.id IN (SELECT value FROM json_each(?))", string(list)), rank, nil
+}
+
+func (e *EmbeddedSearchIndex) Sync(ctx context.Context, db *gorm.DB, ids ...uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var books []model.Book
+	if err := db.WithContext(ctx).Preload("Author").Where("id IN ?", ids).Find(&books).Error; err != nil {
+		return err
+	}
+
+	found := make(map[uuid.UUID]bool, len(books))
+	for _, b := range books {
+		found[b.ID] = true
+	}
+	var gone []uuid.UUID
+	for _, id := range ids {
+		if !found[id] {
+			gone = append(gone, id)
+		}
+	}
+
+	if err := e.index.Put(searchDocuments(books)...); err != nil {
+		return err
+	}
+	return e.index.Delete(gone...)
+}
+
+func (e *EmbeddedSearchIndex) Reindex(ctx context.Context, db *gorm.DB) (int, error) {
+	if err := e.index.Reset(); err != nil {
+		return 0, err
+	}
+
+	var books []model.Book
+	err := db.WithContext(ctx).Preload("Author").Order("id").
+		FindInBatches(&books, reindexBatchSize, func(tx *gorm.DB, batch int) error {
+			return e.index.Put(searchDocuments(books)...)
+		}).Error
+	if err != nil {
+		return 0, err
+	}
+	return e.index.Len(), nil
+}
+
+func searchDocuments(books []model.Book) []search.Document {
+	docs := make([]search.Document, len(books))
+	for i, b := range books {
+		docs[i] = search.Document{
+			ID:          b.ID,
+			Title:       b.Title,
+			Author:      b.Author.Name,
+			Description: b.Description,
+		}
+	}
+	return docs
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/search"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// dryRunPostgres builds Postgres statements without connecting.
+func dryRunPostgres(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to open dry-run postgres: %v", err)
+	}
+	return db
+}
+
+func TestEmbeddedSearchIndex_MatchPostgresBindsOneArray(t *testing.T) {
+	ix, err := search.Open("")
+	if err != nil {
+		t.Fatalf("failed to open search index: %v", err)
+	}
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	for i, id := range ids {
+		if err := ix.Put(search.Document{ID: id, Title: "Wizard " + strings.Repeat("I", i+1)}); err != nil {
+			t.Fatalf("failed to index book: %v", err)
+		}
+	}
+
+	db := dryRunPostgres(t)
+	filtered, rank, err := NewEmbeddedSearchIndex(ix).Match(db.Model(&model.Book{}), SearchQuery{Text: "wizard"})
+	if err != nil {
+		t.Fatalf("failed to match: %v", err)
+	}
+
+	var books []model.Book
+	stmt := filtered.Order(clause.OrderBy{Expression: rank}).Find(&books).Statement
+	sql := stmt.SQL.String()
+	if !strings.Contains(sql, "books.id = ANY($1::uuid[])") || !strings.Contains(sql, "array_position($2::uuid[], books.id)") {
+		t.Fatalf("expected each array bound as a single parameter, got %s", sql)
+	}
+	if len(stmt.Vars) != 2 {
+		t.Fatalf("expected 2 vars, got %v", stmt.Vars)
+	}
+	list, _ := stmt.Vars[0].(string)
+	for _, id := range ids {
+		if !strings.Contains(list, id.String()) {
+			t.Fatalf("expected %s in the bound array %q", id, list)
+		}
+	}
+}
+
+func TestSQLSearchIndex_FuzzyMatchPostgresUsesTrigramOperator(t *testing.T) {
+	db := dryRunPostgres(t)
+	filtered, rank, err := NewSQLSearchIndex().Match(db.Model(&model.Book{}), SearchQuery{Text: "dostoyevsky", Fuzzy: true, Threshold: 0.5})
+	if err != nil {
+		t.Fatalf("failed to match: %v", err)
+	}
+
+	var books []model.Book
+	stmt := filtered.Order(clause.OrderBy{Expression: rank}).Find(&books).Statement
+	sql := stmt.SQL.String()
+	where := sql[strings.Index(sql, "WHERE"):strings.Index(sql, "ORDER BY")]
+	if !strings.Contains(where, "$1 <% books.title") || !strings.Contains(where, "SELECT authors.id FROM authors WHERE $2 <% authors.name") {
+		t.Fatalf("expected the filter to use the indexable <%% operator, got %s", where)
+	}
+	if strings.Contains(where, "word_similarity") {
+		t.Fatalf("expected no word_similarity call in the filter, got %s", where)
+	}
+}
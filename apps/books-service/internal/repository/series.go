@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+type SeriesRepository interface {
+	Create(ctx context.Context, series *model.Series) error
+	List(ctx context.Context) ([]model.Series, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Series, error)
+	Update(ctx context.Context, series *model.Series) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type GormSeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewSeriesRepository(db *gorm.DB) SeriesRepository {
+	return &GormSeriesRepository{db: db}
+}
+
+func (r *GormSeriesRepository) Create(ctx context.Context, series *model.Series) error {
+	return r.db.WithContext(ctx).Create(series).Error
+}
+
+func (r *GormSeriesRepository) List(ctx context.Context) ([]model.Series, error) {
+	var series []model.Series
+
+	if err := r.db.WithContext(ctx).
+		Preload("Books", visibleBooksByVolume).
+		Preload("Books.Author").
+		Order("name ASC").
+		Find(&series).Error; err != nil {
+
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (r *GormSeriesRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Series, error) {
+	var series model.Series
+
+	if err := r.db.WithContext(ctx).
+		Preload("Books", visibleBooksByVolume).
+		Preload("Books.Author").
+		First(&series, "id = ?", id).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &series, nil
+}
+
+func (r *GormSeriesRepository) Update(ctx context.Context, series *model.Series) error {
+	return r.db.WithContext(ctx).Omit("Books").Save(series).Error
+}
+
+// Delete removes the series and detaches its books rather than deleting them.
+func (r *GormSeriesRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Book{}).
+			Where("series_id = ?", id).
+			Updates(map[string]any{
+				"series_id":     nil,
+				"series_volume": nil,
+			}).Error; err != nil {
+
+			return err
+		}
+
+		result := tx.Delete(&model.Series{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func visibleBooksByVolume(db *gorm.DB) *gorm.DB {
+	return orderBooksByVolume(visibleBooks(db))
+}
+
+func orderBooksByVolume(db *gorm.DB) *gorm.DB {
+	return db.Order("series_volume ASC NULLS LAST, title ASC")
+}
+
+// attachSeriesNeighbours fills PreviousVolume and NextVolume for every
+// numbered book in books using a single query per call.
+func attachSeriesNeighbours(db *gorm.DB, books []model.Book) error {
+	seriesIDs := make([]uuid.UUID, 0, len(books))
+	seen := make(map[uuid.UUID]bool)
+	for _, b := range books {
+		if b.SeriesID == nil || b.SeriesVolume == nil || seen[*b.SeriesID] {
+			continue
+		}
+		seen[*b.SeriesID] = true
+		seriesIDs = append(seriesIDs, *b.SeriesID)
+	}
+
+	if len(seriesIDs) == 0 {
+		return nil
+	}
+
+	var volumes []struct {
+		ID           uuid.UUID
+		Title        string
+		SeriesID     uuid.UUID
+		SeriesVolume float64
+	}
+	if err := visibleBooks(db.Model(&model.Book{})).
+		Select("id, title, series_id, series_volume").
+		Where("series_id IN ? AND series_volume IS NOT NULL", seriesIDs).
+		Order("series_volume ASC, title ASC").
+		Scan(&volumes).Error; err != nil {
+
+		return err
+	}
+
+	for i := range books {
+		b := &books[i]
+		if b.SeriesID == nil || b.SeriesVolume == nil {
+			continue
+		}
+
+		var prev, next *model.SeriesVolumeRef
+		found := false
+		for _, v := range volumes {
+			if v.SeriesID != *b.SeriesID {
+				continue
+			}
+			if v.ID == b.ID {
+				found = true
+				continue
+			}
+			ref := &model.SeriesVolumeRef{ID: v.ID, Title: v.Title, Volume: v.SeriesVolume}
+			if !found {
+				prev = ref
+			} else if next == nil {
+				next = ref
+			}
+		}
+
+		b.PreviousVolume = prev
+		b.NextVolume = next
+	}
+
+	return nil
+}
@@ -0,0 +1,407 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrShelfAlreadyExists = errors.New("shelf already exists")
+	ErrShelfBuiltin       = errors.New("built-in shelves cannot be changed")
+	ErrInvalidShelfName   = errors.New("shelf name must contain letters or digits")
+	ErrShelfEntryNotFound = errors.New("book is not on this shelf")
+)
+
+type ShelfWithCount struct {
+	ID        uuid.UUID
+	Slug      string
+	Name      string
+	Builtin   bool
+	BookCount int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ShelfEntryListParams struct {
+	PageSize int
+	Offset   int
+}
+
+type ShelfEntryListResult struct {
+	Entries []model.ShelfEntry
+	Total   int64
+}
+
+// ShelfRepository manages per-user shelves. Shelves are looked up by a ref,
+// which is either the shelf UUID or its slug; lookups never cross users.
+type ShelfRepository interface {
+	List(ctx context.Context, userID string) ([]ShelfWithCount, error)
+	Find(ctx context.Context, userID, ref string) (*model.Shelf, error)
+	Entries(ctx context.Context, shelfID uuid.UUID) ([]model.ShelfEntry, error)
+	// EntriesByShelves pages each shelf's entries, most recently added
+	// first, in one query.
+	EntriesByShelves(ctx context.Context, shelfIDs []uuid.UUID, params ShelfEntryListParams) (map[uuid.UUID]ShelfEntryListResult, error)
+	Create(ctx context.Context, shelf *model.Shelf) error
+	Rename(ctx context.Context, shelf *model.Shelf) error
+	Delete(ctx context.Context, shelf *model.Shelf) error
+	PutEntry(ctx context.Context, shelf *model.Shelf, entry *model.ShelfEntry) error
+	MoveEntry(ctx context.Context, from, to *model.Shelf, entry *model.ShelfEntry) error
+	RemoveEntry(ctx context.Context, shelfID, bookID uuid.UUID) error
+}
+
+type GormShelfRepository struct {
+	db *gorm.DB
+}
+
+func NewShelfRepository(db *gorm.DB) ShelfRepository {
+	return &GormShelfRepository{db: db}
+}
+
+// List returns the user's shelves, built-ins first, with their book counts.
+func (r *GormShelfRepository) List(ctx context.Context, userID string) ([]ShelfWithCount, error) {
+	db := r.db.WithContext(ctx)
+
+	if err := ensureBuiltinShelves(db, userID); err != nil {
+		return nil, err
+	}
+
+	var shelves []ShelfWithCount
+	if err := db.
+		Table("shelves").
+		Select("shelves.id, shelves.slug, shelves.name, shelves.builtin, shelves.created_at, shelves.updated_at, COUNT(shelf_entries.id) AS book_count").
+		Joins("LEFT JOIN shelf_entries ON shelf_entries.shelf_id = shelves.id").
+		Where("shelves.user_id = ?", userID).
+		Group("shelves.id, shelves.slug, shelves.name, shelves.builtin, shelves.created_at, shelves.updated_at").
+		Order("shelves.builtin DESC, shelves.created_at ASC, shelves.name ASC").
+		Scan(&shelves).Error; err != nil {
+
+		return nil, err
+	}
+
+	return shelves, nil
+}
+
+func (r *GormShelfRepository) Find(ctx context.Context, userID, ref string) (*model.Shelf, error) {
+	db := r.db.WithContext(ctx)
+
+	if err := ensureBuiltinShelves(db, userID); err != nil {
+		return nil, err
+	}
+
+	var shelf model.Shelf
+	if err := whereShelfRef(db.Where("user_id = ?", userID), "shelves", ref).
+		First(&shelf).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &shelf, nil
+}
+
+// Entries returns the books on a shelf, most recently added first. Books
+// the viewer can no longer see are left out.
+func (r *GormShelfRepository) Entries(ctx context.Context, shelfID uuid.UUID) ([]model.ShelfEntry, error) {
+	var entries []model.ShelfEntry
+
+	db := r.db.WithContext(ctx)
+	if err := db.
+		Preload("Book").
+		Where("shelf_id = ?", shelfID).
+		Where("book_id IN (?)", visibleBooks(db.Model(&model.Book{}).Select("books.id"))).
+		Order("added_at DESC").
+		Find(&entries).Error; err != nil {
+
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// EntriesByShelves leaves out books the viewer can no longer see, as
+// Entries does, and loads each entry's book with its tags.
+func (r *GormShelfRepository) EntriesByShelves(ctx context.Context, shelfIDs []uuid.UUID, params ShelfEntryListParams) (map[uuid.UUID]ShelfEntryListResult, error) {
+	if params.PageSize <= 0 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+
+	db := r.db.WithContext(ctx)
+	results := make(map[uuid.UUID]ShelfEntryListResult, len(shelfIDs))
+	if len(shelfIDs) == 0 {
+		return results, nil
+	}
+	matched := func() *gorm.DB {
+		return db.Model(&model.ShelfEntry{}).
+			Where("shelf_entries.shelf_id IN ?", shelfIDs).
+			Where("shelf_entries.book_id IN (?)", visibleBooks(db.Model(&model.Book{}).Select("books.id")))
+	}
+
+	var totals []groupCount
+	if err := matched().
+		Select("shelf_entries.shelf_id AS group_id, COUNT(*) AS count").
+		Group("shelf_entries.shelf_id").
+		Scan(&totals).Error; err != nil {
+
+		return nil, err
+	}
+	for _, t := range totals {
+		results[t.GroupID] = ShelfEntryListResult{Total: t.Count}
+	}
+
+	var entries []model.ShelfEntry
+	if err := pagePerGroup(db, matched(), "shelf_entries", "shelf_id", "added_at DESC, shelf_entries.id DESC",
+		params.Offset, params.PageSize).
+		Preload("Book.Tags").
+		Find(&entries).Error; err != nil {
+
+		return nil, err
+	}
+
+	for _, e := range entries {
+		result := results[e.ShelfID]
+		result.Entries = append(result.Entries, e)
+		results[e.ShelfID] = result
+	}
+	return results, nil
+}
+
+func (r *GormShelfRepository) Create(ctx context.Context, shelf *model.Shelf) error {
+	shelf.Slug = model.ShelfSlug(shelf.Name)
+	shelf.Builtin = false
+	if shelf.Slug == "" {
+		return ErrInvalidShelfName
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureBuiltinShelves(tx, shelf.UserID); err != nil {
+			return err
+		}
+		if err := ensureShelfSlugFree(tx, shelf.UserID, shelf.Slug, uuid.Nil); err != nil {
+			return err
+		}
+		return tx.Create(shelf).Error
+	})
+}
+
+func (r *GormShelfRepository) Rename(ctx context.Context, shelf *model.Shelf) error {
+	if shelf.Builtin {
+		return ErrShelfBuiltin
+	}
+	shelf.Slug = model.ShelfSlug(shelf.Name)
+	if shelf.Slug == "" {
+		return ErrInvalidShelfName
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureShelfSlugFree(tx, shelf.UserID, shelf.Slug, shelf.ID); err != nil {
+			return err
+		}
+		return tx.Model(&model.Shelf{}).
+			Where("id = ?", shelf.ID).
+			Updates(map[string]any{
+				"name":       shelf.Name,
+				"slug":       shelf.Slug,
+				"updated_at": time.Now(),
+			}).Error
+	})
+}
+
+func (r *GormShelfRepository) Delete(ctx context.Context, shelf *model.Shelf) error {
+	if shelf.Builtin {
+		return ErrShelfBuiltin
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("shelf_id = ?", shelf.ID).Delete(&model.ShelfEntry{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&model.Shelf{}, "id = ?", shelf.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// PutEntry adds entry.BookID to the shelf, or updates its dates if it is
+// already there. Dates left unset keep their current values.
+func (r *GormShelfRepository) PutEntry(ctx context.Context, shelf *model.Shelf, entry *model.ShelfEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return putShelfEntry(tx, shelf, entry)
+	})
+}
+
+// MoveEntry takes the book off one shelf and puts it on another, carrying
+// over any dates the caller didn't set.
+func (r *GormShelfRepository) MoveEntry(ctx context.Context, from, to *model.Shelf, entry *model.ShelfEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var source model.ShelfEntry
+		err := tx.Where("shelf_id = ? AND book_id = ?", from.ID, entry.BookID).
+			First(&source).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrShelfEntryNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if from.ID != to.ID {
+			if err := tx.Delete(&source).Error; err != nil {
+				return err
+			}
+		}
+
+		fillEntryDates(entry, source, true)
+		return putShelfEntry(tx, to, entry)
+	})
+}
+
+func (r *GormShelfRepository) RemoveEntry(ctx context.Context, shelfID, bookID uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("shelf_id = ? AND book_id = ?", shelfID, bookID).
+		Delete(&model.ShelfEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrShelfEntryNotFound
+	}
+	return nil
+}
+
+// putShelfEntry upserts the entry on the shelf. A book sits on at most one
+// built-in shelf, so placing it on one takes it off the others and carries
+// their reading dates across.
+func putShelfEntry(tx *gorm.DB, shelf *model.Shelf, entry *model.ShelfEntry) error {
+	if err := ensureBookVisible(tx, entry.BookID); err != nil {
+		return err
+	}
+
+	entry.ID = uuid.Nil
+	entry.ShelfID = shelf.ID
+
+	var existing model.ShelfEntry
+	err := tx.Where("shelf_id = ? AND book_id = ?", shelf.ID, entry.BookID).First(&existing).Error
+	switch {
+	case err == nil:
+		entry.ID = existing.ID
+		entry.CreatedAt = existing.CreatedAt
+		fillEntryDates(entry, existing, true)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return err
+	}
+
+	if shelf.Builtin {
+		var others []model.ShelfEntry
+		if err := tx.
+			Joins("JOIN shelves ON shelves.id = shelf_entries.shelf_id").
+			Where("shelves.user_id = ? AND shelves.builtin = ? AND shelves.id <> ?", shelf.UserID, true, shelf.ID).
+			Where("shelf_entries.book_id = ?", entry.BookID).
+			Find(&others).Error; err != nil {
+
+			return err
+		}
+		for _, other := range others {
+			fillEntryDates(entry, other, false)
+			if err := tx.Delete(&other).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	now := time.Now()
+	if entry.AddedAt.IsZero() {
+		entry.AddedAt = now
+	}
+	switch shelf.Slug {
+	case model.ShelfCurrentlyReading:
+		if entry.StartedAt == nil {
+			entry.StartedAt = &now
+		}
+	case model.ShelfRead:
+		if entry.FinishedAt == nil {
+			entry.FinishedAt = &now
+		}
+	}
+
+	if entry.ID == uuid.Nil {
+		err = tx.Omit("Book").Create(entry).Error
+		if err == nil && shelf.Builtin && shelf.Slug == model.ShelfRead {
+			err = recordActivity(tx, shelf.UserID, model.ActivityFinishedReading, entry.BookID, nil, nil)
+		}
+	} else {
+		err = tx.Omit("Book").Save(entry).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Preload("Book").First(entry, "id = ?", entry.ID).Error
+}
+
+// fillEntryDates copies dates from src into the unset fields of dst.
+func fillEntryDates(dst *model.ShelfEntry, src model.ShelfEntry, withAddedAt bool) {
+	if withAddedAt && dst.AddedAt.IsZero() {
+		dst.AddedAt = src.AddedAt
+	}
+	if dst.StartedAt == nil {
+		dst.StartedAt = src.StartedAt
+	}
+	if dst.FinishedAt == nil {
+		dst.FinishedAt = src.FinishedAt
+	}
+}
+
+// ensureBuiltinShelves lazily creates the built-in shelves the first time a
+// user's shelves are touched.
+func ensureBuiltinShelves(db *gorm.DB, userID string) error {
+	var count int64
+	if err := db.Model(&model.Shelf{}).
+		Where("user_id = ? AND builtin = ?", userID, true).
+		Count(&count).Error; err != nil {
+
+		return err
+	}
+	if count == int64(len(model.BuiltinShelves)) {
+		return nil
+	}
+
+	for _, b := range model.BuiltinShelves {
+		shelf := model.Shelf{UserID: userID, Slug: b.Slug, Name: b.Name, Builtin: true}
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&shelf).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ensureShelfSlugFree(tx *gorm.DB, userID, slug string, self uuid.UUID) error {
+	var count int64
+	if err := tx.Model(&model.Shelf{}).
+		Where("user_id = ? AND slug = ? AND id <> ?", userID, slug, self).
+		Count(&count).Error; err != nil {
+
+		return err
+	}
+	if count > 0 {
+		return ErrShelfAlreadyExists
+	}
+	return nil
+}
+
+// whereShelfRef matches a shelf by UUID when ref parses as one, else by slug.
+func whereShelfRef(db *gorm.DB, table, ref string) *gorm.DB {
+	if id, err := uuid.Parse(ref); err == nil {
+		return db.Where(table+".id = ?", id)
+	}
+	return db.Where(table+".slug = ?", ref)
+}
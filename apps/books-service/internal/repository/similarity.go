@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// Weights of the signals two books can share when scoring similarity.
+const (
+	similarityAuthorWeight = 3.0
+	similaritySeriesWeight = 2.0
+	// Each shared tag adds this much.
+	similarityTagWeight = 1.0
+	// Each user who shelved both books adds this much.
+	similarityShelfWeight = 0.5
+)
+
+type SimilarityRepository interface {
+	// Refresh rebuilds the similarity table from the current catalog and
+	// shelves, returning how many pairs it holds.
+	Refresh(ctx context.Context) (int64, error)
+	// Similar returns up to limit books most similar to the given one,
+	// leaving out books the viewer has read.
+	Similar(ctx context.Context, bookID uuid.UUID, limit int) ([]model.Book, error)
+}
+
+type GormSimilarityRepository struct {
+	db *gorm.DB
+}
+
+func NewSimilarityRepository(db *gorm.DB) SimilarityRepository {
+	return &GormSimilarityRepository{db: db}
+}
+
+// similarityPairsSQL scores every ordered pair of books sharing an author,
+// a series, tags or readers.
+const similarityPairsSQL = `
+SELECT pairs.book_id, pairs.similar_book_id, SUM(pairs.score) AS score FROM (
+	SELECT b1.id AS book_id, b2.id AS similar_book_id, ? AS score
+	FROM books b1 JOIN books b2 ON b2.author_id = b1.author_id AND b2.id <> b1.id
+	UNION ALL
+	SELECT b1.id, b2.id, ?
+	FROM books b1 JOIN books b2 ON b2.series_id = b1.series_id AND b2.id <> b1.id
+	UNION ALL
+	SELECT t1.book_id, t2.book_id, ?
+	FROM book_tags t1 JOIN book_tags t2 ON t2.tag_id = t1.tag_id AND t2.book_id <> t1.book_id
+	UNION ALL
+	SELECT s1.book_id, s2.book_id, ?
+	FROM (SELECT DISTINCT shelves.user_id, shelf_entries.book_id FROM shelf_entries JOIN shelves ON shelves.id = shelf_entries.shelf_id) s1
+	JOIN (SELECT DISTINCT shelves.user_id, shelf_entries.book_id FROM shelf_entries JOIN shelves ON shelves.id = shelf_entries.shelf_id) s2
+	ON s2.user_id = s1.user_id AND s2.book_id <> s1.book_id
+) pairs
+GROUP BY pairs.book_id, pairs.similar_book_id`
+
+func (r *GormSimilarityRepository) Refresh(ctx context.Context) (int64, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM book_similarities").Error; err != nil {
+			return err
+		}
+
+		result := tx.Exec(
+			"INSERT INTO book_similarities (book_id, similar_book_id, score)"+similarityPairsSQL,
+			similarityAuthorWeight, similaritySeriesWeight, similarityTagWeight, similarityShelfWeight,
+		)
+		if result.Error != nil {
+			return result.Error
+		}
+		count = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *GormSimilarityRepository) Similar(ctx context.Context, bookID uuid.UUID, limit int) ([]model.Book, error) {
+	db := r.db.WithContext(ctx)
+	if err := ensureBookVisible(db, bookID); err != nil {
+		return nil, err
+	}
+
+	q := visibleBooks(db.Model(&model.Book{})).
+		Preload("Author").
+		Preload("Tags", orderTagsByName).
+		Preload("Series").
+		Preload("Work").
+		Preload("Publisher").
+		Joins("JOIN book_similarities ON book_similarities.similar_book_id = books.id AND book_similarities.book_id = ?", bookID)
+
+	if viewer := viewerID(ctx); viewer != "" {
+		q = q.Where(
+			"books.id NOT IN (SELECT shelf_entries.book_id FROM shelf_entries JOIN shelves ON shelves.id = shelf_entries.shelf_id"+
+				" WHERE shelves.user_id = ? AND shelves.builtin = ? AND shelves.slug = ?)",
+			viewer, true, model.ShelfRead,
+		)
+	}
+
+	var books []model.Book
+	if err := q.Order("book_similarities.score DESC, books.title ASC").
+		Limit(limit).
+		Find(&books).Error; err != nil {
+
+		return nil, err
+	}
+
+	if err := attachSeriesNeighbours(db, books); err != nil {
+		return nil, err
+	}
+	if err := attachCopyCounts(db, books); err != nil {
+		return nil, err
+	}
+	return books, nil
+}
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultStatsCacheTTL is how long computed statistics are served before
+// they are recomputed.
+const DefaultStatsCacheTTL = time.Minute
+
+const (
+	StatsByYear   = "year"
+	StatsByDecade = "decade"
+)
+
+type StatsParams struct {
+	// Books filters the books counted, as for BookRepository.List; paging
+	// and sort are ignored.
+	Books BookListParams
+	// PublishedBy buckets publication dates by StatsByYear or StatsByDecade.
+	PublishedBy string
+	TopAuthors  int
+}
+
+type BookStats struct {
+	Total              int64
+	MissingDescription int64
+	MissingPublishedAt int64
+	// Published counts books with a publication date per year or decade,
+	// keyed by its first year, oldest first.
+	Published []PeriodCount
+	// Added counts books per month they were added, as YYYY-MM, oldest
+	// first.
+	Added      []MonthCount
+	TopAuthors []AuthorCount
+}
+
+type PeriodCount struct {
+	Year  int
+	Count int64
+}
+
+type MonthCount struct {
+	Month string
+	Count int64
+}
+
+type AuthorCount struct {
+	AuthorID uuid.UUID
+	Name     string
+	Count    int64
+}
+
+type StatsRepository interface {
+	// Stats aggregates the books matching params that the user on the
+	// context can see. Results are cached briefly per user and params.
+	Stats(ctx context.Context, params StatsParams) (*BookStats, error)
+}
+
+type GormStatsRepository struct {
+	db     *gorm.DB
+	search SearchIndex
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]statsCacheEntry
+}
+
+type statsCacheEntry struct {
+	stats   *BookStats
+	expires time.Time
+}
+
+func NewStatsRepository(db *gorm.DB, ttl time.Duration, opts ...RepositoryOption) StatsRepository {
+	if ttl <= 0 {
+		ttl = DefaultStatsCacheTTL
+	}
+	o := applyRepositoryOptions(opts)
+	return &GormStatsRepository{
+		db:     db,
+		search: o.search,
+		ttl:    ttl,
+		now:    time.Now,
+		cache:  make(map[string]statsCacheEntry),
+	}
+}
+
+func (r *GormStatsRepository) Stats(ctx context.Context, params StatsParams) (*BookStats, error) {
+	params.Books.Page, params.Books.PageSize, params.Books.Sort, params.Books.Facets = 0, 0, "", nil
+	if params.PublishedBy != StatsByDecade {
+		params.PublishedBy = StatsByYear
+	}
+	if params.TopAuthors <= 0 || params.TopAuthors > 50 {
+		params.TopAuthors = 10
+	}
+
+	key, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := viewerID(ctx) + "|" + string(key)
+
+	if stats := r.cached(cacheKey); stats != nil {
+		return stats, nil
+	}
+
+	var stats *BookStats
+	err = withFuzzySearch(r.db.WithContext(ctx), params.Books, func(db *gorm.DB) error {
+		var err error
+		stats, err = r.compute(db, params)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.store(cacheKey, stats)
+	return stats, nil
+}
+
+func (r *GormStatsRepository) cached(key string) *BookStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.cache[key]
+	if !ok || !r.now().Before(entry.expires) {
+		return nil
+	}
+	return entry.stats
+}
+
+// store caches stats under key, dropping entries that have expired so the
+// cache only grows with the filters in use.
+func (r *GormStatsRepository) store(key string, stats *BookStats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for k, entry := range r.cache {
+		if !now.Before(entry.expires) {
+			delete(r.cache, k)
+		}
+	}
+	r.cache[key] = statsCacheEntry{stats: stats, expires: now.Add(r.ttl)}
+}
+
+func (r *GormStatsRepository) compute(db *gorm.DB, params StatsParams) (*BookStats, error) {
+	filtered, _, err := filterBooks(db, r.search, params.Books)
+	if err != nil {
+		return nil, err
+	}
+	// Each aggregate runs over the matching IDs so the filters' unqualified
+	// columns can't clash with joined tables.
+	books := db.Table("books").Where("books.id IN (?)", filtered.Select("books.id"))
+	newQuery := func() *gorm.DB { return books.Session(&gorm.Session{}) }
+
+	var stats BookStats
+
+	var totals struct {
+		Total              int64
+		MissingDescription int64
+		MissingPublishedAt int64
+	}
+	if err := newQuery().
+		Select("COUNT(*) AS total, " +
+			"COALESCE(SUM(CASE WHEN COALESCE(books.description, '') = '' THEN 1 ELSE 0 END), 0) AS missing_description, " +
+			"COALESCE(SUM(CASE WHEN books.published_at IS NULL THEN 1 ELSE 0 END), 0) AS missing_published_at").
+		Scan(&totals).Error; err != nil {
+
+		return nil, err
+	}
+	stats.Total = totals.Total
+	stats.MissingDescription = totals.MissingDescription
+	stats.MissingPublishedAt = totals.MissingPublishedAt
+
+	month := "substr(books.created_at, 1, 7)"
+	if db.Dialector.Name() == "postgres" {
+		month = "to_char(books.created_at, 'YYYY-MM')"
+	}
+	period := publishedYearSQL(db)
+	if params.PublishedBy == StatsByDecade {
+		period = publishedDecadeSQL(db)
+	}
+
+	if err := newQuery().
+		Select(period + " AS year, COUNT(*) AS count").
+		Where("books.published_at IS NOT NULL").
+		Group(period).
+		Order("year ASC").
+		Scan(&stats.Published).Error; err != nil {
+
+		return nil, err
+	}
+
+	if err := newQuery().
+		Select(month + " AS month, COUNT(*) AS count").
+		Group(month).
+		Order("month ASC").
+		Scan(&stats.Added).Error; err != nil {
+
+		return nil, err
+	}
+
+	if err := newQuery().
+		Select("authors.id AS author_id, authors.name AS name, COUNT(*) AS count").
+		Joins("JOIN authors ON authors.id = books.author_id").
+		Group("authors.id, authors.name").
+		Order("count DESC, authors.name ASC").
+		Limit(params.TopAuthors).
+		Scan(&stats.TopAuthors).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// publishedYearSQL extracts the year from books.published_at.
+func publishedYearSQL(db *gorm.DB) string {
+	if db.Dialector.Name() == "postgres" {
+		return "CAST(EXTRACT(YEAR FROM books.published_at) AS INTEGER)"
+	}
+	return "CAST(substr(books.published_at, 1, 4) AS INTEGER)"
+}
+
+// publishedDecadeSQL gives the first year of the decade books.published_at
+// falls in.
+func publishedDecadeSQL(db *gorm.DB) string {
+	return "(" + publishedYearSQL(db) + " / 10) * 10"
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+const (
+	SuggestionBook   = "book"
+	SuggestionAuthor = "author"
+)
+
+type SuggestParams struct {
+	Prefix string
+	// Types limits suggestions to SuggestionBook and SuggestionAuthor;
+	// empty means both.
+	Types []string
+	Limit int
+}
+
+type Suggestion struct {
+	Type string
+	ID   uuid.UUID
+	Text string
+	// Detail tells apart suggestions with the same text: a book's author.
+	Detail string
+}
+
+type SuggestRepository interface {
+	// Suggest returns titles of books the user on the context can see and
+	// author names starting with the prefix, ignoring case. Exact matches
+	// come first, then shorter ones.
+	Suggest(ctx context.Context, params SuggestParams) ([]Suggestion, error)
+}
+
+type GormSuggestRepository struct {
+	db *gorm.DB
+}
+
+func NewSuggestRepository(db *gorm.DB) SuggestRepository {
+	return &GormSuggestRepository{db: db}
+}
+
+func (r *GormSuggestRepository) Suggest(ctx context.Context, params SuggestParams) ([]Suggestion, error) {
+	prefix := strings.ToLower(strings.TrimSpace(params.Prefix))
+	if prefix == "" {
+		return []Suggestion{}, nil
+	}
+	if params.Limit <= 0 || params.Limit > 20 {
+		params.Limit = 8
+	}
+
+	wants := func(kind string) bool {
+		if len(params.Types) == 0 {
+			return true
+		}
+		for _, t := range params.Types {
+			if t == kind {
+				return true
+			}
+		}
+		return false
+	}
+
+	db := r.db.WithContext(ctx)
+	like := escapeLike(prefix) + "%"
+	suggestions := make([]Suggestion, 0, params.Limit)
+
+	// On Postgres both lookups are range scans of the lower-cased prefix
+	// indexes the migration creates, rather than scans of the table.
+	if wants(SuggestionBook) {
+		var books []struct {
+			ID     uuid.UUID
+			Title  string
+			Author string
+		}
+		if err := visibleBooks(db.Model(&model.Book{})).
+			Select("books.id, books.title, authors.name AS author").
+			Joins("JOIN authors ON authors.id = books.author_id").
+			Where("LOWER(books.title) LIKE ? ESCAPE '\\'", like).
+			Order("LOWER(books.title) ASC").
+			Limit(params.Limit).
+			Scan(&books).Error; err != nil {
+
+			return nil, err
+		}
+		for _, b := range books {
+			suggestions = append(suggestions, Suggestion{Type: SuggestionBook, ID: b.ID, Text: b.Title, Detail: b.Author})
+		}
+	}
+
+	if wants(SuggestionAuthor) {
+		var authors []model.Author
+		if err := db.Select("id", "name").
+			Where("LOWER(name) LIKE ? ESCAPE '\\'", like).
+			Order("LOWER(name) ASC").
+			Limit(params.Limit).
+			Find(&authors).Error; err != nil {
+
+			return nil, err
+		}
+		for _, a := range authors {
+			suggestions = append(suggestions, Suggestion{Type: SuggestionAuthor, ID: a.ID, Text: a.Name})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		exactA, exactB := strings.ToLower(a.Text) == prefix, strings.ToLower(b.Text) == prefix
+		if exactA != exactB {
+			return exactA
+		}
+		if len(a.Text) != len(b.Text) {
+			return len(a.Text) < len(b.Text)
+		}
+		return strings.ToLower(a.Text) < strings.ToLower(b.Text)
+	})
+	if len(suggestions) > params.Limit {
+		suggestions = suggestions[:params.Limit]
+	}
+	return suggestions, nil
+}
+
+// escapeLike escapes LIKE's wildcards in s, for use with ESCAPE '\'.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+var ErrTagAlreadyExists = errors.New("tag already exists")
+
+type TagWithCount struct {
+	ID        uuid.UUID
+	Name      string
+	BookCount int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type TagRepository interface {
+	Create(ctx context.Context, tag *model.Tag) error
+	List(ctx context.Context) ([]TagWithCount, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Tag, error)
+	Update(ctx context.Context, tag *model.Tag) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type GormTagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &GormTagRepository{db: db}
+}
+
+func (r *GormTagRepository) Create(ctx context.Context, tag *model.Tag) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureTagNameFree(tx, tag.Name, uuid.Nil); err != nil {
+			return err
+		}
+		return tx.Create(tag).Error
+	})
+}
+
+// List returns every tag with the number of books carrying it, most used first.
+func (r *GormTagRepository) List(ctx context.Context) ([]TagWithCount, error) {
+	var tags []TagWithCount
+
+	if err := r.db.WithContext(ctx).
+		Table("tags").
+		Select("tags.id, tags.name, tags.created_at, tags.updated_at, COUNT(book_tags.book_id) AS book_count").
+		Joins("LEFT JOIN book_tags ON book_tags.tag_id = tags.id").
+		Group("tags.id, tags.name, tags.created_at, tags.updated_at").
+		Order("book_count DESC, tags.name ASC").
+		Scan(&tags).Error; err != nil {
+
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *GormTagRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Tag, error) {
+	var tag model.Tag
+
+	if err := r.db.WithContext(ctx).
+		First(&tag, "id = ?", id).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (r *GormTagRepository) Update(ctx context.Context, tag *model.Tag) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureTagNameFree(tx, tag.Name, tag.ID); err != nil {
+			return err
+		}
+		return tx.Omit("Books").Save(tag).Error
+	})
+}
+
+func (r *GormTagRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM book_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&model.Tag{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func ensureTagNameFree(tx *gorm.DB, name string, self uuid.UUID) error {
+	var count int64
+	if err := tx.Model(&model.Tag{}).
+		Where("name = ? AND id <> ?", model.NormalizeTagName(name), self).
+		Count(&count).Error; err != nil {
+
+		return err
+	}
+	if count > 0 {
+		return ErrTagAlreadyExists
+	}
+	return nil
+}
+
+// resolveTags maps the names of the given tags to stored tags, creating
+// the ones that don't exist yet. Duplicates collapse into one tag.
+func resolveTags(tx *gorm.DB, tags []model.Tag) ([]model.Tag, error) {
+	names := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		name := model.NormalizeTagName(t.Name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return []model.Tag{}, nil
+	}
+
+	var existing []model.Tag
+	if err := tx.Where("name IN ?", names).Find(&existing).Error; err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]model.Tag, len(existing))
+	for _, t := range existing {
+		byName[t.Name] = t
+	}
+
+	resolved := make([]model.Tag, 0, len(names))
+	for _, name := range names {
+		t, ok := byName[name]
+		if !ok {
+			t = model.Tag{Name: name}
+			if err := tx.Create(&t).Error; err != nil {
+				return nil, err
+			}
+		}
+		resolved = append(resolved, t)
+	}
+
+	return resolved, nil
+}
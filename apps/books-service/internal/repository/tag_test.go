@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+func seedTaggedBooks(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	repo := NewGormBookRepository(db)
+	author := model.Author{Name: "Tagged Author"}
+	if err := db.Create(&author).Error; err != nil {
+		t.Fatalf("failed to seed author: %v", err)
+	}
+
+	books := []struct {
+		title string
+		tags  []string
+	}{
+		{"Dune", []string{"SciFi", "classic"}},
+		{"Neuromancer", []string{"scifi", "cyberpunk"}},
+		{"Emma", []string{"classic"}},
+		{"Untagged", nil},
+	}
+
+	for _, b := range books {
+		book := model.Book{Title: b.title, AuthorID: author.ID}
+		for _, name := range b.tags {
+			book.Tags = append(book.Tags, model.Tag{Name: name})
+		}
+		if err := repo.Create(context.Background(), &book); err != nil {
+			t.Fatalf("failed to seed book %q: %v", b.title, err)
+		}
+	}
+}
+
+func listTitles(t *testing.T, repo *GormBookRepository, params BookListParams) []string {
+	t.Helper()
+
+	params.Sort = "title_asc"
+	result, err := repo.List(context.Background(), params)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+
+	titles := make([]string, 0, len(result.Books))
+	for _, b := range result.Books {
+		titles = append(titles, b.Title)
+	}
+	return titles
+}
+
+func TestGormBookRepository_List_FilterByTags(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewGormBookRepository(db)
+	seedTaggedBooks(t, db)
+
+	tests := []struct {
+		name   string
+		params BookListParams
+		want   []string
+	}{
+		{"any", BookListParams{Tags: []string{"cyberpunk", "classic"}, TagMode: TagModeAny}, []string{"Dune", "Emma", "Neuromancer"}},
+		{"all", BookListParams{Tags: []string{"scifi", "classic"}, TagMode: TagModeAll}, []string{"Dune"}},
+		{"exclude", BookListParams{ExcludeTags: []string{"scifi"}}, []string{"Emma", "Untagged"}},
+		{"include and exclude", BookListParams{Tags: []string{"scifi"}, ExcludeTags: []string{"classic"}}, []string{"Neuromancer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := listTitles(t, repo, tt.params)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGormTagRepository_ListCountsBooks(t *testing.T) {
+	db := setupTestDB(t)
+	seedTaggedBooks(t, db)
+
+	tags, err := NewTagRepository(db).List(context.Background())
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+
+	counts := make(map[string]int64, len(tags))
+	for _, tag := range tags {
+		counts[tag.Name] = tag.BookCount
+	}
+
+	if len(counts) != 3 {
+		t.Fatalf("expected 3 distinct tags, got %v", counts)
+	}
+	if counts["scifi"] != 2 || counts["classic"] != 2 || counts["cyberpunk"] != 1 {
+		t.Fatalf("unexpected tag counts: %v", counts)
+	}
+	if tags[len(tags)-1].Name != "cyberpunk" {
+		t.Fatalf("expected least used tag last, got %q", tags[len(tags)-1].Name)
+	}
+}
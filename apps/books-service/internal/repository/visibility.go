@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// viewerID returns the ID of the user a request is made for, or "" when it
+// is anonymous. Book reads are filtered by it, so that every path that
+// loads books, including preloads, applies the same rules.
+func viewerID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	user, _ := auth.UserFromContext(ctx)
+	return user.ID
+}
+
+// sharesGroupSQL matches when the user bound to the first placeholder
+// belongs to a group with the owner column that follows it.
+const sharesGroupSQL = "EXISTS (SELECT 1 FROM group_members mine JOIN group_members theirs ON theirs.group_id = mine.group_id WHERE mine.user_id = ? AND theirs.user_id = "
+
+// notBlockedSQL matches when the owner column that follows it hasn't
+// blocked the user bound to the placeholder.
+const notBlockedSQL = "NOT EXISTS (SELECT 1 FROM blocks WHERE blocks.blocked_id = ? AND blocks.blocker_id = "
+
+// visibleBooks limits a books query to those the viewer on the query's
+// context can see: catalog books, public ones, their own, and groups-only
+// books of members who share a group with them, leaving out books of
+// members who blocked them. It also works as a Preload condition.
+func visibleBooks(db *gorm.DB) *gorm.DB {
+	viewer := viewerID(db.Statement.Context)
+	return db.Where(
+		"books.owner_id IS NULL OR books.visibility = ? OR books.owner_id = ?"+
+			" OR (books.visibility = ? AND "+sharesGroupSQL+"books.owner_id))",
+		model.VisibilityPublic, viewer, model.VisibilityGroups, viewer,
+	).Where(notBlockedSQL+"books.owner_id)", viewer)
+}
+
+// visibleReviews leaves reviews by users who blocked the viewer on the
+// query's context out of a reviews query.
+func visibleReviews(db *gorm.DB) *gorm.DB {
+	return db.Where(notBlockedSQL+"reviews.user_id)", viewerID(db.Statement.Context))
+}
+
+// ensureBookVisible returns ErrBookNotFound unless the book exists and the
+// viewer on the query's context can see it.
+func ensureBookVisible(db *gorm.DB, bookID uuid.UUID) error {
+	var count int64
+	if err := visibleBooks(db.Model(&model.Book{})).
+		Where("books.id = ?", bookID).
+		Count(&count).Error; err != nil {
+
+		return err
+	}
+	if count == 0 {
+		return ErrBookNotFound
+	}
+	return nil
+}
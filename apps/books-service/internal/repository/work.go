@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"gorm.io/gorm"
+)
+
+var ErrBookNotFound = errors.New("book not found")
+
+type WorkSearchParams struct {
+	Page     int
+	PageSize int
+	Query    string
+}
+
+type WorkSearchResult struct {
+	Works []model.Work
+	Total int64
+}
+
+type WorkRepository interface {
+	Create(ctx context.Context, work *model.Work, bookIDs []uuid.UUID) error
+	Search(ctx context.Context, params WorkSearchParams) (WorkSearchResult, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Work, error)
+	Update(ctx context.Context, work *model.Work) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	LinkEditions(ctx context.Context, workID uuid.UUID, bookIDs []uuid.UUID) error
+	UnlinkEdition(ctx context.Context, workID, bookID uuid.UUID) error
+}
+
+type GormWorkRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkRepository(db *gorm.DB) WorkRepository {
+	return &GormWorkRepository{db: db}
+}
+
+func (r *GormWorkRepository) Create(ctx context.Context, work *model.Work, bookIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Editions").Create(work).Error; err != nil {
+			return err
+		}
+		return linkEditions(tx, work.ID, bookIDs)
+	})
+}
+
+// Search matches works by original title and by the title or ISBN of any
+// of their editions, so every edition of a hit is grouped under its work.
+func (r *GormWorkRepository) Search(ctx context.Context, params WorkSearchParams) (WorkSearchResult, error) {
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+
+	db := r.db.WithContext(ctx).Model(&model.Work{})
+
+	if q := strings.TrimSpace(params.Query); q != "" {
+		like := "%" + strings.ToLower(q) + "%"
+
+		editions := visibleBooks(r.db.WithContext(ctx).Model(&model.Book{})).
+			Select("work_id").
+			Where("work_id IS NOT NULL AND LOWER(title) LIKE ?", like)
+		if isbn, err := model.NormalizeISBN(q); err == nil {
+			editions = editions.Or("work_id IS NOT NULL AND isbn = ?", isbn)
+		}
+
+		db = db.Where("LOWER(works.original_title) LIKE ? OR works.id IN (?)", like, editions)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return WorkSearchResult{}, err
+	}
+
+	var works []model.Work
+	if err := preloadWork(db).
+		Order("works.original_title ASC").
+		Limit(params.PageSize).
+		Offset((params.Page - 1) * params.PageSize).
+		Find(&works).Error; err != nil {
+
+		return WorkSearchResult{}, err
+	}
+
+	return WorkSearchResult{Works: works, Total: total}, nil
+}
+
+func (r *GormWorkRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Work, error) {
+	var work model.Work
+
+	if err := preloadWork(r.db.WithContext(ctx)).
+		First(&work, "id = ?", id).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &work, nil
+}
+
+// Update saves the work's own fields and, when work.Contributors is
+// non-nil, replaces its contributors.
+func (r *GormWorkRepository) Update(ctx context.Context, work *model.Work) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Work{}).
+			Where("id = ?", work.ID).
+			Updates(map[string]any{
+				"original_title":     work.OriginalTitle,
+				"first_published_at": work.FirstPublishedAt,
+			}).Error; err != nil {
+
+			return err
+		}
+
+		if work.Contributors == nil {
+			return nil
+		}
+
+		if err := tx.Where("work_id = ?", work.ID).Delete(&model.WorkContributor{}).Error; err != nil {
+			return err
+		}
+		for i := range work.Contributors {
+			work.Contributors[i].ID = uuid.Nil
+			work.Contributors[i].WorkID = work.ID
+		}
+		if len(work.Contributors) == 0 {
+			return nil
+		}
+		return tx.Omit("Author").Create(&work.Contributors).Error
+	})
+}
+
+// Delete removes the work and its contributors; editions are kept as
+// standalone books.
+func (r *GormWorkRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Book{}).
+			Where("work_id = ?", id).
+			Update("work_id", nil).Error; err != nil {
+
+			return err
+		}
+
+		if err := tx.Where("work_id = ?", id).Delete(&model.WorkContributor{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&model.Work{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *GormWorkRepository) LinkEditions(ctx context.Context, workID uuid.UUID, bookIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.Work{}).Where("id = ?", workID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return linkEditions(tx, workID, bookIDs)
+	})
+}
+
+func (r *GormWorkRepository) UnlinkEdition(ctx context.Context, workID, bookID uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.Book{}).
+		Where("id = ? AND work_id = ?", bookID, workID).
+		Update("work_id", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBookNotFound
+	}
+	return nil
+}
+
+func linkEditions(tx *gorm.DB, workID uuid.UUID, bookIDs []uuid.UUID) error {
+	if len(bookIDs) == 0 {
+		return nil
+	}
+
+	result := tx.Model(&model.Book{}).
+		Where("id IN ?", bookIDs).
+		Update("work_id", workID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	unique := make(map[uuid.UUID]bool, len(bookIDs))
+	for _, id := range bookIDs {
+		unique[id] = true
+	}
+	if result.RowsAffected != int64(len(unique)) {
+		return ErrBookNotFound
+	}
+	return nil
+}
+
+func preloadWork(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Contributors.Author").
+		Preload("Editions", func(db *gorm.DB) *gorm.DB {
+			return visibleBooks(db).Order("published_at ASC NULLS LAST, title ASC")
+		}).
+		Preload("Editions.Author").
+		Preload("Editions.Publisher")
+}
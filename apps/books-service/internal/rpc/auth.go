@@ -0,0 +1,38 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// authInterceptor attaches the caller to the context when a call carries a
+// bearer token, like auth.Verifier.Middleware does for HTTP. Calls without
+// one run anonymously; an invalid token fails with UNAUTHENTICATED.
+func authInterceptor(verifier *auth.Verifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return handler(ctx, req)
+		}
+
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return nil, statusError(codes.Unauthenticated,
+				"INVALID_AUTHORIZATION",
+				"authorization metadata must be: Bearer <token>",
+			)
+		}
+
+		user, err := verifier.Parse(token)
+		if err != nil {
+			return nil, statusError(codes.Unauthenticated, "INVALID_TOKEN", err.Error())
+		}
+		return handler(auth.ContextWithUser(ctx, user), req)
+	}
+}
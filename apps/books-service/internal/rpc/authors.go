@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	booksv1 "github.com/snnyvrz/shelfshare/apps/books-service/proto/books/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+type authorsServer struct {
+	booksv1.UnimplementedAuthorsServiceServer
+	repo repository.AuthorRepository
+}
+
+// The inputs carry the binding rules of the REST author requests.
+
+type createAuthorInput struct {
+	Name string `binding:"required,min=1"`
+	Bio  string `binding:"omitempty,max=2000"`
+}
+
+type updateAuthorInput struct {
+	Name *string `binding:"omitempty,min=1"`
+	Bio  *string `binding:"omitempty,max=2000"`
+}
+
+func (s *authorsServer) GetAuthor(ctx context.Context, req *booksv1.GetAuthorRequest) (*booksv1.Author, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument("AUTHOR_INVALID_ID", "invalid author id")
+	}
+
+	author, err := s.repo.FindByID(ctx, id, []string{}...)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFound("AUTHOR_NOT_FOUND", "author not found")
+		}
+		return nil, internal("AUTHOR_FETCH_FAILED", "failed to fetch author")
+	}
+	return toAuthor(*author), nil
+}
+
+func (s *authorsServer) BatchGetAuthors(ctx context.Context, req *booksv1.BatchGetAuthorsRequest) (*booksv1.BatchGetAuthorsResponse, error) {
+	ids, err := parseIDs(req.GetIds(), "AUTHOR_INVALID_ID", "invalid author id")
+	if err != nil {
+		return nil, err
+	}
+
+	authors, err := s.repo.FindByIDs(ctx, ids, []string{}...)
+	if err != nil {
+		return nil, internal("AUTHOR_FETCH_FAILED", "failed to fetch authors")
+	}
+
+	byID := make(map[uuid.UUID]model.Author, len(authors))
+	for _, a := range authors {
+		byID[a.ID] = a
+	}
+	resp := &booksv1.BatchGetAuthorsResponse{}
+	for _, id := range ids {
+		if a, ok := byID[id]; ok {
+			resp.Authors = append(resp.Authors, toAuthor(a))
+		}
+	}
+	return resp, nil
+}
+
+func (s *authorsServer) ListAuthors(ctx context.Context, _ *booksv1.ListAuthorsRequest) (*booksv1.ListAuthorsResponse, error) {
+	authors, err := s.repo.List(ctx, []string{}...)
+	if err != nil {
+		return nil, internal("AUTHOR_LIST_FAILED", "failed to list authors")
+	}
+
+	resp := &booksv1.ListAuthorsResponse{Authors: make([]*booksv1.Author, 0, len(authors))}
+	for _, a := range authors {
+		resp.Authors = append(resp.Authors, toAuthor(a))
+	}
+	return resp, nil
+}
+
+func (s *authorsServer) CreateAuthor(ctx context.Context, req *booksv1.CreateAuthorRequest) (*booksv1.Author, error) {
+	in := createAuthorInput{Name: req.GetName(), Bio: req.GetBio()}
+	if resp := validation.Validate(&in); resp != nil {
+		return nil, validationError(resp)
+	}
+
+	author := model.Author{Name: in.Name, Bio: in.Bio}
+	if err := s.repo.Create(ctx, &author); err != nil {
+		return nil, internal("AUTHOR_CREATE_FAILED", "failed to create author")
+	}
+	return toAuthor(author), nil
+}
+
+func (s *authorsServer) UpdateAuthor(ctx context.Context, req *booksv1.UpdateAuthorRequest) (*booksv1.Author, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument("AUTHOR_INVALID_ID", "invalid author id")
+	}
+	if resp := validation.Validate(&updateAuthorInput{Name: req.Name, Bio: req.Bio}); resp != nil {
+		return nil, validationError(resp)
+	}
+
+	author, err := s.repo.FindByID(ctx, id, []string{}...)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFound("AUTHOR_NOT_FOUND", "author not found")
+		}
+		return nil, internal("AUTHOR_FETCH_FAILED", "failed to fetch author")
+	}
+
+	if req.Name != nil {
+		author.Name = req.GetName()
+	}
+	if req.Bio != nil {
+		author.Bio = req.GetBio()
+	}
+
+	if err := s.repo.Update(ctx, author); err != nil {
+		return nil, internal("AUTHOR_UPDATE_FAILED", "failed to update author")
+	}
+	return toAuthor(*author), nil
+}
+
+func (s *authorsServer) DeleteAuthor(ctx context.Context, req *booksv1.DeleteAuthorRequest) (*emptypb.Empty, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument("AUTHOR_INVALID_ID", "invalid author id")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFound("AUTHOR_NOT_FOUND", "author not found")
+		}
+		return nil, internal("AUTHOR_DELETE_FAILED", "failed to delete author")
+	}
+	return &emptypb.Empty{}, nil
+}
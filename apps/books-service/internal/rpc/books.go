@@ -0,0 +1,300 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/catalog"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	booksv1 "github.com/snnyvrz/shelfshare/apps/books-service/proto/books/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+type booksServer struct {
+	booksv1.UnimplementedBooksServiceServer
+	repo    repository.BookRepository
+	catalog *catalog.Service
+}
+
+// bookIncludes are the relations a Book message has room for.
+var bookIncludes = []string{repository.IncludeAuthor, repository.IncludeTags}
+
+func (s *booksServer) GetBook(ctx context.Context, req *booksv1.GetBookRequest) (*booksv1.Book, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument("INVALID_BOOK_ID", "invalid book id")
+	}
+
+	book, err := s.repo.FindByID(ctx, id, bookIncludes...)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFound("BOOK_NOT_FOUND", "book not found")
+		}
+		return nil, internal("BOOK_FETCH_FAILED", "failed to fetch book")
+	}
+	return toBook(*book), nil
+}
+
+func (s *booksServer) BatchGetBooks(ctx context.Context, req *booksv1.BatchGetBooksRequest) (*booksv1.BatchGetBooksResponse, error) {
+	ids, err := parseIDs(req.GetIds(), "INVALID_BOOK_ID", "invalid book id")
+	if err != nil {
+		return nil, err
+	}
+
+	books, err := s.repo.FindByIDs(ctx, ids, bookIncludes...)
+	if err != nil {
+		return nil, internal("BOOK_FETCH_FAILED", "failed to fetch books")
+	}
+
+	byID := make(map[uuid.UUID]model.Book, len(books))
+	for _, b := range books {
+		byID[b.ID] = b
+	}
+	resp := &booksv1.BatchGetBooksResponse{}
+	for _, id := range ids {
+		if b, ok := byID[id]; ok {
+			resp.Books = append(resp.Books, toBook(b))
+		}
+	}
+	return resp, nil
+}
+
+func (s *booksServer) ListBooks(ctx context.Context, req *booksv1.ListBooksRequest) (*booksv1.ListBooksResponse, error) {
+	params, err := listParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.List(ctx, params)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotGroupMember) {
+			return nil, statusError(codes.PermissionDenied, "NOT_GROUP_MEMBER", "you are not a member of this group")
+		}
+		return nil, internal("BOOK_LIST_FAILED", "failed to list books")
+	}
+
+	resp := &booksv1.ListBooksResponse{
+		Books:    make([]*booksv1.Book, 0, len(result.Books)),
+		Total:    result.Total,
+		Page:     int32(params.Page),
+		PageSize: int32(params.PageSize),
+	}
+	for _, b := range result.Books {
+		resp.Books = append(resp.Books, toBook(b))
+	}
+	return resp, nil
+}
+
+// listParams reads a ListBooksRequest the way GET /api/books reads its
+// query string.
+func listParams(ctx context.Context, req *booksv1.ListBooksRequest) (repository.BookListParams, error) {
+	params := repository.BookListParams{
+		Page:        int(req.GetPage()),
+		PageSize:    int(req.GetPageSize()),
+		Query:       req.GetQuery(),
+		Fuzzy:       req.GetFuzzy(),
+		Tags:        req.GetTags(),
+		TagMode:     req.GetTagsMode(),
+		ExcludeTags: req.GetExcludeTags(),
+		MinRating:   req.MinRating,
+		Available:   req.GetAvailable(),
+		Include:     bookIncludes,
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	var err error
+	if params.AuthorID, err = parseOptionalID(req.GetAuthorId(), "INVALID_AUTHOR_ID", "author_id must be a valid UUID"); err != nil {
+		return params, err
+	}
+	if params.SeriesID, err = parseOptionalID(req.GetSeriesId(), "INVALID_SERIES_ID", "series_id must be a valid UUID"); err != nil {
+		return params, err
+	}
+	if params.WorkID, err = parseOptionalID(req.GetWorkId(), "INVALID_WORK_ID", "work_id must be a valid UUID"); err != nil {
+		return params, err
+	}
+	if params.PublisherID, err = parseOptionalID(req.GetPublisherId(), "INVALID_PUBLISHER_ID", "publisher_id must be a valid UUID"); err != nil {
+		return params, err
+	}
+
+	if v := req.GetSimilarity(); v != 0 {
+		if v < 0 || v > 1 {
+			return params, invalidArgument("INVALID_SIMILARITY", "similarity must be a number greater than 0 and at most 1")
+		}
+		params.FuzzyThreshold = v
+	}
+
+	params.Sort = req.GetSort()
+	if params.Sort == "" {
+		switch {
+		case params.SeriesID != nil:
+			params.Sort = "series_volume_asc"
+		case params.Fuzzy && params.Query != "":
+			params.Sort = "relevance"
+		default:
+			params.Sort = "created_at_desc"
+		}
+	}
+
+	if params.PubAfter, err = parseDate(req.GetPublishedAfter(), "INVALID_PUBLISHED_AFTER", "published_after must be in format YYYY-MM-DD"); err != nil {
+		return params, err
+	}
+	if params.PubBefore, err = parseDate(req.GetPublishedBefore(), "INVALID_PUBLISHED_BEFORE", "published_before must be in format YYYY-MM-DD"); err != nil {
+		return params, err
+	}
+
+	if params.TagMode == "" {
+		params.TagMode = repository.TagModeAny
+	}
+	if params.TagMode != repository.TagModeAny && params.TagMode != repository.TagModeAll {
+		return params, invalidArgument("INVALID_TAGS_MODE", "tags_mode must be one of: any, all")
+	}
+
+	if r := params.MinRating; r != nil && (*r < 1 || *r > 5) {
+		return params, invalidArgument("INVALID_MIN_RATING", "min_rating must be a number between 1 and 5")
+	}
+
+	user, authenticated := auth.UserFromContext(ctx)
+	if params.Shelf = req.GetShelf(); params.Shelf != "" {
+		if !authenticated {
+			return params, statusError(codes.Unauthenticated, "UNAUTHORIZED", "the shelf filter requires authentication")
+		}
+		params.ShelfUserID = user.ID
+	}
+	if params.GroupID, err = parseOptionalID(req.GetGroupId(), "INVALID_GROUP_ID", "group_id must be a valid UUID"); err != nil {
+		return params, err
+	}
+	if params.GroupID != nil {
+		if !authenticated {
+			return params, statusError(codes.Unauthenticated, "UNAUTHORIZED", "the group filter requires authentication")
+		}
+		params.GroupViewerID = user.ID
+	}
+
+	return params, nil
+}
+
+func (s *booksServer) CreateBook(ctx context.Context, req *booksv1.CreateBookRequest) (*booksv1.Book, error) {
+	in := catalog.CreateBookInput{
+		Title:        req.GetTitle(),
+		Description:  req.GetDescription(),
+		Tags:         req.GetTags(),
+		SeriesVolume: req.SeriesVolume,
+		Format:       req.GetFormat(),
+		Language:     req.GetLanguage(),
+		PageCount:    toIntPtr(req.PageCount),
+		ISBN:         req.GetIsbn(),
+		Visibility:   req.GetVisibility(),
+	}
+
+	var err error
+	if in.AuthorID, err = uuid.Parse(req.GetAuthorId()); err != nil {
+		return nil, invalidArgument("INVALID_AUTHOR_ID", "author_id must be a valid UUID")
+	}
+	if in.PublishedAt, err = parseBookDate(req.GetPublishedAt()); err != nil {
+		return nil, err
+	}
+	if in.SeriesID, err = parseOptionalID(req.GetSeriesId(), "INVALID_SERIES_ID", "series_id must be a valid UUID"); err != nil {
+		return nil, err
+	}
+	if in.WorkID, err = parseOptionalID(req.GetWorkId(), "INVALID_WORK_ID", "work_id must be a valid UUID"); err != nil {
+		return nil, err
+	}
+	if in.PublisherID, err = parseOptionalID(req.GetPublisherId(), "INVALID_PUBLISHER_ID", "publisher_id must be a valid UUID"); err != nil {
+		return nil, err
+	}
+
+	book, err := s.catalog.Create(ctx, in, bookIncludes...)
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toBook(*book), nil
+}
+
+func (s *booksServer) UpdateBook(ctx context.Context, req *booksv1.UpdateBookRequest) (*booksv1.Book, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument("INVALID_BOOK_ID", "invalid book id")
+	}
+
+	in := catalog.UpdateBookInput{
+		Title:        req.Title,
+		Description:  req.Description,
+		SeriesID:     req.SeriesId,
+		SeriesVolume: req.SeriesVolume,
+		WorkID:       req.WorkId,
+		Format:       req.Format,
+		Language:     req.Language,
+		PublisherID:  req.PublisherId,
+		PageCount:    toIntPtr(req.PageCount),
+		ISBN:         req.Isbn,
+		Visibility:   req.Visibility,
+	}
+	if req.AuthorId != nil {
+		authorID, err := uuid.Parse(req.GetAuthorId())
+		if err != nil {
+			return nil, invalidArgument("INVALID_AUTHOR_ID", "author_id must be a valid UUID")
+		}
+		in.AuthorID = &authorID
+	}
+	if req.PublishedAt != nil {
+		if in.PublishedAt, err = parseBookDate(req.GetPublishedAt()); err != nil {
+			return nil, err
+		}
+		// An empty date clears it.
+		if in.PublishedAt == nil {
+			in.PublishedAt = &model.Date{}
+		}
+	}
+	if req.GetUpdateTags() {
+		in.Tags = append([]string{}, req.GetTags()...)
+	}
+
+	book, err := s.catalog.Update(ctx, id, in, bookIncludes...)
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toBook(*book), nil
+}
+
+func (s *booksServer) DeleteBook(ctx context.Context, req *booksv1.DeleteBookRequest) (*emptypb.Empty, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument("INVALID_BOOK_ID", "invalid book id")
+	}
+
+	if err := s.catalog.Delete(ctx, id); err != nil {
+		return nil, catalogError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// parseBookDate reads a book's YYYY-MM-DD published date, which may be
+// empty.
+func parseBookDate(s string) (*model.Date, error) {
+	t, err := parseDate(s, "INVALID_PUBLISHED_AT", "published_at must be in format YYYY-MM-DD")
+	if err != nil || t == nil {
+		return nil, err
+	}
+	return &model.Date{Time: *t}, nil
+}
+
+func toIntPtr(n *int32) *int {
+	if n == nil {
+		return nil
+	}
+	i := int(*n)
+	return &i
+}
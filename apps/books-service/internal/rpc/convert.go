@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	booksv1 "github.com/snnyvrz/shelfshare/apps/books-service/proto/books/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const dateLayout = "2006-01-02"
+
+func toAuthor(a model.Author) *booksv1.Author {
+	return &booksv1.Author{
+		Id:        a.ID.String(),
+		Name:      a.Name,
+		Bio:       a.Bio,
+		CreatedAt: timestamppb.New(a.CreatedAt),
+		UpdatedAt: timestamppb.New(a.UpdatedAt),
+	}
+}
+
+func toBook(b model.Book) *booksv1.Book {
+	out := &booksv1.Book{
+		Id:              b.ID.String(),
+		Title:           b.Title,
+		Description:     b.Description,
+		AuthorId:        b.AuthorID.String(),
+		SeriesId:        optionalID(b.SeriesID),
+		SeriesVolume:    b.SeriesVolume,
+		WorkId:          optionalID(b.WorkID),
+		PublisherId:     optionalID(b.PublisherID),
+		Format:          b.Format,
+		Language:        b.Language,
+		Isbn:            b.ISBN,
+		Visibility:      b.Visibility,
+		RatingAverage:   b.RatingAverage,
+		RatingCount:     b.RatingCount,
+		CopyCount:       b.CopyCount,
+		AvailableCopies: b.AvailableCopies,
+		CreatedAt:       timestamppb.New(b.CreatedAt),
+		UpdatedAt:       timestamppb.New(b.UpdatedAt),
+	}
+	if b.PublishedAt != nil && !b.PublishedAt.IsZero() {
+		out.PublishedAt = b.PublishedAt.Format(dateLayout)
+	}
+	if b.Author.ID != uuid.Nil {
+		out.Author = toAuthor(b.Author)
+	}
+	for _, t := range b.Tags {
+		out.Tags = append(out.Tags, t.Name)
+	}
+	if b.PageCount != nil {
+		n := int32(*b.PageCount)
+		out.PageCount = &n
+	}
+	if b.OwnerID != nil {
+		out.OwnerId = *b.OwnerID
+	}
+	return out
+}
+
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// parseIDs parses the IDs of a batch request, of which there may be at
+// most maxBatch.
+func parseIDs(ids []string, reason, message string) ([]uuid.UUID, error) {
+	if len(ids) > maxBatch {
+		return nil, invalidArgument("TOO_MANY_IDS", "at most 100 ids can be fetched at once")
+	}
+
+	parsed := make([]uuid.UUID, 0, len(ids))
+	for _, s := range ids {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, invalidArgument(reason, message)
+		}
+		parsed = append(parsed, id)
+	}
+	return parsed, nil
+}
+
+// parseOptionalID parses an ID that may be empty.
+func parseOptionalID(s, reason, message string) (*uuid.UUID, error) {
+	if s == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil, invalidArgument(reason, message)
+	}
+	return &id, nil
+}
+
+// parseDate parses a YYYY-MM-DD date that may be empty.
+func parseDate(s, reason, message string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return nil, invalidArgument(reason, message)
+	}
+	return &t, nil
+}
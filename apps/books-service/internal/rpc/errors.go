@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/catalog"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain qualifies the reasons in ErrorInfo details.
+const errorDomain = "books.shelfshare"
+
+// statusError returns a status carrying reason, the code the REST API
+// would report, as ErrorInfo so callers can tell errors apart.
+func statusError(code codes.Code, reason, message string) error {
+	st := status.New(code, message)
+	if withInfo, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}); err == nil {
+		st = withInfo
+	}
+	return st.Err()
+}
+
+func invalidArgument(reason, message string) error {
+	return statusError(codes.InvalidArgument, reason, message)
+}
+
+func notFound(reason, message string) error {
+	return statusError(codes.NotFound, reason, message)
+}
+
+func internal(reason, message string) error {
+	return statusError(codes.Internal, reason, message)
+}
+
+// validationError reports the fields that failed validation as a
+// BadRequest, naming them as in the proto messages.
+func validationError(resp *validation.ErrorResponse) error {
+	st := status.New(codes.InvalidArgument, resp.Message)
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(resp.Errors))
+	for _, f := range resp.Errors {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       snakeCase(f.Field),
+			Description: snakeCase(f.Field) + strings.TrimPrefix(f.Message, f.Field),
+		})
+	}
+	withDetails, err := st.WithDetails(
+		&errdetails.ErrorInfo{Reason: resp.Code, Domain: errorDomain},
+		&errdetails.BadRequest{FieldViolations: violations},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// catalogCodes are the gRPC codes of catalog error kinds.
+var catalogCodes = map[catalog.Kind]codes.Code{
+	catalog.KindInvalid:         codes.InvalidArgument,
+	catalog.KindUnauthenticated: codes.Unauthenticated,
+	catalog.KindForbidden:       codes.PermissionDenied,
+	catalog.KindNotFound:        codes.NotFound,
+}
+
+// catalogError converts an error from the catalog service to a status.
+func catalogError(err error) error {
+	var catErr *catalog.Error
+	if !errors.As(err, &catErr) {
+		return internal("INTERNAL_ERROR", "internal server error")
+	}
+	if len(catErr.Fields) > 0 {
+		return validationError(&validation.ErrorResponse{Code: catErr.Code, Message: catErr.Message, Errors: catErr.Fields})
+	}
+
+	code, ok := catalogCodes[catErr.Kind]
+	if !ok {
+		code = codes.Internal
+	}
+	return statusError(code, catErr.Code, catErr.Message)
+}
+
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,55 @@
+// Package rpc serves the gRPC API other backend services use to reach
+// books and authors without going through the public REST API. It shares
+// the repositories, the catalog service and the error codes of the REST
+// handlers; the codes travel as the reason of an ErrorInfo status detail.
+package rpc
+
+import (
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/catalog"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/cover"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	booksv1 "github.com/snnyvrz/shelfshare/apps/books-service/proto/books/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// maxBatch caps the IDs of a batch get.
+const maxBatch = 100
+
+type Option func(*options)
+
+type options struct {
+	covers *cover.Service
+}
+
+// WithCovers deletes a book's cover blobs along with the book.
+func WithCovers(covers *cover.Service) Option {
+	return func(o *options) {
+		o.covers = covers
+	}
+}
+
+// NewServer returns a gRPC server with BooksService and AuthorsService
+// registered, along with the standard health and reflection services.
+// Callers identify themselves with the same tokens as the REST API.
+func NewServer(verifier *auth.Verifier, books repository.BookRepository, authors repository.AuthorRepository, opts ...Option) *grpc.Server {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s := grpc.NewServer(grpc.UnaryInterceptor(authInterceptor(verifier)))
+	booksv1.RegisterBooksServiceServer(s, &booksServer{repo: books, catalog: catalog.NewService(books, o.covers)})
+	booksv1.RegisterAuthorsServiceServer(s, &authorsServer{repo: authors})
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(booksv1.BooksService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(booksv1.AuthorsService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, healthServer)
+
+	reflection.Register(s)
+	return s
+}
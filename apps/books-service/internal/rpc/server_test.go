@@ -0,0 +1,294 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/auth"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/model"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/testutil"
+	booksv1 "github.com/snnyvrz/shelfshare/apps/books-service/proto/books/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+const testJWTSecret = "test_secret"
+
+func setupClient(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	db := testutil.NewTestDB(t)
+	server := NewServer(auth.NewVerifier(testJWTSecret),
+		repository.NewGormBookRepository(db),
+		repository.NewAuthorRepository(db),
+	)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func withToken(t *testing.T, userID string) context.Context {
+	t.Helper()
+
+	token, err := auth.SignToken(testJWTSecret, auth.User{ID: userID}, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// expectStatus checks err's code and the reason in its ErrorInfo.
+func expectStatus(t *testing.T, err error, code codes.Code, reason string) *status.Status {
+	t.Helper()
+
+	st, _ := status.FromError(err)
+	if st.Code() != code {
+		t.Fatalf("expected %s, got %v", code, err)
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			if info.GetReason() != reason {
+				t.Fatalf("expected reason %s, got %s", reason, info.GetReason())
+			}
+			return st
+		}
+	}
+	t.Fatalf("expected an ErrorInfo with reason %s, got %v", reason, st.Details())
+	return st
+}
+
+func TestAuthorsService(t *testing.T) {
+	client := booksv1.NewAuthorsServiceClient(setupClient(t))
+	ctx := context.Background()
+
+	herbert, err := client.CreateAuthor(ctx, &booksv1.CreateAuthorRequest{Name: "Frank Herbert"})
+	if err != nil {
+		t.Fatalf("CreateAuthor returned error: %v", err)
+	}
+	butler, err := client.CreateAuthor(ctx, &booksv1.CreateAuthorRequest{Name: "Octavia Butler"})
+	if err != nil {
+		t.Fatalf("CreateAuthor returned error: %v", err)
+	}
+
+	_, err = client.CreateAuthor(ctx, &booksv1.CreateAuthorRequest{})
+	st := expectStatus(t, err, codes.InvalidArgument, "VALIDATION_ERROR")
+	var fields []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				fields = append(fields, v.GetField())
+			}
+		}
+	}
+	if !slices.Equal(fields, []string{"name"}) {
+		t.Fatalf("expected a violation on name, got %v", fields)
+	}
+
+	got, err := client.GetAuthor(ctx, &booksv1.GetAuthorRequest{Id: herbert.GetId()})
+	if err != nil || got.GetName() != "Frank Herbert" {
+		t.Fatalf("expected Frank Herbert, got %v, err=%v", got, err)
+	}
+
+	batch, err := client.BatchGetAuthors(ctx, &booksv1.BatchGetAuthorsRequest{
+		Ids: []string{butler.GetId(), "00000000-0000-4000-8000-000000000000", herbert.GetId()},
+	})
+	if err != nil {
+		t.Fatalf("BatchGetAuthors returned error: %v", err)
+	}
+	if len(batch.GetAuthors()) != 2 || batch.GetAuthors()[0].GetId() != butler.GetId() {
+		t.Fatalf("expected butler then herbert, got %v", batch.GetAuthors())
+	}
+
+	_, err = client.BatchGetAuthors(ctx, &booksv1.BatchGetAuthorsRequest{Ids: []string{"nope"}})
+	expectStatus(t, err, codes.InvalidArgument, "AUTHOR_INVALID_ID")
+
+	updated, err := client.UpdateAuthor(ctx, &booksv1.UpdateAuthorRequest{Id: herbert.GetId(), Bio: proto.String("Wrote Dune")})
+	if err != nil || updated.GetName() != "Frank Herbert" || updated.GetBio() != "Wrote Dune" {
+		t.Fatalf("expected only the bio to change, got %v, err=%v", updated, err)
+	}
+
+	list, err := client.ListAuthors(ctx, &booksv1.ListAuthorsRequest{})
+	if err != nil || len(list.GetAuthors()) != 2 {
+		t.Fatalf("expected 2 authors, got %v, err=%v", list, err)
+	}
+
+	if _, err := client.DeleteAuthor(ctx, &booksv1.DeleteAuthorRequest{Id: butler.GetId()}); err != nil {
+		t.Fatalf("DeleteAuthor returned error: %v", err)
+	}
+	_, err = client.GetAuthor(ctx, &booksv1.GetAuthorRequest{Id: butler.GetId()})
+	expectStatus(t, err, codes.NotFound, "AUTHOR_NOT_FOUND")
+}
+
+func TestBooksService(t *testing.T) {
+	conn := setupClient(t)
+	authors := booksv1.NewAuthorsServiceClient(conn)
+	client := booksv1.NewBooksServiceClient(conn)
+	ctx := context.Background()
+	alice := withToken(t, "6563a1f0c2a4b5d6e7f80911")
+	bob := withToken(t, "6563a1f0c2a4b5d6e7f80922")
+
+	author, err := authors.CreateAuthor(ctx, &booksv1.CreateAuthorRequest{Name: "Frank Herbert"})
+	if err != nil {
+		t.Fatalf("CreateAuthor returned error: %v", err)
+	}
+
+	dune, err := client.CreateBook(ctx, &booksv1.CreateBookRequest{
+		Title: "Dune", AuthorId: author.GetId(), Tags: []string{"scifi", "classic"},
+		PublishedAt: "1965-08-01", Isbn: "978-0-441-17271-9", PageCount: proto.Int32(412),
+	})
+	if err != nil {
+		t.Fatalf("CreateBook returned error: %v", err)
+	}
+	if dune.GetAuthor().GetName() != "Frank Herbert" || dune.GetIsbn() != "9780441172719" ||
+		dune.GetPublishedAt() != "1965-08-01" || len(dune.GetTags()) != 2 || dune.GetPageCount() != 412 {
+		t.Fatalf("unexpected created book: %v", dune)
+	}
+
+	diary, err := client.CreateBook(alice, &booksv1.CreateBookRequest{
+		Title: "Reading Diary", AuthorId: author.GetId(), Visibility: model.VisibilityPrivate,
+	})
+	if err != nil {
+		t.Fatalf("CreateBook returned error: %v", err)
+	}
+	if diary.GetOwnerId() != "6563a1f0c2a4b5d6e7f80911" {
+		t.Fatalf("expected alice to own the book, got %q", diary.GetOwnerId())
+	}
+
+	_, err = client.CreateBook(ctx, &booksv1.CreateBookRequest{Title: "Secret", AuthorId: author.GetId(), Visibility: model.VisibilityPrivate})
+	expectStatus(t, err, codes.Unauthenticated, "UNAUTHORIZED")
+
+	_, err = client.CreateBook(ctx, &booksv1.CreateBookRequest{Title: "Dune", AuthorId: author.GetId(), Format: "scroll"})
+	expectStatus(t, err, codes.InvalidArgument, "VALIDATION_ERROR")
+
+	// Private books are hidden from everyone but their owner.
+	_, err = client.GetBook(bob, &booksv1.GetBookRequest{Id: diary.GetId()})
+	expectStatus(t, err, codes.NotFound, "BOOK_NOT_FOUND")
+	if got, err := client.GetBook(alice, &booksv1.GetBookRequest{Id: diary.GetId()}); err != nil || got.GetTitle() != "Reading Diary" {
+		t.Fatalf("expected alice to see her book, got %v, err=%v", got, err)
+	}
+
+	batch, err := client.BatchGetBooks(ctx, &booksv1.BatchGetBooksRequest{Ids: []string{diary.GetId(), dune.GetId()}})
+	if err != nil {
+		t.Fatalf("BatchGetBooks returned error: %v", err)
+	}
+	if len(batch.GetBooks()) != 1 || batch.GetBooks()[0].GetId() != dune.GetId() {
+		t.Fatalf("expected only the public book, got %v", batch.GetBooks())
+	}
+
+	list, err := client.ListBooks(alice, &booksv1.ListBooksRequest{AuthorId: author.GetId(), Sort: "title_asc"})
+	if err != nil {
+		t.Fatalf("ListBooks returned error: %v", err)
+	}
+	if list.GetTotal() != 2 || list.GetBooks()[0].GetTitle() != "Dune" || list.GetPage() != 1 || list.GetPageSize() != 20 {
+		t.Fatalf("expected both books, got %v", list)
+	}
+	list, err = client.ListBooks(ctx, &booksv1.ListBooksRequest{Tags: []string{"classic"}})
+	if err != nil || list.GetTotal() != 1 {
+		t.Fatalf("expected the classic, got %v, err=%v", list, err)
+	}
+	_, err = client.ListBooks(ctx, &booksv1.ListBooksRequest{TagsMode: "some"})
+	expectStatus(t, err, codes.InvalidArgument, "INVALID_TAGS_MODE")
+	_, err = client.ListBooks(ctx, &booksv1.ListBooksRequest{Shelf: "currently-reading"})
+	expectStatus(t, err, codes.Unauthenticated, "UNAUTHORIZED")
+
+	updated, err := client.UpdateBook(ctx, &booksv1.UpdateBookRequest{
+		Id: dune.GetId(), Title: proto.String("Dune (Deluxe)"), UpdateTags: true, Isbn: proto.String(""),
+	})
+	if err != nil {
+		t.Fatalf("UpdateBook returned error: %v", err)
+	}
+	if updated.GetTitle() != "Dune (Deluxe)" || len(updated.GetTags()) != 0 || updated.GetIsbn() != "" ||
+		updated.GetPublishedAt() != "1965-08-01" {
+		t.Fatalf("unexpected updated book: %v", updated)
+	}
+
+	_, err = client.UpdateBook(ctx, &booksv1.UpdateBookRequest{Id: dune.GetId()})
+	expectStatus(t, err, codes.InvalidArgument, "NO_FIELDS_TO_UPDATE")
+	_, err = client.UpdateBook(alice, &booksv1.UpdateBookRequest{Id: dune.GetId(), Visibility: proto.String(model.VisibilityPrivate)})
+	expectStatus(t, err, codes.PermissionDenied, "FORBIDDEN")
+
+	// Only its owner can change an owned book, even a public one.
+	notes, err := client.CreateBook(alice, &booksv1.CreateBookRequest{Title: "Notes on Dune", AuthorId: author.GetId()})
+	if err != nil {
+		t.Fatalf("CreateBook returned error: %v", err)
+	}
+	_, err = client.UpdateBook(bob, &booksv1.UpdateBookRequest{Id: notes.GetId(), Title: proto.String("Mine")})
+	expectStatus(t, err, codes.PermissionDenied, "FORBIDDEN")
+	_, err = client.DeleteBook(ctx, &booksv1.DeleteBookRequest{Id: notes.GetId()})
+	expectStatus(t, err, codes.PermissionDenied, "FORBIDDEN")
+	if _, err := client.DeleteBook(alice, &booksv1.DeleteBookRequest{Id: notes.GetId()}); err != nil {
+		t.Fatalf("DeleteBook returned error: %v", err)
+	}
+
+	if _, err := client.DeleteBook(ctx, &booksv1.DeleteBookRequest{Id: dune.GetId()}); err != nil {
+		t.Fatalf("DeleteBook returned error: %v", err)
+	}
+	_, err = client.DeleteBook(ctx, &booksv1.DeleteBookRequest{Id: dune.GetId()})
+	expectStatus(t, err, codes.NotFound, "BOOK_NOT_FOUND")
+
+	badToken := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer nope")
+	_, err = client.GetBook(badToken, &booksv1.GetBookRequest{Id: diary.GetId()})
+	expectStatus(t, err, codes.Unauthenticated, "INVALID_TOKEN")
+}
+
+func TestHealthAndReflection(t *testing.T) {
+	conn := setupClient(t)
+	ctx := context.Background()
+
+	health := healthpb.NewHealthClient(conn)
+	for _, service := range []string{"", booksv1.BooksService_ServiceDesc.ServiceName} {
+		resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("expected %q to be serving, got %v, err=%v", service, resp, err)
+		}
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatalf("failed to open reflection stream: %v", err)
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatalf("failed to send reflection request: %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("failed to receive reflection response: %v", err)
+	}
+
+	var services []string
+	for _, s := range resp.GetListServicesResponse().GetService() {
+		services = append(services, s.GetName())
+	}
+	for _, want := range []string{"shelfshare.books.v1.BooksService", "shelfshare.books.v1.AuthorsService", "grpc.health.v1.Health"} {
+		if !slices.Contains(services, want) {
+			t.Fatalf("expected %s to be listed, got %v", want, services)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/repository"
+)
+
+const (
+	JobExpireHoldOffers        = "expire-hold-offers"
+	JobRemindDueLoans          = "remind-due-loans"
+	JobMarkOverdueLoans        = "mark-overdue-loans"
+	JobPurgeClosedRecords      = "purge-closed-records"
+	JobRefreshBookSimilarities = "refresh-book-similarities"
+)
+
+// ExpireHoldOffers passes lapsed hold offers on to the next person in each
+// book's queue.
+func ExpireHoldOffers(holds repository.HoldRepository) Job {
+	return Job{
+		Name:     JobExpireHoldOffers,
+		Schedule: "@every 1m",
+		Run: func(ctx context.Context) error {
+			n, err := holds.ExpireOffers(ctx, time.Now())
+			if n > 0 {
+				log.Printf("job %s: expired %d hold offers", JobExpireHoldOffers, n)
+			}
+			return err
+		},
+	}
+}
+
+// RemindDueLoans reminds borrowers of copies due back within lead.
+func RemindDueLoans(loans repository.LoanRepository, lead time.Duration) Job {
+	return Job{
+		Name:     JobRemindDueLoans,
+		Schedule: "@hourly",
+		Run: func(ctx context.Context) error {
+			n, err := loans.RemindDue(ctx, time.Now(), lead)
+			if n > 0 {
+				log.Printf("job %s: reminded %d borrowers", JobRemindDueLoans, n)
+			}
+			return err
+		},
+	}
+}
+
+// MarkOverdueLoans flags copies kept past their due date and tells their
+// owners and borrowers.
+func MarkOverdueLoans(loans repository.LoanRepository) Job {
+	return Job{
+		Name:     JobMarkOverdueLoans,
+		Schedule: "@hourly",
+		Run: func(ctx context.Context) error {
+			n, err := loans.MarkOverdue(ctx, time.Now())
+			if n > 0 {
+				log.Printf("job %s: marked %d loans overdue", JobMarkOverdueLoans, n)
+			}
+			return err
+		},
+	}
+}
+
+// PurgeClosedRecords deletes closed holds and read notifications older
+// than retention.
+func PurgeClosedRecords(retention repository.RetentionRepository, period time.Duration) Job {
+	return Job{
+		Name:     JobPurgeClosedRecords,
+		Schedule: "@daily",
+		Run: func(ctx context.Context) error {
+			n, err := retention.Purge(ctx, time.Now().Add(-period))
+			if n > 0 {
+				log.Printf("job %s: purged %d records", JobPurgeClosedRecords, n)
+			}
+			return err
+		},
+	}
+}
+
+// RefreshBookSimilarities rebuilds the table behind similar-book
+// recommendations.
+func RefreshBookSimilarities(similarities repository.SimilarityRepository) Job {
+	return Job{
+		Name:     JobRefreshBookSimilarities,
+		Schedule: "@hourly",
+		Run: func(ctx context.Context) error {
+			n, err := similarities.Refresh(ctx)
+			if err == nil {
+				log.Printf("job %s: scored %d book pairs", JobRefreshBookSimilarities, n)
+			}
+			return err
+		},
+	}
+}
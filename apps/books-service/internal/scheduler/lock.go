@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+	"log"
+	"sync"
+)
+
+// Locker grants exclusive runs of a job. TryLock doesn't wait: ok is false
+// when someone else holds the lock. unlock must be called once the run is
+// over.
+type Locker interface {
+	TryLock(ctx context.Context, name string) (unlock func(), ok bool, err error)
+}
+
+// PostgresLocker elects a leader per job run with Postgres session-level
+// advisory locks, so across replicas only one runs a given job at a time.
+// A replica that dies mid-run loses its connection and with it the lock.
+type PostgresLocker struct {
+	db *sql.DB
+}
+
+func NewPostgresLocker(db *sql.DB) *PostgresLocker {
+	return &PostgresLocker{db: db}
+}
+
+func (l *PostgresLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	// Advisory locks belong to the session that took them, so the lock
+	// and unlock have to go over the same connection.
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	key := lockKey(name)
+
+	var ok bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&ok); err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+	if !ok {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key); err != nil {
+			log.Printf("job %s: release advisory lock: %v", name, err)
+		}
+		conn.Close()
+	}
+	return unlock, true, nil
+}
+
+// lockKey maps a job name onto the advisory lock keyspace, namespaced so
+// other services sharing the database don't collide with it.
+func lockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("books-service:job:" + name))
+	return int64(h.Sum64())
+}
+
+// LocalLocker only keeps runs apart within this process. It suits a single
+// replica and tests.
+type LocalLocker struct {
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+func NewLocalLocker() *LocalLocker {
+	return &LocalLocker{running: make(map[string]bool)}
+}
+
+func (l *LocalLocker) TryLock(_ context.Context, name string) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.running[name] {
+		return nil, false, nil
+	}
+	l.running[name] = true
+
+	return func() {
+		l.mu.Lock()
+		delete(l.running, name)
+		l.mu.Unlock()
+	}, true, nil
+}
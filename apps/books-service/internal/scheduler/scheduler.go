@@ -0,0 +1,190 @@
+// Package scheduler runs the service's background jobs on cron schedules.
+// Every run first takes the job's lock, so when several replicas run the
+// scheduler only the one holding the lock does the work.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrJobLocked   = errors.New("job is already running elsewhere")
+)
+
+// Job is a unit of background work. Schedule is a five-field cron
+// expression or a descriptor such as "@hourly" or "@every 1m".
+type Job struct {
+	Name     string
+	Schedule string
+	Run      func(ctx context.Context) error
+}
+
+// JobStats describes a job's runs since the process started.
+type JobStats struct {
+	Name          string
+	Schedule      string
+	Runs          int64
+	Failures      int64
+	Skipped       int64
+	LastStartedAt *time.Time
+	LastDuration  time.Duration
+	LastError     string
+	NextRunAt     *time.Time
+}
+
+type Scheduler struct {
+	cron   *cron.Cron
+	locker Locker
+
+	mu      sync.Mutex
+	jobs    map[string]Job
+	entries map[string]cron.EntryID
+	stats   map[string]*JobStats
+	ctx     context.Context
+}
+
+func New(locker Locker) *Scheduler {
+	return &Scheduler{
+		cron:    cron.New(cron.WithLocation(time.UTC)),
+		locker:  locker,
+		jobs:    make(map[string]Job),
+		entries: make(map[string]cron.EntryID),
+		stats:   make(map[string]*JobStats),
+		ctx:     context.Background(),
+	}
+}
+
+// Register adds a job. It fails if the name is taken or the schedule
+// doesn't parse.
+func (s *Scheduler) Register(job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[job.Name]; ok {
+		return fmt.Errorf("job %q registered twice", job.Name)
+	}
+
+	id, err := s.cron.AddFunc(job.Schedule, func() {
+		s.mu.Lock()
+		ctx := s.ctx
+		s.mu.Unlock()
+
+		if err := s.run(ctx, job); err != nil && !errors.Is(err, ErrJobLocked) {
+			log.Printf("job %s failed: %v", job.Name, err)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("job %q: invalid schedule %q: %w", job.Name, job.Schedule, err)
+	}
+
+	s.jobs[job.Name] = job
+	s.entries[job.Name] = id
+	s.stats[job.Name] = &JobStats{Name: job.Name, Schedule: job.Schedule}
+	return nil
+}
+
+// Start runs jobs on their schedules until ctx is cancelled or Stop is
+// called. Runs receive ctx.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	s.ctx = ctx
+	s.mu.Unlock()
+
+	s.cron.Start()
+	go func() {
+		<-ctx.Done()
+		s.Stop()
+	}()
+}
+
+// Stop stops scheduling new runs and waits for running ones to finish.
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
+
+// RunNow runs a job immediately, outside its schedule. It still takes the
+// job's lock and returns ErrJobLocked when another run holds it.
+func (s *Scheduler) RunNow(ctx context.Context, name string) error {
+	s.mu.Lock()
+	job, ok := s.jobs[name]
+	s.mu.Unlock()
+
+	if !ok {
+		return ErrJobNotFound
+	}
+	return s.run(ctx, job)
+}
+
+// Stats returns every job's stats, ordered by name.
+func (s *Scheduler) Stats() []JobStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]JobStats, 0, len(s.stats))
+	for name, st := range s.stats {
+		cp := *st
+		if next := s.cron.Entry(s.entries[name]).Next; !next.IsZero() {
+			cp.NextRunAt = &next
+		}
+		out = append(out, cp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) error {
+	unlock, ok, err := s.locker.TryLock(ctx, job.Name)
+	if err != nil {
+		s.record(job.Name, func(st *JobStats) {
+			st.Failures++
+			st.LastError = err.Error()
+		})
+		return fmt.Errorf("acquire lock: %w", err)
+	}
+	if !ok {
+		s.record(job.Name, func(st *JobStats) { st.Skipped++ })
+		return ErrJobLocked
+	}
+	defer unlock()
+
+	started := time.Now()
+	log.Printf("job %s started", job.Name)
+
+	runErr := job.Run(ctx)
+	elapsed := time.Since(started)
+
+	s.record(job.Name, func(st *JobStats) {
+		st.Runs++
+		st.LastStartedAt = &started
+		st.LastDuration = elapsed
+		st.LastError = ""
+		if runErr != nil {
+			st.Failures++
+			st.LastError = runErr.Error()
+		}
+	})
+
+	if runErr != nil {
+		return runErr
+	}
+	log.Printf("job %s finished in %s", job.Name, elapsed)
+	return nil
+}
+
+func (s *Scheduler) record(name string, update func(*JobStats)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if st, ok := s.stats[name]; ok {
+		update(st)
+	}
+}
@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestScheduler_RunNowRecordsStats(t *testing.T) {
+	s := New(NewLocalLocker())
+
+	fail := false
+	runs := 0
+	err := s.Register(Job{
+		Name:     "count",
+		Schedule: "@every 1h",
+		Run: func(context.Context) error {
+			runs++
+			if fail {
+				return errors.New("boom")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	if err := s.RunNow(context.Background(), "count"); err != nil {
+		t.Fatalf("RunNow failed: %v", err)
+	}
+	fail = true
+	if err := s.RunNow(context.Background(), "count"); err == nil {
+		t.Fatalf("expected the failing run to return its error")
+	}
+
+	if runs != 2 {
+		t.Fatalf("expected 2 runs, got %d", runs)
+	}
+
+	stats := s.Stats()
+	if len(stats) != 1 {
+		t.Fatalf("expected stats for 1 job, got %d", len(stats))
+	}
+	st := stats[0]
+	if st.Runs != 2 || st.Failures != 1 || st.LastError != "boom" || st.LastStartedAt == nil {
+		t.Fatalf("unexpected stats: %+v", st)
+	}
+
+	if err := s.RunNow(context.Background(), "missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestScheduler_SkipsRunsWhileLocked(t *testing.T) {
+	locker := NewLocalLocker()
+	s := New(locker)
+
+	ran := false
+	if err := s.Register(Job{
+		Name:     "exclusive",
+		Schedule: "@every 1h",
+		Run: func(context.Context) error {
+			ran = true
+			return nil
+		},
+	}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	// Another replica holds the lock.
+	unlock, ok, _ := locker.TryLock(context.Background(), "exclusive")
+	if !ok {
+		t.Fatalf("expected to take the lock")
+	}
+
+	if err := s.RunNow(context.Background(), "exclusive"); !errors.Is(err, ErrJobLocked) {
+		t.Fatalf("expected ErrJobLocked, got %v", err)
+	}
+	if ran {
+		t.Fatalf("expected the job not to run while locked")
+	}
+	if st := s.Stats()[0]; st.Skipped != 1 || st.Runs != 0 {
+		t.Fatalf("expected one skipped run, got %+v", st)
+	}
+
+	unlock()
+	if err := s.RunNow(context.Background(), "exclusive"); err != nil {
+		t.Fatalf("RunNow failed after unlock: %v", err)
+	}
+	if !ran {
+		t.Fatalf("expected the job to run once the lock was free")
+	}
+}
+
+func TestScheduler_RegisterRejectsBadJobs(t *testing.T) {
+	s := New(NewLocalLocker())
+	noop := func(context.Context) error { return nil }
+
+	if err := s.Register(Job{Name: "a", Schedule: "not a schedule", Run: noop}); err == nil {
+		t.Fatalf("expected an invalid schedule to be rejected")
+	}
+	if err := s.Register(Job{Name: "a", Schedule: "*/5 * * * *", Run: noop}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := s.Register(Job{Name: "a", Schedule: "@daily", Run: noop}); err == nil {
+		t.Fatalf("expected a duplicate name to be rejected")
+	}
+}
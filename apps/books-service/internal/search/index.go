@@ -0,0 +1,488 @@
+// Package search is an embedded full-text index of books, for single-node
+// deployments that don't want to lean on the database for search. The
+// index lives in memory; changes are appended to a log file that is
+// replayed, and compacted, when the index is opened again.
+package search
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Document is what the index knows about a book.
+type Document struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Author      string    `json:"author"`
+	Description string    `json:"description"`
+}
+
+// How much a term found in each field counts towards a book's score.
+const (
+	titleWeight       = 3.0
+	authorWeight      = 2.0
+	descriptionWeight = 1.0
+)
+
+// A prefix match scores this fraction of an exact one.
+const prefixFactor = 0.5
+
+type Options struct {
+	// Fuzzy matches terms by trigram similarity of at least Threshold,
+	// tolerating typos, rather than by prefix.
+	Fuzzy     bool
+	Threshold float64
+	// Limit caps the number of hits; zero returns all of them.
+	Limit int
+}
+
+type Hit struct {
+	ID    uuid.UUID
+	Score float64
+}
+
+type Index struct {
+	mu sync.RWMutex
+
+	docs map[uuid.UUID]Document
+	// postings maps each term to the documents containing it and the
+	// weight of the best field it appears in.
+	postings map[string]map[uuid.UUID]float64
+	// grams maps each trigram to the terms containing it.
+	grams map[string]map[string]struct{}
+	// sorted holds the terms in order for prefix lookups; nil when stale.
+	sorted []string
+
+	path string
+	log  *os.File
+}
+
+type logEntry struct {
+	Op  string    `json:"op"`
+	Doc *Document `json:"doc,omitempty"`
+	ID  uuid.UUID `json:"id,omitempty"`
+}
+
+const (
+	opPut    = "put"
+	opDelete = "delete"
+)
+
+// Open loads the index logged at path, creating it if needed. An empty
+// path keeps the index in memory only.
+func Open(path string) (*Index, error) {
+	ix := &Index{
+		docs:     make(map[uuid.UUID]Document),
+		postings: make(map[string]map[uuid.UUID]float64),
+		grams:    make(map[string]map[string]struct{}),
+		path:     path,
+	}
+	if path == "" {
+		return ix, nil
+	}
+
+	if err := ix.replay(); err != nil {
+		return nil, err
+	}
+	// Rewriting the log drops entries that later ones superseded.
+	if err := ix.compact(); err != nil {
+		return nil, err
+	}
+	return ix, nil
+}
+
+func (ix *Index) replay() error {
+	f, err := os.Open(ix.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry logEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A torn final line from a crash mid-write; everything before
+			// it is intact.
+			break
+		}
+		switch entry.Op {
+		case opPut:
+			if entry.Doc != nil {
+				ix.put(*entry.Doc)
+			}
+		case opDelete:
+			ix.remove(entry.ID)
+		}
+	}
+	return scanner.Err()
+}
+
+// compact rewrites the log as one put per document and reopens it for
+// appending.
+func (ix *Index) compact() error {
+	if ix.log != nil {
+		if err := ix.log.Close(); err != nil {
+			return err
+		}
+		ix.log = nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(ix.path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(ix.path), ".search-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, doc := range ix.docs {
+		if err := enc.Encode(logEntry{Op: opPut, Doc: &doc}); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), ix.path); err != nil {
+		return err
+	}
+
+	ix.log, err = os.OpenFile(ix.path, os.O_WRONLY|os.O_APPEND, 0o644)
+	return err
+}
+
+func (ix *Index) append(entries ...logEntry) error {
+	if ix.log == nil {
+		return nil
+	}
+
+	var buf strings.Builder
+	enc := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	_, err := ix.log.WriteString(buf.String())
+	return err
+}
+
+// Put adds documents, replacing any with the same IDs.
+func (ix *Index) Put(docs ...Document) error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	entries := make([]logEntry, 0, len(docs))
+	for i := range docs {
+		ix.remove(docs[i].ID)
+		ix.put(docs[i])
+		entries = append(entries, logEntry{Op: opPut, Doc: &docs[i]})
+	}
+	return ix.append(entries...)
+}
+
+// Delete drops documents; unknown IDs are ignored.
+func (ix *Index) Delete(ids ...uuid.UUID) error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	entries := make([]logEntry, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := ix.docs[id]; !ok {
+			continue
+		}
+		ix.remove(id)
+		entries = append(entries, logEntry{Op: opDelete, ID: id})
+	}
+	return ix.append(entries...)
+}
+
+// Reset empties the index, ahead of putting every document back.
+func (ix *Index) Reset() error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.docs = make(map[uuid.UUID]Document)
+	ix.postings = make(map[string]map[uuid.UUID]float64)
+	ix.grams = make(map[string]map[string]struct{})
+	ix.sorted = nil
+
+	if ix.path == "" {
+		return nil
+	}
+	return ix.compact()
+}
+
+// Len returns the number of documents in the index.
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+func (ix *Index) Close() error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	if ix.log == nil {
+		return nil
+	}
+	err := ix.log.Close()
+	ix.log = nil
+	return err
+}
+
+func (ix *Index) put(doc Document) {
+	ix.docs[doc.ID] = doc
+	for term, weight := range documentTerms(doc) {
+		postings, ok := ix.postings[term]
+		if !ok {
+			postings = make(map[uuid.UUID]float64)
+			ix.postings[term] = postings
+			for _, g := range trigrams(term) {
+				if ix.grams[g] == nil {
+					ix.grams[g] = make(map[string]struct{})
+				}
+				ix.grams[g][term] = struct{}{}
+			}
+			ix.sorted = nil
+		}
+		postings[doc.ID] = weight
+	}
+}
+
+func (ix *Index) remove(id uuid.UUID) {
+	doc, ok := ix.docs[id]
+	if !ok {
+		return
+	}
+	delete(ix.docs, id)
+
+	for term := range documentTerms(doc) {
+		postings := ix.postings[term]
+		delete(postings, id)
+		if len(postings) > 0 {
+			continue
+		}
+		delete(ix.postings, term)
+		for _, g := range trigrams(term) {
+			delete(ix.grams[g], term)
+			if len(ix.grams[g]) == 0 {
+				delete(ix.grams, g)
+			}
+		}
+		ix.sorted = nil
+	}
+}
+
+// documentTerms returns the doc's terms with the weight of the best field
+// each appears in.
+func documentTerms(doc Document) map[string]float64 {
+	terms := make(map[string]float64)
+	for _, field := range []struct {
+		text   string
+		weight float64
+	}{
+		{doc.Title, titleWeight},
+		{doc.Author, authorWeight},
+		{doc.Description, descriptionWeight},
+	} {
+		for _, term := range tokenize(field.text) {
+			if field.weight > terms[term] {
+				terms[term] = field.weight
+			}
+		}
+	}
+	return terms
+}
+
+// Search returns the documents matching q, best first. By default each
+// term of q must start a term of the document; with Fuzzy the document's
+// terms need only be similar enough to q's on average.
+func (ix *Index) Search(q string, opts Options) []Hit {
+	tokens := tokenize(q)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	if opts.Fuzzy {
+		ix.mu.RLock()
+		defer ix.mu.RUnlock()
+		return ix.rank(ix.fuzzyScores(tokens, opts.Threshold), opts.Limit)
+	}
+
+	for {
+		ix.mu.RLock()
+		if ix.sorted != nil {
+			defer ix.mu.RUnlock()
+			return ix.rank(ix.prefixScores(tokens), opts.Limit)
+		}
+		ix.mu.RUnlock()
+
+		ix.mu.Lock()
+		if ix.sorted == nil {
+			ix.sorted = make([]string, 0, len(ix.postings))
+			for term := range ix.postings {
+				ix.sorted = append(ix.sorted, term)
+			}
+			sort.Strings(ix.sorted)
+		}
+		ix.mu.Unlock()
+	}
+}
+
+// prefixScores scores documents containing a term starting with each
+// token, favouring exact matches and better fields.
+func (ix *Index) prefixScores(tokens []string) map[uuid.UUID]float64 {
+	var scores map[uuid.UUID]float64
+	for i, token := range tokens {
+		best := make(map[uuid.UUID]float64)
+		start := sort.SearchStrings(ix.sorted, token)
+		for _, term := range ix.sorted[start:] {
+			if !strings.HasPrefix(term, token) {
+				break
+			}
+			factor := prefixFactor
+			if term == token {
+				factor = 1
+			}
+			for id, weight := range ix.postings[term] {
+				if s := weight * factor; s > best[id] {
+					best[id] = s
+				}
+			}
+		}
+
+		if i == 0 {
+			scores = best
+			continue
+		}
+		for id := range scores {
+			if s, ok := best[id]; ok {
+				scores[id] += s
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+	return scores
+}
+
+// fuzzyScores scores documents by how many of each token's trigrams their
+// terms share, keeping those whose average similarity over the tokens
+// reaches threshold.
+func (ix *Index) fuzzyScores(tokens []string, threshold float64) map[uuid.UUID]float64 {
+	type match struct {
+		similarity float64
+		score      float64
+	}
+	matches := make(map[uuid.UUID][]match)
+
+	for i, token := range tokens {
+		grams := trigrams(token)
+		shared := make(map[string]int)
+		for _, g := range grams {
+			for term := range ix.grams[g] {
+				shared[term]++
+			}
+		}
+
+		for term, n := range shared {
+			similarity := float64(n) / float64(len(grams))
+			for id, weight := range ix.postings[term] {
+				m := matches[id]
+				if m == nil {
+					m = make([]match, len(tokens))
+					matches[id] = m
+				}
+				if similarity > m[i].similarity {
+					m[i] = match{similarity: similarity, score: similarity * weight}
+				}
+			}
+		}
+	}
+
+	scores := make(map[uuid.UUID]float64)
+	for id, m := range matches {
+		var similarity, score float64
+		for _, t := range m {
+			similarity += t.similarity
+			score += t.score
+		}
+		if similarity/float64(len(tokens)) >= threshold {
+			scores[id] = score
+		}
+	}
+	return scores
+}
+
+func (ix *Index) rank(scores map[uuid.UUID]float64, limit int) []Hit {
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		ti, tj := strings.ToLower(ix.docs[hits[i].ID].Title), strings.ToLower(ix.docs[hits[j].ID].Title)
+		if ti != tj {
+			return ti < tj
+		}
+		return hits[i].ID.String() < hits[j].ID.String()
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// tokenize lowercases s and splits it into runs of letters and digits.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// trigrams returns the distinct three-letter runs of term, or term itself
+// when it is shorter.
+func trigrams(term string) []string {
+	runes := []rune(term)
+	if len(runes) < 3 {
+		return []string{term}
+	}
+
+	seen := make(map[string]bool, len(runes)-2)
+	grams := make([]string, 0, len(runes)-2)
+	for i := 0; i+3 <= len(runes); i++ {
+		g := string(runes[i : i+3])
+		if !seen[g] {
+			seen[g] = true
+			grams = append(grams, g)
+		}
+	}
+	return grams
+}
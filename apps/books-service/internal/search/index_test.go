@@ -0,0 +1,118 @@
+package search
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func hitIDs(hits []Hit) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.ID)
+	}
+	return ids
+}
+
+func seed(t *testing.T, ix *Index) (dispossessed, lathe, crime Document) {
+	t.Helper()
+
+	dispossessed = Document{ID: uuid.New(), Title: "The Dispossessed", Author: "Ursula K. Le Guin", Description: "An anarchist physicist on a world without crime"}
+	lathe = Document{ID: uuid.New(), Title: "The Lathe of Heaven", Author: "Ursula K. Le Guin", Description: "Dreams that change the world"}
+	crime = Document{ID: uuid.New(), Title: "Crime and Punishment", Author: "Fyodor Dostoevsky", Description: "A student in Saint Petersburg dreams of a crime"}
+	if err := ix.Put(dispossessed, lathe, crime); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	return dispossessed, lathe, crime
+}
+
+func TestIndex_Search(t *testing.T) {
+	ix, err := Open("")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	dispossessed, lathe, crime := seed(t, ix)
+
+	if got := hitIDs(ix.Search("le guin", Options{})); len(got) != 2 {
+		t.Fatalf("expected both Le Guin books, got %v", got)
+	}
+	if got := hitIDs(ix.Search("DISPOSS", Options{})); len(got) != 1 || got[0] != dispossessed.ID {
+		t.Fatalf("expected a prefix to match, got %v", got)
+	}
+
+	// A title match outranks a description match.
+	got := hitIDs(ix.Search("crime", Options{}))
+	if len(got) != 2 || got[0] != crime.ID {
+		t.Fatalf("expected Crime and Punishment first, got %v", got)
+	}
+	got = hitIDs(ix.Search("dreams", Options{}))
+	if len(got) != 2 {
+		t.Fatalf("expected two books about dreams, got %v", got)
+	}
+
+	if got := ix.Search("dostoyevsky", Options{}); len(got) != 0 {
+		t.Fatalf("expected no exact match for a misspelling, got %v", got)
+	}
+	if got := hitIDs(ix.Search("dostoyevsky", Options{Fuzzy: true, Threshold: 0.5})); len(got) != 1 || got[0] != crime.ID {
+		t.Fatalf("expected a fuzzy match on the author, got %v", got)
+	}
+	if got := hitIDs(ix.Search("lathe of haevn", Options{Fuzzy: true, Threshold: 0.5})); len(got) == 0 || got[0] != lathe.ID {
+		t.Fatalf("expected the misspelled title ranked first, got %v", got)
+	}
+
+	if err := ix.Delete(lathe.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if got := ix.Search("lathe", Options{}); len(got) != 0 {
+		t.Fatalf("expected a deleted book to be gone, got %v", got)
+	}
+
+	lathe.Title = "Lathe"
+	if err := ix.Put(dispossessed, lathe); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if got := ix.Search("le guin", Options{Limit: 1}); len(got) != 1 || ix.Len() != 3 {
+		t.Fatalf("expected limit to cap hits over 3 documents, got %v and %d", got, ix.Len())
+	}
+}
+
+func TestIndex_PersistsAcrossOpens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.idx")
+
+	ix, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	dispossessed, lathe, _ := seed(t, ix)
+	if err := ix.Delete(lathe.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := ix.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	ix, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer ix.Close()
+	if ix.Len() != 2 {
+		t.Fatalf("expected 2 documents after replay, got %d", ix.Len())
+	}
+	if got := hitIDs(ix.Search("dispossessed", Options{})); len(got) != 1 || got[0] != dispossessed.ID {
+		t.Fatalf("expected the replayed document to be searchable, got %v", got)
+	}
+
+	if err := ix.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	ix.Close()
+	ix, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	if ix.Len() != 0 {
+		t.Fatalf("expected an empty index after reset, got %d", ix.Len())
+	}
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/snnyvrz/shelfshare/apps/books-service/internal/config"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// LocalMediaPath is where main serves the local backend's files when no
+// BLOB_PUBLIC_URL is configured.
+const LocalMediaPath = "/media"
+
+func NewFromConfig(ctx context.Context, cfg *config.Config) (BlobStore, error) {
+	switch cfg.BlobBackend {
+	case "local", "":
+		publicURL := cfg.BlobPublicURL
+		if publicURL == "" {
+			publicURL = LocalMediaPath
+		}
+		return NewLocalStore(cfg.BlobLocalDir, publicURL)
+	case "s3":
+		return NewS3Store(ctx, S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			UseSSL:    cfg.S3UseSSL,
+			PublicURL: cfg.BlobPublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown BLOB_BACKEND %q", cfg.BlobBackend)
+	}
+}
@@ -23,7 +23,7 @@ func NewTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("failed to connect to test database: %v", err)
 	}
 
-	if err := db.AutoMigrate(&model.Author{}, &model.Book{}); err != nil {
+	if err := db.AutoMigrate(&model.Author{}, &model.Series{}, &model.Work{}, &model.WorkContributor{}, &model.Publisher{}, &model.Book{}, &model.Tag{}, &model.Shelf{}, &model.ShelfEntry{}, &model.Review{}, &model.ProgressEntry{}, &model.Copy{}, &model.Hold{}, &model.Notification{}, &model.NotificationPreference{}, &model.Group{}, &model.GroupMember{}, &model.GroupInvite{}, &model.CopyGroup{}, &model.Follow{}, &model.AccountSettings{}, &model.Block{}, &model.BookSimilarity{}, &model.Activity{}); err != nil {
 		t.Fatalf("failed to migrate test database: %v", err)
 	}
 
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -45,6 +46,25 @@ func BindAndValidateJSON(c *gin.Context, dst any) bool {
 	return true
 }
 
+// Validate checks dst against its binding tags with the same rules and
+// error format as BindAndValidateJSON, for input that doesn't arrive as a
+// JSON body. It returns nil when dst is valid.
+func Validate(dst any) *ErrorResponse {
+	err := binding.Validator.ValidateStruct(dst)
+	if err == nil {
+		return nil
+	}
+
+	if verrs, ok := err.(validator.ValidationErrors); ok {
+		resp := formatValidationErrors(verrs)
+		return &resp
+	}
+	return &ErrorResponse{
+		Code:    "VALIDATION_ERROR",
+		Message: err.Error(),
+	}
+}
+
 func formatValidationErrors(verrs validator.ValidationErrors) ErrorResponse {
 	fields := make([]FieldError, 0, len(verrs))
 
@@ -0,0 +1,585 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: books/v1/authors.proto
+
+package booksv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Author struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Bio           string                 `protobuf:"bytes,3,opt,name=bio,proto3" json:"bio,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Author) Reset() {
+	*x = Author{}
+	mi := &file_books_v1_authors_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Author) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Author) ProtoMessage() {}
+
+func (x *Author) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_authors_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Author.ProtoReflect.Descriptor instead.
+func (*Author) Descriptor() ([]byte, []int) {
+	return file_books_v1_authors_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Author) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Author) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Author) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *Author) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Author) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuthorRequest) Reset() {
+	*x = GetAuthorRequest{}
+	mi := &file_books_v1_authors_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthorRequest) ProtoMessage() {}
+
+func (x *GetAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_authors_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthorRequest.ProtoReflect.Descriptor instead.
+func (*GetAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_books_v1_authors_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetAuthorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type BatchGetAuthorsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most 100 IDs.
+	Ids           []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetAuthorsRequest) Reset() {
+	*x = BatchGetAuthorsRequest{}
+	mi := &file_books_v1_authors_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetAuthorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetAuthorsRequest) ProtoMessage() {}
+
+func (x *BatchGetAuthorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_authors_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetAuthorsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetAuthorsRequest) Descriptor() ([]byte, []int) {
+	return file_books_v1_authors_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchGetAuthorsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetAuthorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Authors       []*Author              `protobuf:"bytes,1,rep,name=authors,proto3" json:"authors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetAuthorsResponse) Reset() {
+	*x = BatchGetAuthorsResponse{}
+	mi := &file_books_v1_authors_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetAuthorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetAuthorsResponse) ProtoMessage() {}
+
+func (x *BatchGetAuthorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_authors_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetAuthorsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetAuthorsResponse) Descriptor() ([]byte, []int) {
+	return file_books_v1_authors_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetAuthorsResponse) GetAuthors() []*Author {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+type ListAuthorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuthorsRequest) Reset() {
+	*x = ListAuthorsRequest{}
+	mi := &file_books_v1_authors_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuthorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuthorsRequest) ProtoMessage() {}
+
+func (x *ListAuthorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_authors_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuthorsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuthorsRequest) Descriptor() ([]byte, []int) {
+	return file_books_v1_authors_proto_rawDescGZIP(), []int{4}
+}
+
+type ListAuthorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Authors       []*Author              `protobuf:"bytes,1,rep,name=authors,proto3" json:"authors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuthorsResponse) Reset() {
+	*x = ListAuthorsResponse{}
+	mi := &file_books_v1_authors_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuthorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuthorsResponse) ProtoMessage() {}
+
+func (x *ListAuthorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_authors_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuthorsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuthorsResponse) Descriptor() ([]byte, []int) {
+	return file_books_v1_authors_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAuthorsResponse) GetAuthors() []*Author {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+type CreateAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Bio           string                 `protobuf:"bytes,2,opt,name=bio,proto3" json:"bio,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuthorRequest) Reset() {
+	*x = CreateAuthorRequest{}
+	mi := &file_books_v1_authors_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuthorRequest) ProtoMessage() {}
+
+func (x *CreateAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_authors_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuthorRequest.ProtoReflect.Descriptor instead.
+func (*CreateAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_books_v1_authors_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateAuthorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAuthorRequest) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+type UpdateAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Bio           *string                `protobuf:"bytes,3,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAuthorRequest) Reset() {
+	*x = UpdateAuthorRequest{}
+	mi := &file_books_v1_authors_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAuthorRequest) ProtoMessage() {}
+
+func (x *UpdateAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_authors_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAuthorRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_books_v1_authors_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateAuthorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAuthorRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateAuthorRequest) GetBio() string {
+	if x != nil && x.Bio != nil {
+		return *x.Bio
+	}
+	return ""
+}
+
+type DeleteAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAuthorRequest) Reset() {
+	*x = DeleteAuthorRequest{}
+	mi := &file_books_v1_authors_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAuthorRequest) ProtoMessage() {}
+
+func (x *DeleteAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_authors_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAuthorRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_books_v1_authors_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteAuthorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_books_v1_authors_proto protoreflect.FileDescriptor
+
+const file_books_v1_authors_proto_rawDesc = "" +
+	"\n" +
+	"\x16books/v1/authors.proto\x12\x13shelfshare.books.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb4\x01\n" +
+	"\x06Author\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03bio\x18\x03 \x01(\tR\x03bio\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\"\n" +
+	"\x10GetAuthorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"*\n" +
+	"\x16BatchGetAuthorsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"P\n" +
+	"\x17BatchGetAuthorsResponse\x125\n" +
+	"\aauthors\x18\x01 \x03(\v2\x1b.shelfshare.books.v1.AuthorR\aauthors\"\x14\n" +
+	"\x12ListAuthorsRequest\"L\n" +
+	"\x13ListAuthorsResponse\x125\n" +
+	"\aauthors\x18\x01 \x03(\v2\x1b.shelfshare.books.v1.AuthorR\aauthors\";\n" +
+	"\x13CreateAuthorRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03bio\x18\x02 \x01(\tR\x03bio\"f\n" +
+	"\x13UpdateAuthorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x15\n" +
+	"\x03bio\x18\x03 \x01(\tH\x01R\x03bio\x88\x01\x01B\a\n" +
+	"\x05_nameB\x06\n" +
+	"\x04_bio\"%\n" +
+	"\x13DeleteAuthorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\xb1\x04\n" +
+	"\x0eAuthorsService\x12O\n" +
+	"\tGetAuthor\x12%.shelfshare.books.v1.GetAuthorRequest\x1a\x1b.shelfshare.books.v1.Author\x12l\n" +
+	"\x0fBatchGetAuthors\x12+.shelfshare.books.v1.BatchGetAuthorsRequest\x1a,.shelfshare.books.v1.BatchGetAuthorsResponse\x12`\n" +
+	"\vListAuthors\x12'.shelfshare.books.v1.ListAuthorsRequest\x1a(.shelfshare.books.v1.ListAuthorsResponse\x12U\n" +
+	"\fCreateAuthor\x12(.shelfshare.books.v1.CreateAuthorRequest\x1a\x1b.shelfshare.books.v1.Author\x12U\n" +
+	"\fUpdateAuthor\x12(.shelfshare.books.v1.UpdateAuthorRequest\x1a\x1b.shelfshare.books.v1.Author\x12P\n" +
+	"\fDeleteAuthor\x12(.shelfshare.books.v1.DeleteAuthorRequest\x1a\x16.google.protobuf.EmptyBIZGgithub.com/snnyvrz/shelfshare/apps/books-service/proto/books/v1;booksv1b\x06proto3"
+
+var (
+	file_books_v1_authors_proto_rawDescOnce sync.Once
+	file_books_v1_authors_proto_rawDescData []byte
+)
+
+func file_books_v1_authors_proto_rawDescGZIP() []byte {
+	file_books_v1_authors_proto_rawDescOnce.Do(func() {
+		file_books_v1_authors_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_books_v1_authors_proto_rawDesc), len(file_books_v1_authors_proto_rawDesc)))
+	})
+	return file_books_v1_authors_proto_rawDescData
+}
+
+var file_books_v1_authors_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_books_v1_authors_proto_goTypes = []any{
+	(*Author)(nil),                  // 0: shelfshare.books.v1.Author
+	(*GetAuthorRequest)(nil),        // 1: shelfshare.books.v1.GetAuthorRequest
+	(*BatchGetAuthorsRequest)(nil),  // 2: shelfshare.books.v1.BatchGetAuthorsRequest
+	(*BatchGetAuthorsResponse)(nil), // 3: shelfshare.books.v1.BatchGetAuthorsResponse
+	(*ListAuthorsRequest)(nil),      // 4: shelfshare.books.v1.ListAuthorsRequest
+	(*ListAuthorsResponse)(nil),     // 5: shelfshare.books.v1.ListAuthorsResponse
+	(*CreateAuthorRequest)(nil),     // 6: shelfshare.books.v1.CreateAuthorRequest
+	(*UpdateAuthorRequest)(nil),     // 7: shelfshare.books.v1.UpdateAuthorRequest
+	(*DeleteAuthorRequest)(nil),     // 8: shelfshare.books.v1.DeleteAuthorRequest
+	(*timestamppb.Timestamp)(nil),   // 9: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),           // 10: google.protobuf.Empty
+}
+var file_books_v1_authors_proto_depIdxs = []int32{
+	9,  // 0: shelfshare.books.v1.Author.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: shelfshare.books.v1.Author.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: shelfshare.books.v1.BatchGetAuthorsResponse.authors:type_name -> shelfshare.books.v1.Author
+	0,  // 3: shelfshare.books.v1.ListAuthorsResponse.authors:type_name -> shelfshare.books.v1.Author
+	1,  // 4: shelfshare.books.v1.AuthorsService.GetAuthor:input_type -> shelfshare.books.v1.GetAuthorRequest
+	2,  // 5: shelfshare.books.v1.AuthorsService.BatchGetAuthors:input_type -> shelfshare.books.v1.BatchGetAuthorsRequest
+	4,  // 6: shelfshare.books.v1.AuthorsService.ListAuthors:input_type -> shelfshare.books.v1.ListAuthorsRequest
+	6,  // 7: shelfshare.books.v1.AuthorsService.CreateAuthor:input_type -> shelfshare.books.v1.CreateAuthorRequest
+	7,  // 8: shelfshare.books.v1.AuthorsService.UpdateAuthor:input_type -> shelfshare.books.v1.UpdateAuthorRequest
+	8,  // 9: shelfshare.books.v1.AuthorsService.DeleteAuthor:input_type -> shelfshare.books.v1.DeleteAuthorRequest
+	0,  // 10: shelfshare.books.v1.AuthorsService.GetAuthor:output_type -> shelfshare.books.v1.Author
+	3,  // 11: shelfshare.books.v1.AuthorsService.BatchGetAuthors:output_type -> shelfshare.books.v1.BatchGetAuthorsResponse
+	5,  // 12: shelfshare.books.v1.AuthorsService.ListAuthors:output_type -> shelfshare.books.v1.ListAuthorsResponse
+	0,  // 13: shelfshare.books.v1.AuthorsService.CreateAuthor:output_type -> shelfshare.books.v1.Author
+	0,  // 14: shelfshare.books.v1.AuthorsService.UpdateAuthor:output_type -> shelfshare.books.v1.Author
+	10, // 15: shelfshare.books.v1.AuthorsService.DeleteAuthor:output_type -> google.protobuf.Empty
+	10, // [10:16] is the sub-list for method output_type
+	4,  // [4:10] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_books_v1_authors_proto_init() }
+func file_books_v1_authors_proto_init() {
+	if File_books_v1_authors_proto != nil {
+		return
+	}
+	file_books_v1_authors_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_books_v1_authors_proto_rawDesc), len(file_books_v1_authors_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_books_v1_authors_proto_goTypes,
+		DependencyIndexes: file_books_v1_authors_proto_depIdxs,
+		MessageInfos:      file_books_v1_authors_proto_msgTypes,
+	}.Build()
+	File_books_v1_authors_proto = out.File
+	file_books_v1_authors_proto_goTypes = nil
+	file_books_v1_authors_proto_depIdxs = nil
+}
@@ -0,0 +1,1236 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: books/v1/books.proto
+
+package booksv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Book struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Publication date as YYYY-MM-DD, or empty.
+	PublishedAt  string   `protobuf:"bytes,4,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	AuthorId     string   `protobuf:"bytes,5,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Author       *Author  `protobuf:"bytes,6,opt,name=author,proto3" json:"author,omitempty"`
+	Tags         []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	SeriesId     string   `protobuf:"bytes,8,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	SeriesVolume *float64 `protobuf:"fixed64,9,opt,name=series_volume,json=seriesVolume,proto3,oneof" json:"series_volume,omitempty"`
+	WorkId       string   `protobuf:"bytes,10,opt,name=work_id,json=workId,proto3" json:"work_id,omitempty"`
+	PublisherId  string   `protobuf:"bytes,11,opt,name=publisher_id,json=publisherId,proto3" json:"publisher_id,omitempty"`
+	Format       string   `protobuf:"bytes,12,opt,name=format,proto3" json:"format,omitempty"`
+	Language     string   `protobuf:"bytes,13,opt,name=language,proto3" json:"language,omitempty"`
+	PageCount    *int32   `protobuf:"varint,14,opt,name=page_count,json=pageCount,proto3,oneof" json:"page_count,omitempty"`
+	Isbn         string   `protobuf:"bytes,15,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	// One of public, groups or private.
+	Visibility string `protobuf:"bytes,16,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	// The member who added the book; empty for catalog books.
+	OwnerId         string                 `protobuf:"bytes,17,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	RatingAverage   float64                `protobuf:"fixed64,18,opt,name=rating_average,json=ratingAverage,proto3" json:"rating_average,omitempty"`
+	RatingCount     int64                  `protobuf:"varint,19,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	CopyCount       int64                  `protobuf:"varint,20,opt,name=copy_count,json=copyCount,proto3" json:"copy_count,omitempty"`
+	AvailableCopies int64                  `protobuf:"varint,21,opt,name=available_copies,json=availableCopies,proto3" json:"available_copies,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Book) Reset() {
+	*x = Book{}
+	mi := &file_books_v1_books_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Book) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Book) ProtoMessage() {}
+
+func (x *Book) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_books_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Book.ProtoReflect.Descriptor instead.
+func (*Book) Descriptor() ([]byte, []int) {
+	return file_books_v1_books_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Book) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Book) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Book) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Book) GetPublishedAt() string {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return ""
+}
+
+func (x *Book) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *Book) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Book) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Book) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *Book) GetSeriesVolume() float64 {
+	if x != nil && x.SeriesVolume != nil {
+		return *x.SeriesVolume
+	}
+	return 0
+}
+
+func (x *Book) GetWorkId() string {
+	if x != nil {
+		return x.WorkId
+	}
+	return ""
+}
+
+func (x *Book) GetPublisherId() string {
+	if x != nil {
+		return x.PublisherId
+	}
+	return ""
+}
+
+func (x *Book) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Book) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Book) GetPageCount() int32 {
+	if x != nil && x.PageCount != nil {
+		return *x.PageCount
+	}
+	return 0
+}
+
+func (x *Book) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *Book) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *Book) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Book) GetRatingAverage() float64 {
+	if x != nil {
+		return x.RatingAverage
+	}
+	return 0
+}
+
+func (x *Book) GetRatingCount() int64 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *Book) GetCopyCount() int64 {
+	if x != nil {
+		return x.CopyCount
+	}
+	return 0
+}
+
+func (x *Book) GetAvailableCopies() int64 {
+	if x != nil {
+		return x.AvailableCopies
+	}
+	return 0
+}
+
+func (x *Book) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Book) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	mi := &file_books_v1_books_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_books_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
+func (*GetBookRequest) Descriptor() ([]byte, []int) {
+	return file_books_v1_books_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type BatchGetBooksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most 100 IDs.
+	Ids           []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetBooksRequest) Reset() {
+	*x = BatchGetBooksRequest{}
+	mi := &file_books_v1_books_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetBooksRequest) ProtoMessage() {}
+
+func (x *BatchGetBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_books_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetBooksRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetBooksRequest) Descriptor() ([]byte, []int) {
+	return file_books_v1_books_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchGetBooksRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetBooksResponse) Reset() {
+	*x = BatchGetBooksResponse{}
+	mi := &file_books_v1_books_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetBooksResponse) ProtoMessage() {}
+
+func (x *BatchGetBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_books_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetBooksResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetBooksResponse) Descriptor() ([]byte, []int) {
+	return file_books_v1_books_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetBooksResponse) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+// ListBooksRequest takes the filters of GET /api/books.
+type ListBooksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to 1.
+	Page int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Defaults to 20, at most 100.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// One of created_at_desc, created_at_asc, title_asc, title_desc,
+	// published_at_desc, published_at_asc, series_volume_asc, publisher_asc,
+	// publisher_desc, rating_desc or relevance.
+	Sort  string `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Query string `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
+	Fuzzy bool   `protobuf:"varint,5,opt,name=fuzzy,proto3" json:"fuzzy,omitempty"`
+	// Similarity a fuzzy match needs, from 0 to 1; defaults to 0.5.
+	Similarity  float64 `protobuf:"fixed64,6,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	AuthorId    string  `protobuf:"bytes,7,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	SeriesId    string  `protobuf:"bytes,8,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	WorkId      string  `protobuf:"bytes,9,opt,name=work_id,json=workId,proto3" json:"work_id,omitempty"`
+	PublisherId string  `protobuf:"bytes,10,opt,name=publisher_id,json=publisherId,proto3" json:"publisher_id,omitempty"`
+	// YYYY-MM-DD
+	PublishedAfter string `protobuf:"bytes,11,opt,name=published_after,json=publishedAfter,proto3" json:"published_after,omitempty"`
+	// YYYY-MM-DD
+	PublishedBefore string   `protobuf:"bytes,12,opt,name=published_before,json=publishedBefore,proto3" json:"published_before,omitempty"`
+	Tags            []string `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
+	// any or all; defaults to any.
+	TagsMode    string   `protobuf:"bytes,14,opt,name=tags_mode,json=tagsMode,proto3" json:"tags_mode,omitempty"`
+	ExcludeTags []string `protobuf:"bytes,15,rep,name=exclude_tags,json=excludeTags,proto3" json:"exclude_tags,omitempty"`
+	MinRating   *float64 `protobuf:"fixed64,16,opt,name=min_rating,json=minRating,proto3,oneof" json:"min_rating,omitempty"`
+	Available   bool     `protobuf:"varint,17,opt,name=available,proto3" json:"available,omitempty"`
+	// One of the caller's shelves, by ID or slug; requires a caller.
+	Shelf string `protobuf:"bytes,18,opt,name=shelf,proto3" json:"shelf,omitempty"`
+	// A group's combined library; requires a caller who is a member.
+	GroupId       string `protobuf:"bytes,19,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksRequest) Reset() {
+	*x = ListBooksRequest{}
+	mi := &file_books_v1_books_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksRequest) ProtoMessage() {}
+
+func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_books_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksRequest.ProtoReflect.Descriptor instead.
+func (*ListBooksRequest) Descriptor() ([]byte, []int) {
+	return file_books_v1_books_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListBooksRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetFuzzy() bool {
+	if x != nil {
+		return x.Fuzzy
+	}
+	return false
+}
+
+func (x *ListBooksRequest) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetWorkId() string {
+	if x != nil {
+		return x.WorkId
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetPublisherId() string {
+	if x != nil {
+		return x.PublisherId
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetPublishedAfter() string {
+	if x != nil {
+		return x.PublishedAfter
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetPublishedBefore() string {
+	if x != nil {
+		return x.PublishedBefore
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListBooksRequest) GetTagsMode() string {
+	if x != nil {
+		return x.TagsMode
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetExcludeTags() []string {
+	if x != nil {
+		return x.ExcludeTags
+	}
+	return nil
+}
+
+func (x *ListBooksRequest) GetMinRating() float64 {
+	if x != nil && x.MinRating != nil {
+		return *x.MinRating
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *ListBooksRequest) GetShelf() string {
+	if x != nil {
+		return x.Shelf
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+type ListBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksResponse) Reset() {
+	*x = ListBooksResponse{}
+	mi := &file_books_v1_books_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksResponse) ProtoMessage() {}
+
+func (x *ListBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_books_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksResponse.ProtoReflect.Descriptor instead.
+func (*ListBooksResponse) Descriptor() ([]byte, []int) {
+	return file_books_v1_books_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListBooksResponse) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+func (x *ListBooksResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListBooksResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBooksResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type CreateBookRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	AuthorId    string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// YYYY-MM-DD
+	PublishedAt   string   `protobuf:"bytes,4,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	Tags          []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	SeriesId      string   `protobuf:"bytes,6,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	SeriesVolume  *float64 `protobuf:"fixed64,7,opt,name=series_volume,json=seriesVolume,proto3,oneof" json:"series_volume,omitempty"`
+	WorkId        string   `protobuf:"bytes,8,opt,name=work_id,json=workId,proto3" json:"work_id,omitempty"`
+	Format        string   `protobuf:"bytes,9,opt,name=format,proto3" json:"format,omitempty"`
+	Language      string   `protobuf:"bytes,10,opt,name=language,proto3" json:"language,omitempty"`
+	PublisherId   string   `protobuf:"bytes,11,opt,name=publisher_id,json=publisherId,proto3" json:"publisher_id,omitempty"`
+	PageCount     *int32   `protobuf:"varint,12,opt,name=page_count,json=pageCount,proto3,oneof" json:"page_count,omitempty"`
+	Isbn          string   `protobuf:"bytes,13,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	Visibility    string   `protobuf:"bytes,14,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBookRequest) Reset() {
+	*x = CreateBookRequest{}
+	mi := &file_books_v1_books_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBookRequest) ProtoMessage() {}
+
+func (x *CreateBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_books_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBookRequest.ProtoReflect.Descriptor instead.
+func (*CreateBookRequest) Descriptor() ([]byte, []int) {
+	return file_books_v1_books_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateBookRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetPublishedAt() string {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateBookRequest) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetSeriesVolume() float64 {
+	if x != nil && x.SeriesVolume != nil {
+		return *x.SeriesVolume
+	}
+	return 0
+}
+
+func (x *CreateBookRequest) GetWorkId() string {
+	if x != nil {
+		return x.WorkId
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetPublisherId() string {
+	if x != nil {
+		return x.PublisherId
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetPageCount() int32 {
+	if x != nil && x.PageCount != nil {
+		return *x.PageCount
+	}
+	return 0
+}
+
+func (x *CreateBookRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+// UpdateBookRequest sets the fields that are present. An empty published_at,
+// series_id, work_id, publisher_id or isbn clears it.
+type UpdateBookRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	AuthorId    *string                `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3,oneof" json:"author_id,omitempty"`
+	Description *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	PublishedAt *string                `protobuf:"bytes,5,opt,name=published_at,json=publishedAt,proto3,oneof" json:"published_at,omitempty"`
+	// Replaces the tags when update_tags is set, so they can be cleared.
+	Tags          []string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	UpdateTags    bool     `protobuf:"varint,7,opt,name=update_tags,json=updateTags,proto3" json:"update_tags,omitempty"`
+	SeriesId      *string  `protobuf:"bytes,8,opt,name=series_id,json=seriesId,proto3,oneof" json:"series_id,omitempty"`
+	SeriesVolume  *float64 `protobuf:"fixed64,9,opt,name=series_volume,json=seriesVolume,proto3,oneof" json:"series_volume,omitempty"`
+	WorkId        *string  `protobuf:"bytes,10,opt,name=work_id,json=workId,proto3,oneof" json:"work_id,omitempty"`
+	Format        *string  `protobuf:"bytes,11,opt,name=format,proto3,oneof" json:"format,omitempty"`
+	Language      *string  `protobuf:"bytes,12,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	PublisherId   *string  `protobuf:"bytes,13,opt,name=publisher_id,json=publisherId,proto3,oneof" json:"publisher_id,omitempty"`
+	PageCount     *int32   `protobuf:"varint,14,opt,name=page_count,json=pageCount,proto3,oneof" json:"page_count,omitempty"`
+	Isbn          *string  `protobuf:"bytes,15,opt,name=isbn,proto3,oneof" json:"isbn,omitempty"`
+	Visibility    *string  `protobuf:"bytes,16,opt,name=visibility,proto3,oneof" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBookRequest) Reset() {
+	*x = UpdateBookRequest{}
+	mi := &file_books_v1_books_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookRequest) ProtoMessage() {}
+
+func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_books_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBookRequest) Descriptor() ([]byte, []int) {
+	return file_books_v1_books_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetAuthorId() string {
+	if x != nil && x.AuthorId != nil {
+		return *x.AuthorId
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetPublishedAt() string {
+	if x != nil && x.PublishedAt != nil {
+		return *x.PublishedAt
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateBookRequest) GetUpdateTags() bool {
+	if x != nil {
+		return x.UpdateTags
+	}
+	return false
+}
+
+func (x *UpdateBookRequest) GetSeriesId() string {
+	if x != nil && x.SeriesId != nil {
+		return *x.SeriesId
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetSeriesVolume() float64 {
+	if x != nil && x.SeriesVolume != nil {
+		return *x.SeriesVolume
+	}
+	return 0
+}
+
+func (x *UpdateBookRequest) GetWorkId() string {
+	if x != nil && x.WorkId != nil {
+		return *x.WorkId
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetFormat() string {
+	if x != nil && x.Format != nil {
+		return *x.Format
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetPublisherId() string {
+	if x != nil && x.PublisherId != nil {
+		return *x.PublisherId
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetPageCount() int32 {
+	if x != nil && x.PageCount != nil {
+		return *x.PageCount
+	}
+	return 0
+}
+
+func (x *UpdateBookRequest) GetIsbn() string {
+	if x != nil && x.Isbn != nil {
+		return *x.Isbn
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetVisibility() string {
+	if x != nil && x.Visibility != nil {
+		return *x.Visibility
+	}
+	return ""
+}
+
+type DeleteBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBookRequest) Reset() {
+	*x = DeleteBookRequest{}
+	mi := &file_books_v1_books_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBookRequest) ProtoMessage() {}
+
+func (x *DeleteBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_books_v1_books_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBookRequest) Descriptor() ([]byte, []int) {
+	return file_books_v1_books_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_books_v1_books_proto protoreflect.FileDescriptor
+
+const file_books_v1_books_proto_rawDesc = "" +
+	"\n" +
+	"\x14books/v1/books.proto\x12\x13shelfshare.books.v1\x1a\x16books/v1/authors.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xac\x06\n" +
+	"\x04Book\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12!\n" +
+	"\fpublished_at\x18\x04 \x01(\tR\vpublishedAt\x12\x1b\n" +
+	"\tauthor_id\x18\x05 \x01(\tR\bauthorId\x123\n" +
+	"\x06author\x18\x06 \x01(\v2\x1b.shelfshare.books.v1.AuthorR\x06author\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x1b\n" +
+	"\tseries_id\x18\b \x01(\tR\bseriesId\x12(\n" +
+	"\rseries_volume\x18\t \x01(\x01H\x00R\fseriesVolume\x88\x01\x01\x12\x17\n" +
+	"\awork_id\x18\n" +
+	" \x01(\tR\x06workId\x12!\n" +
+	"\fpublisher_id\x18\v \x01(\tR\vpublisherId\x12\x16\n" +
+	"\x06format\x18\f \x01(\tR\x06format\x12\x1a\n" +
+	"\blanguage\x18\r \x01(\tR\blanguage\x12\"\n" +
+	"\n" +
+	"page_count\x18\x0e \x01(\x05H\x01R\tpageCount\x88\x01\x01\x12\x12\n" +
+	"\x04isbn\x18\x0f \x01(\tR\x04isbn\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\x10 \x01(\tR\n" +
+	"visibility\x12\x19\n" +
+	"\bowner_id\x18\x11 \x01(\tR\aownerId\x12%\n" +
+	"\x0erating_average\x18\x12 \x01(\x01R\rratingAverage\x12!\n" +
+	"\frating_count\x18\x13 \x01(\x03R\vratingCount\x12\x1d\n" +
+	"\n" +
+	"copy_count\x18\x14 \x01(\x03R\tcopyCount\x12)\n" +
+	"\x10available_copies\x18\x15 \x01(\x03R\x0favailableCopies\x129\n" +
+	"\n" +
+	"created_at\x18\x16 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x10\n" +
+	"\x0e_series_volumeB\r\n" +
+	"\v_page_count\" \n" +
+	"\x0eGetBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"(\n" +
+	"\x14BatchGetBooksRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"H\n" +
+	"\x15BatchGetBooksResponse\x12/\n" +
+	"\x05books\x18\x01 \x03(\v2\x19.shelfshare.books.v1.BookR\x05books\"\xc3\x04\n" +
+	"\x10ListBooksRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x14\n" +
+	"\x05fuzzy\x18\x05 \x01(\bR\x05fuzzy\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x06 \x01(\x01R\n" +
+	"similarity\x12\x1b\n" +
+	"\tauthor_id\x18\a \x01(\tR\bauthorId\x12\x1b\n" +
+	"\tseries_id\x18\b \x01(\tR\bseriesId\x12\x17\n" +
+	"\awork_id\x18\t \x01(\tR\x06workId\x12!\n" +
+	"\fpublisher_id\x18\n" +
+	" \x01(\tR\vpublisherId\x12'\n" +
+	"\x0fpublished_after\x18\v \x01(\tR\x0epublishedAfter\x12)\n" +
+	"\x10published_before\x18\f \x01(\tR\x0fpublishedBefore\x12\x12\n" +
+	"\x04tags\x18\r \x03(\tR\x04tags\x12\x1b\n" +
+	"\ttags_mode\x18\x0e \x01(\tR\btagsMode\x12!\n" +
+	"\fexclude_tags\x18\x0f \x03(\tR\vexcludeTags\x12\"\n" +
+	"\n" +
+	"min_rating\x18\x10 \x01(\x01H\x00R\tminRating\x88\x01\x01\x12\x1c\n" +
+	"\tavailable\x18\x11 \x01(\bR\tavailable\x12\x14\n" +
+	"\x05shelf\x18\x12 \x01(\tR\x05shelf\x12\x19\n" +
+	"\bgroup_id\x18\x13 \x01(\tR\agroupIdB\r\n" +
+	"\v_min_rating\"\x8b\x01\n" +
+	"\x11ListBooksResponse\x12/\n" +
+	"\x05books\x18\x01 \x03(\v2\x19.shelfshare.books.v1.BookR\x05books\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xcf\x03\n" +
+	"\x11CreateBookRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12!\n" +
+	"\fpublished_at\x18\x04 \x01(\tR\vpublishedAt\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1b\n" +
+	"\tseries_id\x18\x06 \x01(\tR\bseriesId\x12(\n" +
+	"\rseries_volume\x18\a \x01(\x01H\x00R\fseriesVolume\x88\x01\x01\x12\x17\n" +
+	"\awork_id\x18\b \x01(\tR\x06workId\x12\x16\n" +
+	"\x06format\x18\t \x01(\tR\x06format\x12\x1a\n" +
+	"\blanguage\x18\n" +
+	" \x01(\tR\blanguage\x12!\n" +
+	"\fpublisher_id\x18\v \x01(\tR\vpublisherId\x12\"\n" +
+	"\n" +
+	"page_count\x18\f \x01(\x05H\x01R\tpageCount\x88\x01\x01\x12\x12\n" +
+	"\x04isbn\x18\r \x01(\tR\x04isbn\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\x0e \x01(\tR\n" +
+	"visibilityB\x10\n" +
+	"\x0e_series_volumeB\r\n" +
+	"\v_page_count\"\xcb\x05\n" +
+	"\x11UpdateBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12 \n" +
+	"\tauthor_id\x18\x03 \x01(\tH\x01R\bauthorId\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x02R\vdescription\x88\x01\x01\x12&\n" +
+	"\fpublished_at\x18\x05 \x01(\tH\x03R\vpublishedAt\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x1f\n" +
+	"\vupdate_tags\x18\a \x01(\bR\n" +
+	"updateTags\x12 \n" +
+	"\tseries_id\x18\b \x01(\tH\x04R\bseriesId\x88\x01\x01\x12(\n" +
+	"\rseries_volume\x18\t \x01(\x01H\x05R\fseriesVolume\x88\x01\x01\x12\x1c\n" +
+	"\awork_id\x18\n" +
+	" \x01(\tH\x06R\x06workId\x88\x01\x01\x12\x1b\n" +
+	"\x06format\x18\v \x01(\tH\aR\x06format\x88\x01\x01\x12\x1f\n" +
+	"\blanguage\x18\f \x01(\tH\bR\blanguage\x88\x01\x01\x12&\n" +
+	"\fpublisher_id\x18\r \x01(\tH\tR\vpublisherId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_count\x18\x0e \x01(\x05H\n" +
+	"R\tpageCount\x88\x01\x01\x12\x17\n" +
+	"\x04isbn\x18\x0f \x01(\tH\vR\x04isbn\x88\x01\x01\x12#\n" +
+	"\n" +
+	"visibility\x18\x10 \x01(\tH\fR\n" +
+	"visibility\x88\x01\x01B\b\n" +
+	"\x06_titleB\f\n" +
+	"\n" +
+	"_author_idB\x0e\n" +
+	"\f_descriptionB\x0f\n" +
+	"\r_published_atB\f\n" +
+	"\n" +
+	"_series_idB\x10\n" +
+	"\x0e_series_volumeB\n" +
+	"\n" +
+	"\b_work_idB\t\n" +
+	"\a_formatB\v\n" +
+	"\t_languageB\x0f\n" +
+	"\r_publisher_idB\r\n" +
+	"\v_page_countB\a\n" +
+	"\x05_isbnB\r\n" +
+	"\v_visibility\"#\n" +
+	"\x11DeleteBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\x8d\x04\n" +
+	"\fBooksService\x12I\n" +
+	"\aGetBook\x12#.shelfshare.books.v1.GetBookRequest\x1a\x19.shelfshare.books.v1.Book\x12f\n" +
+	"\rBatchGetBooks\x12).shelfshare.books.v1.BatchGetBooksRequest\x1a*.shelfshare.books.v1.BatchGetBooksResponse\x12Z\n" +
+	"\tListBooks\x12%.shelfshare.books.v1.ListBooksRequest\x1a&.shelfshare.books.v1.ListBooksResponse\x12O\n" +
+	"\n" +
+	"CreateBook\x12&.shelfshare.books.v1.CreateBookRequest\x1a\x19.shelfshare.books.v1.Book\x12O\n" +
+	"\n" +
+	"UpdateBook\x12&.shelfshare.books.v1.UpdateBookRequest\x1a\x19.shelfshare.books.v1.Book\x12L\n" +
+	"\n" +
+	"DeleteBook\x12&.shelfshare.books.v1.DeleteBookRequest\x1a\x16.google.protobuf.EmptyBIZGgithub.com/snnyvrz/shelfshare/apps/books-service/proto/books/v1;booksv1b\x06proto3"
+
+var (
+	file_books_v1_books_proto_rawDescOnce sync.Once
+	file_books_v1_books_proto_rawDescData []byte
+)
+
+func file_books_v1_books_proto_rawDescGZIP() []byte {
+	file_books_v1_books_proto_rawDescOnce.Do(func() {
+		file_books_v1_books_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_books_v1_books_proto_rawDesc), len(file_books_v1_books_proto_rawDesc)))
+	})
+	return file_books_v1_books_proto_rawDescData
+}
+
+var file_books_v1_books_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_books_v1_books_proto_goTypes = []any{
+	(*Book)(nil),                  // 0: shelfshare.books.v1.Book
+	(*GetBookRequest)(nil),        // 1: shelfshare.books.v1.GetBookRequest
+	(*BatchGetBooksRequest)(nil),  // 2: shelfshare.books.v1.BatchGetBooksRequest
+	(*BatchGetBooksResponse)(nil), // 3: shelfshare.books.v1.BatchGetBooksResponse
+	(*ListBooksRequest)(nil),      // 4: shelfshare.books.v1.ListBooksRequest
+	(*ListBooksResponse)(nil),     // 5: shelfshare.books.v1.ListBooksResponse
+	(*CreateBookRequest)(nil),     // 6: shelfshare.books.v1.CreateBookRequest
+	(*UpdateBookRequest)(nil),     // 7: shelfshare.books.v1.UpdateBookRequest
+	(*DeleteBookRequest)(nil),     // 8: shelfshare.books.v1.DeleteBookRequest
+	(*Author)(nil),                // 9: shelfshare.books.v1.Author
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 11: google.protobuf.Empty
+}
+var file_books_v1_books_proto_depIdxs = []int32{
+	9,  // 0: shelfshare.books.v1.Book.author:type_name -> shelfshare.books.v1.Author
+	10, // 1: shelfshare.books.v1.Book.created_at:type_name -> google.protobuf.Timestamp
+	10, // 2: shelfshare.books.v1.Book.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: shelfshare.books.v1.BatchGetBooksResponse.books:type_name -> shelfshare.books.v1.Book
+	0,  // 4: shelfshare.books.v1.ListBooksResponse.books:type_name -> shelfshare.books.v1.Book
+	1,  // 5: shelfshare.books.v1.BooksService.GetBook:input_type -> shelfshare.books.v1.GetBookRequest
+	2,  // 6: shelfshare.books.v1.BooksService.BatchGetBooks:input_type -> shelfshare.books.v1.BatchGetBooksRequest
+	4,  // 7: shelfshare.books.v1.BooksService.ListBooks:input_type -> shelfshare.books.v1.ListBooksRequest
+	6,  // 8: shelfshare.books.v1.BooksService.CreateBook:input_type -> shelfshare.books.v1.CreateBookRequest
+	7,  // 9: shelfshare.books.v1.BooksService.UpdateBook:input_type -> shelfshare.books.v1.UpdateBookRequest
+	8,  // 10: shelfshare.books.v1.BooksService.DeleteBook:input_type -> shelfshare.books.v1.DeleteBookRequest
+	0,  // 11: shelfshare.books.v1.BooksService.GetBook:output_type -> shelfshare.books.v1.Book
+	3,  // 12: shelfshare.books.v1.BooksService.BatchGetBooks:output_type -> shelfshare.books.v1.BatchGetBooksResponse
+	5,  // 13: shelfshare.books.v1.BooksService.ListBooks:output_type -> shelfshare.books.v1.ListBooksResponse
+	0,  // 14: shelfshare.books.v1.BooksService.CreateBook:output_type -> shelfshare.books.v1.Book
+	0,  // 15: shelfshare.books.v1.BooksService.UpdateBook:output_type -> shelfshare.books.v1.Book
+	11, // 16: shelfshare.books.v1.BooksService.DeleteBook:output_type -> google.protobuf.Empty
+	11, // [11:17] is the sub-list for method output_type
+	5,  // [5:11] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_books_v1_books_proto_init() }
+func file_books_v1_books_proto_init() {
+	if File_books_v1_books_proto != nil {
+		return
+	}
+	file_books_v1_authors_proto_init()
+	file_books_v1_books_proto_msgTypes[0].OneofWrappers = []any{}
+	file_books_v1_books_proto_msgTypes[4].OneofWrappers = []any{}
+	file_books_v1_books_proto_msgTypes[6].OneofWrappers = []any{}
+	file_books_v1_books_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_books_v1_books_proto_rawDesc), len(file_books_v1_books_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_books_v1_books_proto_goTypes,
+		DependencyIndexes: file_books_v1_books_proto_depIdxs,
+		MessageInfos:      file_books_v1_books_proto_msgTypes,
+	}.Build()
+	File_books_v1_books_proto = out.File
+	file_books_v1_books_proto_goTypes = nil
+	file_books_v1_books_proto_depIdxs = nil
+}